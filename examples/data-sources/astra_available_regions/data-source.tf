@@ -1,2 +1,7 @@
 data "astra_available_regions" "regions" {
 }
+
+data "astra_available_regions" "vector_aws" {
+  cloud_provider = "aws"
+  vector_only    = true
+}
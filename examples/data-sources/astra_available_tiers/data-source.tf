@@ -0,0 +1,4 @@
+data "astra_available_tiers" "tiers" {
+  cloud_provider = "aws"
+  tier_type      = "serverless"
+}
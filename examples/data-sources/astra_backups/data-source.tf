@@ -0,0 +1,4 @@
+data "astra_backups" "backups" {
+  database_id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  status      = "COMPLETED"
+}
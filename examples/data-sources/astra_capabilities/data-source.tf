@@ -0,0 +1,15 @@
+data "astra_capabilities" "useast1" {
+  cloud_provider = "gcp"
+  region         = "us-east1"
+}
+
+# Only enable CDC where it is available
+resource "astra_cdc" "cdc" {
+  count            = data.astra_capabilities.useast1.cdc ? 1 : 0
+  database_id      = "5b70892f-e01a-4595-98e6-19ecc9985d50"
+  database_name    = "sai_test"
+  table            = "test"
+  keyspace         = "sai_test"
+  topic_partitions = 3
+  tenant_name      = "terraformtest"
+}
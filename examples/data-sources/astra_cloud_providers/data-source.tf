@@ -0,0 +1,6 @@
+data "astra_cloud_providers" "providers" {
+}
+
+output "streaming_cloud_providers" {
+  value = [for p in data.astra_cloud_providers.providers.results : p.cloud_provider if p.streaming_supported]
+}
@@ -0,0 +1,19 @@
+# Serverless database running the whole month
+data "astra_cost_estimate" "database" {
+  cloud_provider = "gcp"
+  region         = "us-east1"
+}
+
+# Streaming tenant with its expected traffic
+data "astra_cost_estimate" "streaming" {
+  product              = "streaming"
+  cloud_provider       = "gcp"
+  region               = "us-east1"
+  read_gb_per_month    = 500
+  written_gb_per_month = 100
+  network_gb_per_month = 600
+}
+
+output "monthly_cost_dollars" {
+  value = (data.astra_cost_estimate.database.monthly_cost_cents + data.astra_cost_estimate.streaming.monthly_cost_cents) / 100
+}
@@ -0,0 +1,10 @@
+variable "organization_id" {}
+
+data "astra_current_token_info" "current" {
+  lifecycle {
+    postcondition {
+      condition     = self.organization_id == var.organization_id
+      error_message = "The Astra token belongs to organization ${self.organization_name}, not ${var.organization_id}."
+    }
+  }
+}
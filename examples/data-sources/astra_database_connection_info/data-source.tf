@@ -0,0 +1,13 @@
+data "astra_database_connection_info" "db" {
+  database_id = "8d356587-73b3-430a-9c0e-d780332e2afb"
+  region      = "us-east1"
+}
+
+output "data_api_endpoint" {
+  value = data.astra_database_connection_info.db.data_api_endpoint
+}
+
+output "secure_connect_bundle_url" {
+  value     = data.astra_database_connection_info.db.secure_connect_bundle_url
+  sensitive = true
+}
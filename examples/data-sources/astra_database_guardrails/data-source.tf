@@ -0,0 +1,14 @@
+data "astra_database_guardrails" "db" {
+  database_id = "8d356587-73b3-430a-9c0e-d780332e2afb"
+}
+
+locals {
+  columns = ["id", "name", "email"]
+}
+
+check "columns_per_table" {
+  assert {
+    condition     = length(local.columns) <= data.astra_database_guardrails.db.max_columns_per_table
+    error_message = "Too many columns for an Astra table."
+  }
+}
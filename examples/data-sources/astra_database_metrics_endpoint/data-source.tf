@@ -0,0 +1,3 @@
+data "astra_database_metrics_endpoint" "db" {
+  database_id = "8d356587-73b3-430a-9c0e-d780332e2afb"
+}
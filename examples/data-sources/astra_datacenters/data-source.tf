@@ -0,0 +1,3 @@
+data "astra_datacenters" "dcs" {
+  database_id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+}
@@ -0,0 +1,4 @@
+data "astra_organization_limits" "limits" {
+  cloud_provider = "gcp"
+  region         = "us-east1"
+}
@@ -0,0 +1,4 @@
+data "astra_private_link_allowed_principals" "principals" {
+  database_id   = "a6bc9c26-e7ce-424f-84c7-0a00afb12588"
+  datacenter_id = "a6bc9c26-e7ce-424f-84c7-0a00afb12588-1"
+}
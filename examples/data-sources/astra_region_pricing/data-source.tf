@@ -0,0 +1,7 @@
+data "astra_region_pricing" "all" {
+}
+
+data "astra_region_pricing" "aws_streaming" {
+  product        = "streaming"
+  cloud_provider = "aws"
+}
@@ -0,0 +1,19 @@
+data "astra_secure_connect_bundle_urls" "scbs" {
+  database_id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+}
+
+// Pick the bundle of a given region using the datacenters map of the database
+resource "astra_database" "mydb" {
+  name           = "dbname"
+  keyspace       = "testks"
+  cloud_provider = "gcp"
+  regions        = ["us-west4", "us-east4"]
+}
+
+data "astra_secure_connect_bundle_urls" "mydb" {
+  database_id = astra_database.mydb.id
+}
+
+output "us_east4_scb_url" {
+  value = data.astra_secure_connect_bundle_urls.mydb.urls[astra_database.mydb.datacenters["${astra_database.mydb.cloud_provider}.us-east4"]]
+}
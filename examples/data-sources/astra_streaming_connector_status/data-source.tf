@@ -0,0 +1,14 @@
+data "astra_streaming_connector_status" "sink" {
+  tenant_name  = astra_streaming_sink.streaming_sink.tenant_name
+  cluster_name = "pulsar-gcp-useast1"
+  namespace    = astra_streaming_sink.streaming_sink.namespace
+  name         = astra_streaming_sink.streaming_sink.sink_name
+}
+
+# Check that the sink started after apply
+check "sink_running" {
+  assert {
+    condition     = data.astra_streaming_connector_status.sink.running
+    error_message = "Sink is not running: ${data.astra_streaming_connector_status.sink.last_error}"
+  }
+}
@@ -0,0 +1,16 @@
+data "astra_streaming_connectors" "connectors" {
+  tenant_name  = "mytenant"
+  cluster_name = "pulsar-gcp-useast4"
+}
+
+resource "astra_streaming_sink" "sink" {
+  # ...
+  sink_name = "jdbc-clickhouse"
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.astra_streaming_connectors.connectors.sinks[*].name, "jdbc-clickhouse")
+      error_message = "The jdbc-clickhouse sink is not available on the cluster."
+    }
+  }
+}
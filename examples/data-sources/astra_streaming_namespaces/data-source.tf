@@ -0,0 +1,4 @@
+data "astra_streaming_namespaces" "namespaces" {
+  tenant_name  = "mytenant"
+  cluster_name = "pulsar-gcp-useast4"
+}
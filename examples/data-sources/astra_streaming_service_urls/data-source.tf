@@ -0,0 +1,11 @@
+data "astra_streaming_service_urls" "urls" {
+  tenant_name = "mytenant"
+}
+
+output "pulsar_broker_url" {
+  value = data.astra_streaming_service_urls.urls.broker_service_url
+}
+
+output "pulsar_web_socket_url" {
+  value = data.astra_streaming_service_urls.urls.web_socket_url
+}
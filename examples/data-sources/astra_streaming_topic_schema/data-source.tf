@@ -0,0 +1,5 @@
+# Schema generated for the data topic of a CDC table
+data "astra_streaming_topic_schema" "cdc" {
+  cluster_name = "pulsar-gcp-useast4"
+  topic        = astra_cdc.cdc.data_topic
+}
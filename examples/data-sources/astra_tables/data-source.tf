@@ -0,0 +1,5 @@
+data "astra_tables" "tables" {
+  database_id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  region      = "us-east-1"
+  keyspace    = "keyspace_name"
+}
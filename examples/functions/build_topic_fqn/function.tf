@@ -0,0 +1,3 @@
+output "orders_topic" {
+  value = provider::astra::build_topic_fqn(astra_streaming_tenant.example.tenant_name, "default", "orders")
+}
@@ -0,0 +1,3 @@
+output "cluster_name" {
+  value = provider::astra::pulsar_cluster_name("gcp", "us-east1")
+}
@@ -10,7 +10,9 @@ resource "astra_access_list" "example" {
     enabled = true
   }
   addresses {
-    address = "0.0.0.3/0"
-    enabled = true
+    address     = "0.0.0.3/0"
+    enabled     = true
+    description = "Temporary access for the migration"
+    expires_at  = "2030-01-01T00:00:00Z"
   }
 }
\ No newline at end of file
@@ -0,0 +1,9 @@
+import {
+  to = astra_cdc.example
+  identity = {
+    database_id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+    keyspace    = "example"
+    table       = "test"
+    tenant_name = "terraformtest"
+  }
+}
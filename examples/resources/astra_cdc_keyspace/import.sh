@@ -0,0 +1,3 @@
+# the import id is databaseId/keyspace/tenantName, optionally followed by /table1,table2 to only import these tables
+terraform import astra_cdc_keyspace.example databaseId/keyspace/tenantName
+terraform import astra_cdc_keyspace.example databaseId/keyspace/tenantName/table1,table2
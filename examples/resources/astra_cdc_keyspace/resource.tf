@@ -0,0 +1,17 @@
+resource "astra_streaming_tenant" "streaming_tenant-1" {
+  tenant_name    = "terraformtest"
+  topic          = "terraformtest"
+  region         = "useast-4"
+  cloud_provider = "gcp"
+  user_email     = "seb@datastax.com"
+}
+
+# Enable CDC for every table of the keyspace, except the audit table
+resource "astra_cdc_keyspace" "sai_test" {
+  database_id      = "5b70892f-e01a-4595-98e6-19ecc9985d50"
+  database_name    = "sai_test"
+  keyspace         = "sai_test"
+  topic_partitions = 3
+  tenant_name      = astra_streaming_tenant.streaming_tenant-1.tenant_name
+  exclude_tables   = ["audit"]
+}
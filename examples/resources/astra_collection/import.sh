@@ -0,0 +1,2 @@
+# the import id includes the database_id, namespace, and collection name.
+terraform import astra_collection.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/default_keyspace/products
\ No newline at end of file
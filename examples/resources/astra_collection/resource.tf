@@ -0,0 +1,13 @@
+resource "astra_collection" "products" {
+  database_id      = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+  region           = "us-east1"
+  namespace        = "default_keyspace"
+  name             = "products"
+  vector_dimension = 1536
+  vector_metric    = "cosine"
+  vectorize {
+    provider     = "openai"
+    model_name   = "text-embedding-3-small"
+    provider_key = "my_openai_key"
+  }
+}
@@ -0,0 +1,2 @@
+# the import id includes the database_id and namespace name.
+terraform import astra_data_api_namespace.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/namespace/app_documents
\ No newline at end of file
@@ -0,0 +1,5 @@
+resource "astra_data_api_namespace" "app" {
+  database_id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+  region      = "us-east1"
+  name        = "app_documents"
+}
@@ -0,0 +1,6 @@
+import {
+  to = astra_database.example
+  identity = {
+    id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+  }
+}
@@ -4,3 +4,47 @@ resource "astra_database" "example" {
   cloud_provider = "gcp"
   regions        = ["us-east1"]
 }
+
+resource "astra_database" "multi_region" {
+  name              = "name"
+  keyspace          = "keyspace"
+  cloud_provider    = "gcp"
+  primary_region    = "us-east1"
+  regions           = ["us-east1", "us-west1"]
+  preferred_regions = ["us-west1", "us-east1"]
+}
+
+resource "astra_database" "classic" {
+  name           = "name"
+  keyspace       = "keyspace"
+  cloud_provider = "aws"
+  regions        = ["us-east-1"]
+  tier           = "C10"
+  capacity_units = 2
+}
+
+resource "astra_database" "vector" {
+  name              = "name"
+  keyspace          = "keyspace"
+  cloud_provider    = "gcp"
+  regions           = ["us-east1"]
+  db_type           = "vector"
+  wait_for_data_api = true
+}
+
+# Refresh a staging database from the latest backup of production
+data "astra_backups" "production" {
+  database_id = "a6bc9c26-e7ce-424f-84c7-0a00afb12588"
+  status      = "COMPLETED"
+}
+
+resource "astra_database" "staging" {
+  name           = "staging"
+  keyspace       = "keyspace"
+  cloud_provider = "gcp"
+  regions        = ["us-east1"]
+  restore_from {
+    database_id = data.astra_backups.production.database_id
+    backup_id   = data.astra_backups.production.results[0].backup_id
+  }
+}
@@ -0,0 +1,7 @@
+import {
+  to = astra_keyspace.example
+  identity = {
+    database_id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+    name        = "example"
+  }
+}
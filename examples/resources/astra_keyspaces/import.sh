@@ -0,0 +1,3 @@
+# the import id is the database_id followed by /keyspaces/ and the names of the imported keyspaces, separated by commas.
+# Only list keyspaces which are not managed by astra_keyspace resources, since they are dropped when astra_keyspaces is destroyed.
+terraform import astra_keyspaces.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/keyspaces/tenant_a,tenant_b
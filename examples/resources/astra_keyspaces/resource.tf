@@ -0,0 +1,4 @@
+resource "astra_keyspaces" "example" {
+  database_id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+  names       = ["tenant_a", "tenant_b", "tenant_c"]
+}
\ No newline at end of file
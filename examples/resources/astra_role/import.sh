@@ -1 +1,4 @@
-terraform import astra_role.example role-id
\ No newline at end of file
+terraform import astra_role.example role-id
+
+# Import a role by its name
+terraform import astra_role.example name/role-name
@@ -0,0 +1 @@
+terraform import astra_streaming_astra_db_sink.example tenant_name/namespace/astra-db
@@ -0,0 +1,15 @@
+resource "astra_streaming_astra_db_sink" "example" {
+  tenant_name    = "my-tenant"
+  cloud_provider = "gcp"
+  region         = "us-east1"
+  namespace      = "astracdc"
+  topic          = astra_cdc.example.data_topic
+  database_id    = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  keyspace       = "replica"
+  table          = "users"
+  mapping = {
+    id    = "key.id"
+    name  = "value.name"
+    email = "value.email"
+  }
+}
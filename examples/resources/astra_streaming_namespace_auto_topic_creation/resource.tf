@@ -0,0 +1,24 @@
+resource "astra_streaming_tenant" "example" {
+  tenant_name    = "terraformtest"
+  cloud_provider = "gcp"
+  region         = "useast-4"
+  user_email     = "someuser@example.com"
+}
+
+# Only allow the topics created by Terraform in the default namespace
+resource "astra_streaming_namespace_auto_topic_creation" "default" {
+  tenant_name               = astra_streaming_tenant.example.tenant_name
+  cluster_name              = astra_streaming_tenant.example.cluster_name
+  namespace                 = "default"
+  allow_auto_topic_creation = false
+}
+
+# Create partitioned topics with 4 partitions on first use in the sandbox namespace
+resource "astra_streaming_namespace_auto_topic_creation" "sandbox" {
+  tenant_name               = astra_streaming_tenant.example.tenant_name
+  cluster_name              = astra_streaming_tenant.example.cluster_name
+  namespace                 = "sandbox"
+  allow_auto_topic_creation = true
+  topic_type                = "partitioned"
+  default_num_partitions    = 4
+}
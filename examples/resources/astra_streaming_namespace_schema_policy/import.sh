@@ -0,0 +1,2 @@
+# the import id is tenant_name/cluster_name/namespace
+terraform import astra_streaming_namespace_schema_policy.default terraformtest/pulsar-gcp-useast4/default
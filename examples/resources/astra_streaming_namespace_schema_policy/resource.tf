@@ -0,0 +1,15 @@
+resource "astra_streaming_tenant" "example" {
+  tenant_name    = "terraformtest"
+  cloud_provider = "gcp"
+  region         = "useast-4"
+  user_email     = "someuser@example.com"
+}
+
+# Producers can only register schemas which can read the data of the previous schema
+resource "astra_streaming_namespace_schema_policy" "default" {
+  tenant_name                   = astra_streaming_tenant.example.tenant_name
+  cluster_name                  = astra_streaming_tenant.example.cluster_name
+  namespace                     = "default"
+  schema_compatibility_strategy = "BACKWARD"
+  schema_auto_update_enabled    = true
+}
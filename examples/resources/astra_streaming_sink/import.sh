@@ -1 +1 @@
-terraform import astra_streaming_sink.example tenant_name/topic
\ No newline at end of file
+terraform import astra_streaming_sink.example tenant_name/namespace/sink_name
\ No newline at end of file
@@ -0,0 +1,2 @@
+# the import id is tenant_name/cluster_name, or cluster_name for the telemetry of a dedicated cluster
+terraform import astra_streaming_telemetry.prometheus terraformtest/pulsar-gcp-useast4
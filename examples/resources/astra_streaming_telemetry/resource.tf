@@ -0,0 +1,30 @@
+resource "astra_streaming_tenant" "example" {
+  tenant_name    = "terraformtest"
+  cloud_provider = "gcp"
+  region         = "useast-4"
+  user_email     = "someuser@example.com"
+}
+
+# Export the metrics of a tenant to a Prometheus remote write endpoint
+resource "astra_streaming_telemetry" "prometheus" {
+  tenant_name  = astra_streaming_tenant.example.tenant_name
+  cluster_name = astra_streaming_tenant.example.cluster_name
+  prometheus_remote {
+    endpoint = "https://prometheus.example.com/api/v1/write"
+    username = "astra"
+    password = var.prometheus_password
+  }
+}
+
+# Export the metrics of all the tenants of a dedicated cluster to Kafka
+resource "astra_streaming_telemetry" "kafka" {
+  cluster_name = "pulsar-aws-useast1-dedicated"
+  kafka {
+    bootstrap_servers = ["kafka-1.example.com:9093", "kafka-2.example.com:9093"]
+    topic             = "astra-streaming-metrics"
+    security_protocol = "SASL_SSL"
+    sasl_mechanism    = "PLAIN"
+    sasl_username     = "astra"
+    sasl_password     = var.kafka_password
+  }
+}
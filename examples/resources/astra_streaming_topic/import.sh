@@ -0,0 +1 @@
+terraform import astra_streaming_topic.example persistent://tenant_name/namespace/topic
\ No newline at end of file
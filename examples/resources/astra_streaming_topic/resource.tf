@@ -0,0 +1,7 @@
+resource "astra_streaming_topic" "example_topic" {
+  tenant_name    = "terraformtest1"
+  topic          = "mytopic"
+  region         = "useast-4"
+  cloud_provider = "gcp"
+  namespace      = "default"
+}
@@ -0,0 +1 @@
+terraform import astra_streaming_transform_function.example tenant_name/namespace/name
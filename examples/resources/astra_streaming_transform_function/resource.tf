@@ -0,0 +1,37 @@
+resource "astra_streaming_transform_function" "example" {
+  tenant_name    = "my-tenant"
+  cloud_provider = "gcp"
+  region         = "us-east1"
+  namespace      = "astracdc"
+  name           = "clean-users"
+  inputs         = [astra_cdc.users.data_topic]
+  output         = "persistent://my-tenant/default/users-clean"
+
+  # Keep the rows which are not deleted
+  step {
+    type = "drop"
+    when = "value.deleted == true"
+  }
+
+  step {
+    type   = "drop-fields"
+    fields = ["password", "ssn"]
+    part   = "value"
+  }
+
+  # Rename a field
+  step {
+    type = "compute"
+    compute {
+      name       = "value.email_address"
+      expression = "value.email"
+      type       = "STRING"
+    }
+  }
+
+  step {
+    type   = "drop-fields"
+    fields = ["email"]
+    part   = "value"
+  }
+}
@@ -1,3 +1,12 @@
 resource "astra_token" "example" {
   roles = ["a8cd363d-5069-4a2b-86d8-0578139812ac"]
-}
\ No newline at end of file
+}
+
+# Token which can only read and write the tables of one keyspace
+resource "astra_token" "app" {
+  scope {
+    database_ids = [astra_database.example.id]
+    keyspaces    = ["app"]
+    policy       = ["db-cql", "db-table-select", "db-table-modify", "db-table-describe", "db-keyspace-describe"]
+  }
+}
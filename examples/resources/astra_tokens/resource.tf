@@ -0,0 +1,11 @@
+# One token per service role
+resource "astra_tokens" "services" {
+  roles = [
+    "a8cd363d-5069-4a2b-86d8-0578139812ac",
+    "f07f8ee4-bd09-4e8b-a4ec-b0e8b6d1cb64",
+  ]
+}
+
+output "service_client_ids" {
+  value = astra_tokens.services.client_ids
+}
@@ -26,7 +26,7 @@ func dataSourceAccessList() *schema.Resource {
 
 			// Computed
 			"enabled": {
-				Description: "The Access list is enabled or disabled.",
+				Description: "Whether the access list is enforced. When `true`, access to the database is denied by default and only the enabled addresses of the list are allowed.",
 				Type:        schema.TypeBool,
 				Computed:    true,
 			},
@@ -51,6 +51,16 @@ func dataSourceAccessList() *schema.Resource {
 							Type:        schema.TypeBool,
 							Required:    true,
 						},
+						"expires_at": {
+							Description: "The time (RFC3339) after which this IP Address/CIDR group is no longer needed, if it was annotated with an expiry.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"expired": {
+							Description: "Whether the expiry of this IP Address/CIDR group has passed.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -87,6 +97,9 @@ func listAccessList(ctx context.Context, client *astra.ClientWithResponses, data
 		return nil, err
 	}
 
+	if err := checkReadStatus(resp.StatusCode(), resp.Body, fmt.Sprintf("database %s", databaseID)); err != nil {
+		return nil, err
+	}
 	db := resp.JSON200
 	if db == nil {
 		return nil, fmt.Errorf("error fetching database: %s", string(resp.Body))
@@ -98,12 +111,12 @@ func listAccessList(ctx context.Context, client *astra.ClientWithResponses, data
 	}
 
 	alResponse, err := client.GetAccessListForDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
-
 	if err != nil {
 		return nil, err
 	}
+	if err := checkReadStatus(alResponse.StatusCode(), alResponse.Body, fmt.Sprintf("access list of database %s", databaseID)); err != nil {
+		return nil, err
+	}
 
-	accessListOutput := alResponse.JSON200
-
-	return accessListOutput, err
+	return alResponse.JSON200, nil
 }
@@ -2,20 +2,51 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var availableRegionClassifications = []string{
+	"standard",
+	"premium",
+	"premium_plus",
+}
+
 func dataSourceAvailableRegions() *schema.Resource {
 	return &schema.Resource{
-		Description: "Retrieve a list of available cloud regions in Astra",
+		Description: "Retrieve a list of available cloud regions in Astra. The serverless regions of classic databases are returned, or the regions of vector search databases when `vector_only` is set.",
 
 		ReadContext: dataSourceRegionsRead,
 
 		Schema: map[string]*schema.Schema{
+			// Optional filters
+			"cloud_provider": {
+				Description:      "Only return regions for the given cloud provider, if supplied. (Currently supported: aws, azure, gcp)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringInSlice(availableCloudProviders, true),
+				DiffSuppressFunc: ignoreCase,
+			},
+			"classification": {
+				Description:  "Only return regions with the given pricing classification (tier), if supplied. One of `standard`, `premium` or `premium_plus`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(availableRegionClassifications, true),
+			},
+			"vector_only": {
+				Description: "Return the regions that support vector search databases instead of the serverless regions of classic databases. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			// Computed
 			"results": {
 				Type:        schema.TypeList,
 				Description: "The list of supported Astra regions by cloud provider and tier.",
@@ -42,6 +73,16 @@ func dataSourceAvailableRegions() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"classification": {
+							Description: "The pricing classification (tier) of the region",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vector_enabled": {
+							Description: "Whether vector search databases can be created in the region",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -52,21 +93,37 @@ func dataSourceAvailableRegions() *schema.Resource {
 func dataSourceRegionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 
-	regionsResp, err := client.ListServerlessRegionsWithResponse(ctx)
-	if err != nil {
-		return diag.FromErr(err)
-	} else if regionsResp.StatusCode() != 200 {
-		return diag.Errorf("unexpected list available regions response: %s", string(regionsResp.Body))
-	}
+	cloudProvider := d.Get("cloud_provider").(string)
+	classification := d.Get("classification").(string)
+	vectorOnly := d.Get("vector_only").(bool)
 
+	vectorRegions, err := listServerlessRegions(ctx, client, "vector", true)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	// Vector only regions cannot host classic databases, so they are only listed when asked for
+	regions := vectorRegions
+	if !vectorOnly {
+		if regions, err = listServerlessRegions(ctx, client, "serverless", true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	vectorEnabled := make(map[string]bool, len(vectorRegions))
+	for _, region := range vectorRegions {
+		vectorEnabled[regionKey(string(region.CloudProvider), region.Name)] = true
+	}
 
-	regions := *regionsResp.JSON200
 	flatRegions := make([]map[string]interface{}, 0, len(regions))
 	for _, region := range regions {
-		flatRegions = append(flatRegions, flattenRegion(&region))
+		if cloudProvider != "" && !strings.EqualFold(string(region.CloudProvider), cloudProvider) {
+			continue
+		}
+		if classification != "" && !strings.EqualFold(region.Classification, classification) {
+			continue
+		}
+		flatRegion := flattenRegion(&region)
+		flatRegion["vector_enabled"] = vectorEnabled[regionKey(string(region.CloudProvider), region.Name)]
+		flatRegions = append(flatRegions, flatRegion)
 	}
 
 	d.SetId(id.UniqueId())
@@ -77,11 +134,36 @@ func dataSourceRegionsRead(ctx context.Context, d *schema.ResourceData, meta int
 	return nil
 }
 
+// listServerlessRegions returns the serverless regions of the given region type ("serverless", "vector" or "all").
+// Unless filterByOrg is set, the regions that are not enabled for the organization are also returned.
+func listServerlessRegions(ctx context.Context, client *astra.ClientWithResponses, regionType string, filterByOrg bool) ([]astra.ServerlessRegion, error) {
+	regionsResp, err := client.ListServerlessRegionsWithResponse(ctx, func(ctx context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		query.Set("region-type", regionType)
+		if !filterByOrg {
+			query.Set("filter-by-org", "disabled")
+		}
+		req.URL.RawQuery = query.Encode()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	} else if regionsResp.StatusCode() != http.StatusOK || regionsResp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected list available regions response: %s", string(regionsResp.Body))
+	}
+	return *regionsResp.JSON200, nil
+}
+
+func regionKey(cloudProvider, region string) string {
+	return strings.ToLower(cloudProvider) + "." + strings.ToLower(region)
+}
+
 func flattenRegion(region *astra.ServerlessRegion) map[string]interface{} {
 	return map[string]interface{}{
 		"cloud_provider": region.CloudProvider,
 		"region":         region.Name,
 		"zone":           region.Zone,
 		"display_name":   region.DisplayName,
+		"classification": region.Classification,
 	}
 }
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAvailableRegionsType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("region-type") {
+		case "serverless":
+			w.Write([]byte(`[{"cloudProvider":"GCP","name":"us-east1","classification":"standard"},{"cloudProvider":"AWS","name":"us-east-1","classification":"standard"}]`))
+		case "vector":
+			w.Write([]byte(`[{"cloudProvider":"GCP","name":"us-east1","classification":"standard"},{"cloudProvider":"AWS","name":"us-west-2","classification":"standard"}]`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	client, err := astra.NewClientWithResponses(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		vectorOnly bool
+		expected   map[string]bool
+	}{
+		// us-west-2 only supports vector databases, so it is not listed by default
+		{vectorOnly: false, expected: map[string]bool{"us-east1": true, "us-east-1": false}},
+		{vectorOnly: true, expected: map[string]bool{"us-east1": true, "us-west-2": true}},
+	} {
+		d := schema.TestResourceDataRaw(t, dataSourceAvailableRegions().Schema, map[string]interface{}{"vector_only": test.vectorOnly})
+		if diags := dataSourceRegionsRead(context.Background(), d, astraClients{astraClient: client}); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		results := d.Get("results").([]interface{})
+		if len(results) != len(test.expected) {
+			t.Fatalf("expected regions %v with vector_only %t, got %v", test.expected, test.vectorOnly, results)
+		}
+		for _, result := range results {
+			region := result.(map[string]interface{})
+			vectorEnabled, ok := test.expected[region["region"].(string)]
+			if !ok || region["vector_enabled"] != vectorEnabled {
+				t.Fatalf("unexpected region with vector_only %t: %v", test.vectorOnly, region)
+			}
+		}
+	}
+}
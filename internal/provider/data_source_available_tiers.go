@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var availableTierTypes = []string{
+	"serverless",
+	"classic",
+}
+
+func dataSourceAvailableTiers() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_available_tiers` provides a datasource that lists the database tiers (serverless and classic/dedicated) available to the organization in each region.",
+
+		ReadContext: dataSourceAvailableTiersRead,
+
+		Schema: map[string]*schema.Schema{
+			// Optional filters
+			"cloud_provider": {
+				Description:      "Only return tiers for the given cloud provider, if supplied.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringInSlice(availableCloudProviders, true),
+				DiffSuppressFunc: ignoreCase,
+			},
+			"region": {
+				Description: "Only return tiers for the given cloud provider region, if supplied.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tier_type": {
+				Description:  "Only return tiers of the given type, if supplied. One of `serverless` or `classic`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(availableTierTypes, true),
+			},
+			// Computed
+			"results": {
+				Description: "The list of tiers by cloud provider and region.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tier": {
+							Description: "The database tier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tier_type": {
+							Description: "The type of the tier, `serverless` or `classic`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cloud_provider": {
+							Description: "The cloud provider.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: "The cloud provider region.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the tier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"available": {
+							Description: "True when the organization can create another database of this tier in the region.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAvailableTiersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	cloudProvider := d.Get("cloud_provider").(string)
+	region := d.Get("region").(string)
+	tierType := d.Get("tier_type").(string)
+
+	resp, err := client.ListAvailableRegionsWithResponse(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return diag.Errorf("unexpected list available regions response: %s", string(resp.Body))
+	}
+
+	results := make([]map[string]interface{}, 0, len(*resp.JSON200))
+	for _, r := range *resp.JSON200 {
+		if cloudProvider != "" && !strings.EqualFold(string(r.CloudProvider), cloudProvider) {
+			continue
+		}
+		if region != "" && !strings.EqualFold(r.Region, region) {
+			continue
+		}
+		t := tierTypeOf(r.Tier)
+		if tierType != "" && !strings.EqualFold(t, tierType) {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"tier":           string(r.Tier),
+			"tier_type":      t,
+			"cloud_provider": string(r.CloudProvider),
+			"region":         r.Region,
+			"description":    astra.StringValue(r.Description),
+			"available":      r.DatabaseCountUsed < r.DatabaseCountLimit,
+		})
+	}
+
+	d.SetId(id.UniqueId())
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// tierTypeOf returns "serverless" for serverless tiers and "classic" for the dedicated (classic) tiers
+func tierTypeOf(tier astra.Tier) string {
+	switch tier {
+	case astra.Serverless, astra.Developer:
+		return "serverless"
+	default:
+		return "classic"
+	}
+}
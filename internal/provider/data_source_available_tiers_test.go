@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAvailableTiersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAvailableTiersDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_available_tiers.dev", "results.0.tier_type", "serverless"),
+					resource.TestCheckResourceAttrSet("data.astra_available_tiers.dev", "results.0.region"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccAvailableTiersDataSource() string {
+	return `
+data "astra_available_tiers" "dev" {
+  tier_type = "serverless"
+}
+`
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBackups() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_backups` provides a datasource that lists the backups (restore points) of an Astra database.",
+
+		ReadContext: dataSourceBackupsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Optional inputs
+			"status": {
+				Description: "Only return backups with the given status, for example `COMPLETED`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			// Computed
+			"results": {
+				Description: "The list of backups of the database.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_id": {
+							Description: "The backup ID.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The backup status.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "The time the backup was started, in RFC 3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"completed_at": {
+							Description: "The time the backup completed, in RFC 3339 format, if completed.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"size_bytes": {
+							Description: "The size of the backup in bytes, if reported by Astra.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBackupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	status := d.Get("status").(string)
+
+	backups, err := listBackups(ctx, client, databaseID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := make([]map[string]interface{}, 0, len(backups))
+	for _, b := range backups {
+		if status != "" && !strings.EqualFold(b.Status, status) {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"backup_id":    b.ID,
+			"status":       b.Status,
+			"created_at":   b.CreatedAt,
+			"completed_at": b.CompletedAt,
+			"size_bytes":   b.SizeBytes,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/backups", databaseID))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func listBackups(ctx context.Context, client *astra.ClientWithResponses, databaseID string) (DatabaseBackups, error) {
+	statusCode, body, err := astraAPIGet(ctx, client, fmt.Sprintf("v2/databases/%s/backups", databaseID))
+	if err != nil {
+		return nil, err
+	} else if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected list backups response: %s", string(body))
+	}
+
+	var backups DatabaseBackups
+	if err := json.Unmarshal(body, &backups); err != nil {
+		return nil, fmt.Errorf("failed to decode backups: %w", err)
+	}
+	return backups, nil
+}
+
+// restoreBackup requests the restore of a backup of the source database into the database. The generated client does
+// not cover the backup endpoints, so the restore is sent next to the list of backups, to v2/databases/{id}/restore/{backupId}.
+func restoreBackup(ctx context.Context, client *astra.ClientWithResponses, databaseID, sourceDatabaseID, backupID string) error {
+	statusCode, body, err := astraAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("v2/databases/%s/restore/%s", databaseID, backupID), map[string]string{
+		"sourceDatabaseId": sourceDatabaseID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := permissionError(opRestoreBackup, statusCode, body); err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+		return fmt.Errorf("error restoring backup %s of database %s: %s", backupID, sourceDatabaseID, string(body))
+	}
+	return nil
+}
+
+type DatabaseBackups []struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+	CompletedAt string `json:"completedAt,omitempty"`
+	SizeBytes   int    `json:"sizeBytes,omitempty"`
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestBackupsDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBackupsDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_backups.dev", "database_id", databaseID),
+					resource.TestCheckResourceAttrSet("data.astra_backups.dev", "results.#"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccBackupsDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+data "astra_backups" "dev" {
+  database_id = "%s"
+}
+`, databaseID)
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCapabilities() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_capabilities` provides a datasource with the Astra features available to the organization in a cloud provider region, so that configurations can check for a feature, for example with `count`, instead of failing at apply.",
+
+		ReadContext: dataSourceCapabilitiesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cloud_provider": {
+				Description:      "The cloud provider. (Currently supported: aws, azure, gcp)",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringInSlice(availableCloudProviders, true),
+				DiffSuppressFunc: ignoreCase,
+			},
+			"region": {
+				Description:      "The cloud provider region, with or without dashes.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: ignoreRegionFormat,
+			},
+			// Computed
+			"database": {
+				Description: "Whether serverless databases can be created in the region by the organization.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"vector": {
+				Description: "Whether serverless databases with vector search can be created in the region by the organization.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"streaming": {
+				Description: "Whether streaming tenants can be created in the region.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"cdc": {
+				Description: "Whether CDC can be enabled for the tables of a database in the region, which requires both a database and a streaming tenant in the region.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"private_link": {
+				Description: "Whether private links can be configured in the region. This is false when the organization cannot list its private links, for example when its plan does not include private links or when the token is not allowed to read them.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"telemetry_destinations": {
+				Description: "The external systems the metrics of streaming tenants in the region can be exported to with `astra_streaming_telemetry`, empty when streaming is not available.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCapabilitiesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	cloudProvider := d.Get("cloud_provider").(string)
+	region := d.Get("region").(string)
+
+	regions, err := listServerlessRegions(ctx, client, "all", true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vectorRegions, err := listServerlessRegions(ctx, client, "vector", true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	streamingRegions, err := listStreamingRegions(ctx, streamingClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	privateLinks, err := privateLinksAvailable(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", strings.ToLower(cloudProvider), normalizeRegion(region)))
+	for attribute, value := range flattenCapabilities(cloudProvider, region, regions, vectorRegions, streamingRegions, privateLinks) {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// privateLinksAvailable returns whether the organization can list its private links. Other errors than missing
+// permissions and unavailable features are returned.
+func privateLinksAvailable(ctx context.Context, client *astra.ClientWithResponses) (bool, error) {
+	resp, err := client.ListPrivateLinksForOrgWithResponse(ctx)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case resp.StatusCode() == http.StatusOK:
+		return true, nil
+	case resp.StatusCode() >= http.StatusInternalServerError:
+		return false, fmt.Errorf("error listing private links of the organization: %s", string(resp.Body))
+	default:
+		return false, nil
+	}
+}
+
+// flattenCapabilities returns the capabilities of the region from the database regions enabled for the organization,
+// the vector regions and the streaming regions
+func flattenCapabilities(cloudProvider, region string, regions, vectorRegions []astra.ServerlessRegion, streamingRegions ServerlessStreamingAvailableRegionsResult, privateLinks bool) map[string]interface{} {
+	inRegions := func(regions []astra.ServerlessRegion) bool {
+		for _, r := range regions {
+			if strings.EqualFold(string(r.CloudProvider), cloudProvider) && regionsEqual(r.Name, region) {
+				return true
+			}
+		}
+		return false
+	}
+	database := inRegions(regions)
+	streaming := false
+	for _, r := range streamingRegions {
+		if strings.EqualFold(r.CloudProvider, cloudProvider) && regionsEqual(r.Region, region) {
+			streaming = true
+			break
+		}
+	}
+
+	telemetryDestinations := []string{}
+	if streaming {
+		telemetryDestinations = streamingTelemetryExporters
+	}
+	return map[string]interface{}{
+		"database":               database,
+		"vector":                 inRegions(vectorRegions),
+		"streaming":              streaming,
+		"cdc":                    database && streaming,
+		"private_link":           database && privateLinks,
+		"telemetry_destinations": telemetryDestinations,
+	}
+}
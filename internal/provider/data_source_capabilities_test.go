@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCapabilitiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCapabilitiesDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_capabilities.dev", "database", "true"),
+					resource.TestCheckResourceAttrSet("data.astra_capabilities.dev", "cdc"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCapabilitiesDataSource() string {
+	return `
+data "astra_capabilities" "dev" {
+  cloud_provider = "gcp"
+  region         = "us-east1"
+}
+`
+}
+
+func TestFlattenCapabilities(t *testing.T) {
+	regions := []astra.ServerlessRegion{
+		{CloudProvider: "GCP", Name: "us-east1"},
+		{CloudProvider: "AWS", Name: "us-east-2"},
+	}
+	vectorRegions := []astra.ServerlessRegion{
+		{CloudProvider: "GCP", Name: "us-east1"},
+	}
+	streamingRegions := ServerlessStreamingAvailableRegionsResult{
+		{CloudProvider: "gcp", Region: "useast1"},
+	}
+
+	capabilities := flattenCapabilities("gcp", "useast1", regions, vectorRegions, streamingRegions, true)
+	expected := map[string]interface{}{
+		"database":               true,
+		"vector":                 true,
+		"streaming":              true,
+		"cdc":                    true,
+		"private_link":           true,
+		"telemetry_destinations": streamingTelemetryExporters,
+	}
+	if !reflect.DeepEqual(capabilities, expected) {
+		t.Fatalf("expected %v, got %v", expected, capabilities)
+	}
+
+	capabilities = flattenCapabilities("aws", "us-east-2", regions, vectorRegions, streamingRegions, false)
+	expected = map[string]interface{}{
+		"database":               true,
+		"vector":                 false,
+		"streaming":              false,
+		"cdc":                    false,
+		"private_link":           false,
+		"telemetry_destinations": []string{},
+	}
+	if !reflect.DeepEqual(capabilities, expected) {
+		t.Fatalf("expected %v, got %v", expected, capabilities)
+	}
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudProviders() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_cloud_providers` provides a datasource with the cloud providers supported for serverless databases and streaming tenants in the organization. Cloud providers with database regions that are not enabled for the organization are listed as restricted.",
+
+		ReadContext: dataSourceCloudProvidersRead,
+
+		Schema: map[string]*schema.Schema{
+			// Computed
+			"names": {
+				Description: "The names of the cloud providers supported for databases or streaming in the organization, in lower case.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"results": {
+				Description: "The cloud providers of Astra, including the restricted ones.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloud_provider": {
+							Description: "The name of the cloud provider, in lower case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"database_supported": {
+							Description: "Whether serverless databases can be created on the cloud provider in the organization.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"streaming_supported": {
+							Description: "Whether streaming tenants can be created on the cloud provider in the organization.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"restricted": {
+							Description: "Whether some database regions of the cloud provider are not enabled for the organization.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"database_region_count": {
+							Description: "The number of database regions of the cloud provider enabled for the organization.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"restricted_database_region_count": {
+							Description: "The number of database regions of the cloud provider not enabled for the organization.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"streaming_region_count": {
+							Description: "The number of streaming regions of the cloud provider.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudProvidersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	orgRegions, err := listServerlessRegions(ctx, client, "all", true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	allRegions, err := listServerlessRegions(ctx, client, "all", false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	streamingRegions, err := listStreamingRegions(ctx, streamingClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := flattenCloudProviders(orgRegions, allRegions, streamingRegions)
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		if result["database_supported"].(bool) || result["streaming_supported"].(bool) {
+			names = append(names, result["cloud_provider"].(string))
+		}
+	}
+
+	d.SetId(id.UniqueId())
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenCloudProviders aggregates the database regions enabled for the organization, all the database regions and
+// the streaming regions by cloud provider, sorted by name
+func flattenCloudProviders(orgRegions, allRegions []astra.ServerlessRegion, streamingRegions ServerlessStreamingAvailableRegionsResult) []map[string]interface{} {
+	enabled := map[string]bool{}
+	databaseRegions := map[string]map[string]bool{}
+	restrictedRegions := map[string]map[string]bool{}
+	streamingRegionNames := map[string]map[string]bool{}
+	addRegion := func(regions map[string]map[string]bool, cloudProvider, region string) {
+		cloudProvider = strings.ToLower(cloudProvider)
+		if regions[cloudProvider] == nil {
+			regions[cloudProvider] = map[string]bool{}
+		}
+		regions[cloudProvider][strings.ToLower(region)] = true
+	}
+
+	for _, region := range orgRegions {
+		enabled[regionKey(string(region.CloudProvider), region.Name)] = true
+		addRegion(databaseRegions, string(region.CloudProvider), region.Name)
+	}
+	for _, region := range allRegions {
+		if !enabled[regionKey(string(region.CloudProvider), region.Name)] {
+			addRegion(restrictedRegions, string(region.CloudProvider), region.Name)
+		}
+	}
+	for _, region := range streamingRegions {
+		addRegion(streamingRegionNames, region.CloudProvider, region.Region)
+	}
+
+	names := map[string]bool{}
+	for _, regions := range []map[string]map[string]bool{databaseRegions, restrictedRegions, streamingRegionNames} {
+		for cloudProvider := range regions {
+			names[cloudProvider] = true
+		}
+	}
+	cloudProviders := make([]string, 0, len(names))
+	for cloudProvider := range names {
+		cloudProviders = append(cloudProviders, cloudProvider)
+	}
+	sort.Strings(cloudProviders)
+
+	results := make([]map[string]interface{}, 0, len(cloudProviders))
+	for _, cloudProvider := range cloudProviders {
+		results = append(results, map[string]interface{}{
+			"cloud_provider":                   cloudProvider,
+			"database_supported":               len(databaseRegions[cloudProvider]) > 0,
+			"streaming_supported":              len(streamingRegionNames[cloudProvider]) > 0,
+			"restricted":                       len(restrictedRegions[cloudProvider]) > 0,
+			"database_region_count":            len(databaseRegions[cloudProvider]),
+			"restricted_database_region_count": len(restrictedRegions[cloudProvider]),
+			"streaming_region_count":           len(streamingRegionNames[cloudProvider]),
+		})
+	}
+	return results
+}
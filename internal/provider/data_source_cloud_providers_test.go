@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCloudProvidersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudProvidersDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_cloud_providers.dev", "names.0"),
+					resource.TestCheckResourceAttrSet("data.astra_cloud_providers.dev", "results.0.cloud_provider"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudProvidersDataSource() string {
+	return `
+data "astra_cloud_providers" "dev" {
+}
+`
+}
+
+func TestFlattenCloudProviders(t *testing.T) {
+	orgRegions := []astra.ServerlessRegion{
+		{CloudProvider: "AWS", Name: "us-east-2"},
+		{CloudProvider: "GCP", Name: "us-east1"},
+		{CloudProvider: "GCP", Name: "us-east1"},
+	}
+	allRegions := append([]astra.ServerlessRegion{
+		{CloudProvider: "AWS", Name: "eu-west-1"},
+		{CloudProvider: "AZURE", Name: "westus2"},
+	}, orgRegions...)
+	streamingRegions := ServerlessStreamingAvailableRegionsResult{
+		{CloudProvider: "gcp", Region: "useast1"},
+	}
+
+	results := flattenCloudProviders(orgRegions, allRegions, streamingRegions)
+	expected := []map[string]interface{}{
+		{
+			"cloud_provider":                   "aws",
+			"database_supported":               true,
+			"streaming_supported":              false,
+			"restricted":                       true,
+			"database_region_count":            1,
+			"restricted_database_region_count": 1,
+			"streaming_region_count":           0,
+		},
+		{
+			"cloud_provider":                   "azure",
+			"database_supported":               false,
+			"streaming_supported":              false,
+			"restricted":                       true,
+			"database_region_count":            0,
+			"restricted_database_region_count": 1,
+			"streaming_region_count":           0,
+		},
+		{
+			"cloud_provider":                   "gcp",
+			"database_supported":               true,
+			"streaming_supported":              true,
+			"restricted":                       false,
+			"database_region_count":            1,
+			"restricted_database_region_count": 0,
+			"streaming_region_count":           1,
+		},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %v, got %v", expected, results)
+	}
+}
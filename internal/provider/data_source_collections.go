@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCollections() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_collections` provides a datasource that lists the Data API collections in a namespace, including their vector settings.",
+
+		ReadContext: dataSourceCollectionsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description: "The region of the database used to reach the Data API.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"namespace": {
+				Description:      "The namespace (keyspace) to list the collections of.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			// Computed
+			"results": {
+				Description: "The list of collections in the namespace.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The collection name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vector_enabled": {
+							Description: "Whether the collection stores vectors.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"vector_dimension": {
+							Description: "The dimension of the vectors, 0 when vectors are not enabled.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"vector_metric": {
+							Description: "The similarity metric of the vectors, `cosine`, `euclidean` or `dot_product`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCollectionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	namespace := d.Get("namespace").(string)
+
+	command := map[string]interface{}{
+		"findCollections": map[string]interface{}{
+			"options": map[string]interface{}{
+				"explain": true,
+			},
+		},
+	}
+	body, err := dataAPICommand(ctx, meta, databaseID, region, namespace, command)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var collectionsResp DataAPIFindCollectionsResponse
+	if err := json.Unmarshal(body, &collectionsResp); err != nil {
+		return diag.Errorf("failed to decode collections: %s", err)
+	}
+	if len(collectionsResp.Errors) > 0 {
+		return diag.Errorf("error listing collections in namespace %s: %s", namespace, collectionsResp.Errors[0].Message)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", databaseID, namespace))
+	if err := d.Set("results", flattenCollections(collectionsResp.Status.Collections)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenCollections(collections []DataAPICollection) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(collections))
+	for _, c := range collections {
+		flatCollection := map[string]interface{}{
+			"name":             c.Name,
+			"vector_enabled":   false,
+			"vector_dimension": 0,
+			"vector_metric":    "",
+		}
+		if c.Options.Vector != nil {
+			flatCollection["vector_enabled"] = true
+			flatCollection["vector_dimension"] = c.Options.Vector.Dimension
+			flatCollection["vector_metric"] = c.Options.Vector.Metric
+		}
+		results = append(results, flatCollection)
+	}
+	return results
+}
+
+// dataAPICommand sends a command to the Data API of a namespace and returns the raw response body
+func dataAPICommand(ctx context.Context, meta interface{}, databaseID, region, namespace string, command interface{}) ([]byte, error) {
+	providerVersion := meta.(astraClients).providerVersion
+	userAgent := meta.(astraClients).userAgent
+	token := meta.(astraClients).token
+
+	reqBody, err := json.Marshal(command)
+	if err != nil {
+		return nil, err
+	}
+
+	// Namespace commands like createNamespace are sent to the API root instead of a namespace
+	serverURL := fmt.Sprintf("https://%s-%s.apps.astra.datastax.com/api/json/v1", databaseID, region)
+	if namespace != "" {
+		serverURL = fmt.Sprintf("%s/%s", serverURL, namespace)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, serverURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Astra-Provider-Version", providerVersion)
+	req.Header.Set("X-Astra-Client-Version", fmt.Sprintf("go/%s", astra.Version))
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = newHTTPClient(meta.(astraClients).httpTransport, meta.(astraClients).httpOptions)
+	retryClient.RetryMax = 10
+	resp, err := retryClient.StandardClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected Data API response (status %d): %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+type DataAPIFindCollectionsResponse struct {
+	Status struct {
+		Collections []DataAPICollection `json:"collections"`
+	} `json:"status"`
+	Errors []DataAPIError `json:"errors,omitempty"`
+}
+
+type DataAPICollection struct {
+	Name    string `json:"name"`
+	Options struct {
+		Vector *struct {
+			Dimension int                             `json:"dimension"`
+			Metric    string                          `json:"metric"`
+			Service   *DataAPICollectionVectorService `json:"service,omitempty"`
+		} `json:"vector,omitempty"`
+	} `json:"options"`
+}
+
+type DataAPICollectionVectorService struct {
+	Provider       string `json:"provider"`
+	ModelName      string `json:"modelName,omitempty"`
+	Authentication *struct {
+		ProviderKey string `json:"providerKey,omitempty"`
+	} `json:"authentication,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type DataAPIError struct {
+	Message   string `json:"message"`
+	ErrorCode string `json:"errorCode,omitempty"`
+}
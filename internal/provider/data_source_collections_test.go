@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCollectionsDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID", "ASTRA_TEST_DATABASE_REGION")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	region := os.Getenv("ASTRA_TEST_DATABASE_REGION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionsDataSource(databaseID, region),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_collections.dev", "database_id", databaseID),
+					resource.TestCheckResourceAttrSet("data.astra_collections.dev", "results.#"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccCollectionsDataSource(databaseID, region string) string {
+	return fmt.Sprintf(`
+data "astra_collections" "dev" {
+  database_id = "%s"
+  region      = "%s"
+  namespace   = "default_keyspace"
+}
+`, databaseID, region)
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hoursPerMonth is the number of hours of the monthly costs of the pricing API: 365 * 24 / 12
+const hoursPerMonth = 730
+
+func dataSourceCostEstimate() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_cost_estimate` provides a datasource with the estimated monthly cost of a serverless database or streaming tenant in a region, from the expected usage and the pricing of `astra_region_pricing`. The estimate only includes the costs returned by the pricing API: the running and parked time, and for streaming the GB read, written and transferred. Storage is not included. All costs are in US cents.",
+
+		ReadContext: dataSourceCostEstimateRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cloud_provider": {
+				Description:      "The cloud provider. (Currently supported: aws, azure, gcp)",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringInSlice(availableCloudProviders, true),
+				DiffSuppressFunc: ignoreCase,
+			},
+			"region": {
+				Description: "The cloud provider region.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Optional inputs
+			"product": {
+				Description:  "The product to estimate, `database` or `streaming`. Defaults to `database`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "database",
+				ValidateFunc: validation.StringInSlice(regionPricingProducts, false),
+			},
+			"tier": {
+				Description: "The tier of the pricing. Defaults to `serverless`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     string(astra.Serverless),
+			},
+			"running_hours_per_month": {
+				Description:  fmt.Sprintf("The number of hours per month the database or tenant is running, it is parked for the rest of the month. Defaults to the whole month, %d hours.", hoursPerMonth),
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      float64(hoursPerMonth),
+				ValidateFunc: validation.FloatBetween(0, hoursPerMonth),
+			},
+			"read_gb_per_month": {
+				Description:  "The expected GB read per month (streaming only).",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"written_gb_per_month": {
+				Description:  "The expected GB written per month (streaming only).",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"network_gb_per_month": {
+				Description:  "The expected GB of network transfer per month (streaming only).",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			// Computed
+			"running_cost_cents": {
+				Description: "Monthly cost of the running hours in cents.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"parked_cost_cents": {
+				Description: "Monthly cost of the parked hours in cents.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"read_cost_cents": {
+				Description: "Monthly cost of the GB read in cents.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"written_cost_cents": {
+				Description: "Monthly cost of the GB written in cents.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"network_cost_cents": {
+				Description: "Monthly cost of the network transfer in cents.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"monthly_cost_cents": {
+				Description: "Estimated total monthly cost in cents.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// costEstimateUsage is the expected monthly usage of a database or streaming tenant
+type costEstimateUsage struct {
+	runningHours float64
+	readGB       float64
+	writtenGB    float64
+	networkGB    float64
+}
+
+func dataSourceCostEstimateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	cloudProvider := d.Get("cloud_provider").(string)
+	region := d.Get("region").(string)
+	product := d.Get("product").(string)
+	tier := d.Get("tier").(string)
+	usage := costEstimateUsage{
+		runningHours: d.Get("running_hours_per_month").(float64),
+		readGB:       d.Get("read_gb_per_month").(float64),
+		writtenGB:    d.Get("written_gb_per_month").(float64),
+		networkGB:    d.Get("network_gb_per_month").(float64),
+	}
+
+	var costs []map[string]interface{}
+	var err error
+	if product == "streaming" {
+		costs, err = listStreamingRegionCosts(ctx, streamingClient)
+	} else {
+		costs, err = listDatabaseRegionCosts(ctx, client)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cost, err := findRegionCost(costs, cloudProvider, region, tier)
+	if err != nil {
+		return diag.Errorf("no %s pricing found: %s", product, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", product, strings.ToLower(cloudProvider), region, tier))
+	for attribute, value := range estimateMonthlyCost(cost, usage) {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// findRegionCost returns the costs of the tier in the region, as listed by astra_region_pricing
+func findRegionCost(costs []map[string]interface{}, cloudProvider, region, tier string) (map[string]interface{}, error) {
+	tiers := map[string]bool{}
+	for _, cost := range costs {
+		if !strings.EqualFold(cost["cloud_provider"].(string), cloudProvider) || !regionsEqual(cost["region"].(string), region) {
+			continue
+		}
+		if strings.EqualFold(cost["tier"].(string), tier) {
+			return cost, nil
+		}
+		tiers[cost["tier"].(string)] = true
+	}
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("region %s is not available on %s", region, cloudProvider)
+	}
+	available := make([]string, 0, len(tiers))
+	for t := range tiers {
+		available = append(available, t)
+	}
+	sort.Strings(available)
+	return nil, fmt.Errorf("tier %s is not available in region %s, available tiers: %s", tier, region, strings.Join(available, ", "))
+}
+
+// estimateMonthlyCost returns the monthly costs of the usage with the costs of a region
+func estimateMonthlyCost(cost map[string]interface{}, usage costEstimateUsage) map[string]interface{} {
+	estimate := map[string]interface{}{
+		"running_cost_cents": cost["cost_per_hour_cents"].(float64) * usage.runningHours,
+		"parked_cost_cents":  cost["cost_per_hour_parked_cents"].(float64) * (hoursPerMonth - usage.runningHours),
+		"read_cost_cents":    cost["cost_per_read_gb_cents"].(float64) * usage.readGB,
+		"written_cost_cents": cost["cost_per_written_gb_cents"].(float64) * usage.writtenGB,
+		"network_cost_cents": cost["cost_per_network_gb_cents"].(float64) * usage.networkGB,
+	}
+	// Sum in a fixed order so that the total does not change between reads
+	total := 0.0
+	for _, attribute := range []string{"running_cost_cents", "parked_cost_cents", "read_cost_cents", "written_cost_cents", "network_cost_cents"} {
+		total += estimate[attribute].(float64)
+	}
+	estimate["monthly_cost_cents"] = total
+	return estimate
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCostEstimateDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCostEstimateDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_cost_estimate.dev", "monthly_cost_cents"),
+					resource.TestCheckResourceAttr("data.astra_cost_estimate.dev", "parked_cost_cents", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCostEstimateDataSource() string {
+	return `
+data "astra_cost_estimate" "dev" {
+  cloud_provider = "gcp"
+  region         = "us-east1"
+}
+`
+}
+
+func TestEstimateMonthlyCost(t *testing.T) {
+	costs := []map[string]interface{}{
+		{"product": "streaming", "tier": "serverless", "cloud_provider": "gcp", "region": "useast1", "cost_per_hour_cents": 2.0, "cost_per_hour_parked_cents": 0.5, "cost_per_read_gb_cents": 4.0, "cost_per_written_gb_cents": 9.0, "cost_per_network_gb_cents": 1.0},
+		{"product": "streaming", "tier": "dedicated", "cloud_provider": "gcp", "region": "useast1", "cost_per_hour_cents": 20.0, "cost_per_hour_parked_cents": 0.0, "cost_per_read_gb_cents": 0.0, "cost_per_written_gb_cents": 0.0, "cost_per_network_gb_cents": 0.0},
+	}
+
+	cost, err := findRegionCost(costs, "GCP", "us-east1", "serverless")
+	if err != nil {
+		t.Fatal(err)
+	}
+	estimate := estimateMonthlyCost(cost, costEstimateUsage{runningHours: 700, readGB: 10, writtenGB: 2, networkGB: 5})
+	expected := map[string]float64{
+		"running_cost_cents": 1400,
+		"parked_cost_cents":  15,
+		"read_cost_cents":    40,
+		"written_cost_cents": 18,
+		"network_cost_cents": 5,
+		"monthly_cost_cents": 1478,
+	}
+	for attribute, value := range expected {
+		if estimate[attribute] != value {
+			t.Fatalf("expected %s to be %v, got %v", attribute, value, estimate[attribute])
+		}
+	}
+
+	if _, err := findRegionCost(costs, "gcp", "us-east1", "developer"); err == nil || !strings.Contains(err.Error(), "available tiers: dedicated, serverless") {
+		t.Fatalf("expected an error listing the available tiers, got %v", err)
+	}
+	if _, err := findRegionCost(costs, "aws", "us-east-1", "serverless"); err == nil {
+		t.Fatal("expected an error for an unavailable region")
+	}
+}
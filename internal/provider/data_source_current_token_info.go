@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCurrentTokenInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_current_token_info` provides a datasource that describes the token configured in the provider: its client ID, its organization and its roles. This can be used to check that the right credential is in use before creating resources.",
+
+		ReadContext: dataSourceCurrentTokenInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			// Computed
+			"client_id": {
+				Description: "The client ID of the token.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"organization_id": {
+				Description: "The ID of the organization of the token.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"organization_name": {
+				Description: "The name of the organization of the token.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"generated_on": {
+				Description: "The time the token was generated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"roles": {
+				Description: "The roles of the token.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Description: "The role id.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"role_name": {
+							Description: "The role name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCurrentTokenInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	clientID, err := tokenClientID(meta.(astraClients).token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token, err := listToken(ctx, client, clientID)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if token == nil {
+		return diag.Errorf("token with client ID %s not found in organization %s", clientID, org.ID)
+	}
+
+	rolesResp, err := client.GetOrganizationRolesWithResponse(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if rolesResp.StatusCode() != http.StatusOK {
+		return diag.Errorf("Unable to retrieve organization roles: (%s) %s", rolesResp.Status(), string(rolesResp.Body))
+	}
+
+	d.SetId(clientID)
+	d.Set("client_id", clientID)
+	d.Set("organization_id", org.ID)
+	d.Set("organization_name", org.Name)
+	if generatedOn, ok := token["generatedOn"].(string); ok {
+		d.Set("generated_on", generatedOn)
+	}
+	if err := d.Set("roles", flattenTokenRoles(token["roles"], getRoleSlice(rolesResp.JSON200))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// tokenClientID returns the client ID of a token in the format AstraCS:<client ID>:<secret>
+func tokenClientID(token string) (string, error) {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 || parts[0] != "AstraCS" || parts[1] == "" {
+		return "", fmt.Errorf("the configured token is not an Astra application token in the format AstraCS:<client ID>:<secret>")
+	}
+	return parts[1], nil
+}
+
+// flattenTokenRoles returns the role IDs of a token with the names of the organization roles
+func flattenTokenRoles(tokenRoles interface{}, orgRoles []astra.Role) []map[string]interface{} {
+	roleNames := make(map[string]string, len(orgRoles))
+	for _, r := range orgRoles {
+		if r.Id != nil && r.Name != nil {
+			roleNames[*r.Id] = *r.Name
+		}
+	}
+	roleIDs, _ := tokenRoles.([]interface{})
+	roles := make([]map[string]interface{}, 0, len(roleIDs))
+	for _, r := range roleIDs {
+		roleID := fmt.Sprint(r)
+		roles = append(roles, map[string]interface{}{
+			"role_id":   roleID,
+			"role_name": roleNames[roleID],
+		})
+	}
+	return roles
+}
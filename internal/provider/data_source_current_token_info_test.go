@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCurrentTokenInfoDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCurrentTokenInfoDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_current_token_info.current", "client_id"),
+					resource.TestCheckResourceAttrSet("data.astra_current_token_info.current", "organization_id"),
+					resource.TestCheckResourceAttrSet("data.astra_current_token_info.current", "roles.0.role_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCurrentTokenInfoDataSource() string {
+	return `
+data "astra_current_token_info" "current" {}
+`
+}
+
+func TestTokenClientID(t *testing.T) {
+	clientID, err := tokenClientID("AstraCS:abcdef:0123456789")
+	if err != nil || clientID != "abcdef" {
+		t.Errorf("expected client ID abcdef, got %q (%v)", clientID, err)
+	}
+	for _, token := range []string{"", "mock", "AstraCS::secret", "Other:abcdef:secret"} {
+		if _, err := tokenClientID(token); err == nil {
+			t.Errorf("expected an error for token %q", token)
+		}
+	}
+}
+
+func TestFlattenTokenRoles(t *testing.T) {
+	id, name := "role-1", "Organization Administrator"
+	roles := flattenTokenRoles([]interface{}{"role-1", "role-2"}, []astra.Role{{Id: &id, Name: &name}})
+	expected := []map[string]interface{}{
+		{"role_id": "role-1", "role_name": "Organization Administrator"},
+		{"role_id": "role-2", "role_name": ""},
+	}
+	if !reflect.DeepEqual(roles, expected) {
+		t.Errorf("expected %v, got %v", expected, roles)
+	}
+}
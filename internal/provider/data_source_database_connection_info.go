@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// astraCQLPort is the port of the CQL endpoint of Astra databases, served by the SNI proxy of the secure connect bundle
+const astraCQLPort = 29042
+
+func dataSourceDatabaseConnectionInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_database_connection_info` provides a datasource with everything an application needs to connect to an Astra database: the Data API endpoint, the CQL host and port, and the secure connect bundle URLs of every region. The secure connect bundle URLs are temporary credentials which last five minutes, so they are sensitive.",
+
+		ReadContext: dataSourceDatabaseConnectionInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Optional inputs
+			"region": {
+				Description: "The region the application connects to. Defaults to the region the database was created in.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			// Computed
+			"regions": {
+				Description: "The regions of the database.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"keyspace": {
+				Description: "The default keyspace of the database.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"data_api_endpoint": {
+				Description: "The Data API endpoint of the database in `region`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"data_api_endpoints": {
+				Description: "Map of region to the Data API endpoint of the database in the region.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"cql_host": {
+				Description: "The CQL host of the database in `region`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"cql_port": {
+				Description: "The recommended CQL port.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"secure_connect_bundle_url": {
+				Description: "The temporary download URL of the secure connect bundle of the database in `region`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"secure_connect_bundle_urls": {
+				Description: "Map of region to the temporary download URL of the secure connect bundle of the database in the region.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseConnectionInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.JSON200 == nil {
+		return diag.Errorf("error fetching database: %s", string(resp.Body))
+	}
+	db := resp.JSON200
+
+	// Datacenter ID by region, the secure connect bundles are returned by datacenter
+	datacenters := map[string]string{}
+	if db.Info.Datacenters != nil {
+		for _, dc := range *db.Info.Datacenters {
+			datacenters[dc.Region] = astra.StringValue(dc.Id)
+		}
+	}
+	regions := make([]string, 0, len(datacenters))
+	for region := range datacenters {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	region := d.Get("region").(string)
+	if region == "" {
+		region = astra.StringValue(db.Info.Region)
+	}
+	if _, ok := datacenters[region]; !ok {
+		return diag.Errorf("database %s has no datacenter in region %s, available regions: %v", databaseID, region, regions)
+	}
+
+	bundles, err := getSecureConnectBundles(ctx, client, databaseID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bundleURLs := map[string]string{}
+	for _, bundle := range bundles {
+		// DevOps APi has a misspelling that they might fix
+		datacenterID := astra.StringValue(bundle.DatacenterID)
+		if datacenterID == "" {
+			datacenterID = astra.StringValue(bundle.DatcenterID)
+		}
+		bundleURLs[datacenterID] = bundle.DownloadURL
+	}
+
+	dataAPIEndpoints := make(map[string]string, len(regions))
+	secureConnectBundleURLs := make(map[string]string, len(regions))
+	for _, r := range regions {
+		dataAPIEndpoints[r] = fmt.Sprintf("https://%s-%s.apps.astra.datastax.com", databaseID, r)
+		secureConnectBundleURLs[r] = bundleURLs[datacenters[r]]
+	}
+
+	d.SetId(fmt.Sprintf("%s/connection-info/%s", databaseID, region))
+	values := map[string]interface{}{
+		"region":                     region,
+		"regions":                    regions,
+		"keyspace":                   astra.StringValue(db.Info.Keyspace),
+		"data_api_endpoint":          dataAPIEndpoints[region],
+		"data_api_endpoints":         dataAPIEndpoints,
+		"cql_host":                   fmt.Sprintf("%s-%s.db.astra.datastax.com", databaseID, region),
+		"cql_port":                   astraCQLPort,
+		"secure_connect_bundle_url":  secureConnectBundleURLs[region],
+		"secure_connect_bundle_urls": secureConnectBundleURLs,
+	}
+	for attribute, value := range values {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
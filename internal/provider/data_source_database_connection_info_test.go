@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDatabaseConnectionInfoDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseConnectionInfoDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_database_connection_info.dev", "cql_port", "29042"),
+					resource.TestCheckResourceAttrSet("data.astra_database_connection_info.dev", "data_api_endpoint"),
+					resource.TestCheckResourceAttrSet("data.astra_database_connection_info.dev", "secure_connect_bundle_url"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccDatabaseConnectionInfoDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+data "astra_database_connection_info" "dev" {
+  database_id = "%s"
+}
+`, databaseID)
+}
+
+func TestMockDatabaseConnectionInfo(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	client := p.Meta().(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	resp, err := client.CreateDatabaseWithResponse(ctx, astra.CreateDatabaseJSONRequestBody{
+		Name:          "connectiondb",
+		Keyspace:      "ks1",
+		CloudProvider: "gcp",
+		Region:        "us-east1",
+		Tier:          astra.Serverless,
+		CapacityUnits: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 creating database, got %d: %s", resp.StatusCode(), resp.Body)
+	}
+	databaseID := resp.HTTPResponse.Header.Get("Location")
+	dcResp, err := client.AddDatacentersWithResponse(ctx, databaseID, astra.AddDatacentersJSONRequestBody{{CloudProvider: "gcp", Region: "europe-west1", Tier: astra.Serverless}})
+	if err != nil {
+		t.Fatal(err)
+	} else if dcResp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 adding datacenter, got %d: %s", dcResp.StatusCode(), dcResp.Body)
+	}
+
+	connectionInfo := p.DataSourcesMap["astra_database_connection_info"]
+	d := schema.TestResourceDataRaw(t, connectionInfo.Schema, map[string]interface{}{"database_id": databaseID})
+	if diags := connectionInfo.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatalf("failed to read connection info: %v", diags)
+	}
+	if region := d.Get("region"); region != "us-east1" {
+		t.Errorf("expected the region of the database to be the default, got %v", region)
+	}
+	if regions := d.Get("regions").([]interface{}); len(regions) != 2 {
+		t.Errorf("expected 2 regions, got %v", regions)
+	}
+	if endpoint := d.Get("data_api_endpoint"); endpoint != fmt.Sprintf("https://%s-us-east1.apps.astra.datastax.com", databaseID) {
+		t.Errorf("unexpected Data API endpoint %v", endpoint)
+	}
+	bundleURLs := d.Get("secure_connect_bundle_urls").(map[string]interface{})
+	if d.Get("secure_connect_bundle_url") == "" || bundleURLs["europe-west1"] == "" || bundleURLs["europe-west1"] == bundleURLs["us-east1"] {
+		t.Errorf("expected a secure connect bundle per region, got %v", bundleURLs)
+	}
+
+	d = schema.TestResourceDataRaw(t, connectionInfo.Schema, map[string]interface{}{"database_id": databaseID, "region": "us-west2"})
+	if diags := connectionInfo.ReadContext(ctx, d, p.Meta()); !diags.HasError() {
+		t.Error("expected an error for a region without datacenter")
+	}
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serverlessGuardrails are the guardrails of Astra DB Serverless databases, as documented in the database limits of the
+// Astra DB documentation. The DevOps API does not expose them.
+var serverlessGuardrails = map[string]int{
+	"max_tables":                      200,
+	"tables_warn_threshold":           100,
+	"max_columns_per_table":           75,
+	"max_indexes_per_table":           10,
+	"max_indexes":                     100,
+	"max_page_size_kb":                512,
+	"max_partition_keys_in_select":    20,
+	"max_in_select_cartesian_product": 25,
+}
+
+func dataSourceDatabaseGuardrails() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_database_guardrails` provides a datasource with the guardrails of an Astra database: the limits on the number of tables, columns and indexes, and on the size of queries. This can be used by modules generating schemas to validate them before creating tables.",
+
+		ReadContext: dataSourceDatabaseGuardrailsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Computed
+			"max_tables": {
+				Description: "The maximum number of tables in the database, across all keyspaces.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"tables_warn_threshold": {
+				Description: "The number of tables in the database above which a warning is returned when creating tables.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_columns_per_table": {
+				Description: "The maximum number of columns of a table.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_indexes_per_table": {
+				Description: "The maximum number of Storage-Attached Indexes of a table.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_indexes": {
+				Description: "The maximum number of Storage-Attached Indexes in the database.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_page_size_kb": {
+				Description: "The maximum size in KB of a page of query results.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_partition_keys_in_select": {
+				Description: "The maximum number of partition keys in the `IN` clause of a `SELECT`.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_in_select_cartesian_product": {
+				Description: "The maximum size of the cartesian product of the `IN` clauses of a `SELECT`.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseGuardrailsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+
+	// Make sure the database exists, so a wrong ID does not silently return limits
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.JSON200 == nil {
+		return diag.Errorf("error fetching database: %s", string(resp.Body))
+	}
+
+	d.SetId(databaseID)
+	for attribute, value := range serverlessGuardrails {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
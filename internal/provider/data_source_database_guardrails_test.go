@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDatabaseGuardrailsDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseGuardrailsDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_database_guardrails.dev", "max_tables", "200"),
+					resource.TestCheckResourceAttr("data.astra_database_guardrails.dev", "max_columns_per_table", "75"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccDatabaseGuardrailsDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+data "astra_database_guardrails" "dev" {
+  database_id = "%s"
+}
+`, databaseID)
+}
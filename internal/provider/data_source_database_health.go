@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDatabaseHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_database_health` provides a datasource with a health summary of an Astra database. This can be used to gate promotions or alert on capacity. Read and write rates are not exposed by the Astra DevOps API, use the `grafana_url` of the database for detailed metrics.",
+
+		ReadContext: dataSourceDatabaseHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Computed
+			"status": {
+				Description: "Database status.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"healthy": {
+				Description: "True when the database and all of its datacenters are `ACTIVE`.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"message": {
+				Description: "Message from Astra about the database, if any.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"available_actions": {
+				Description: "Actions that can currently be performed on the database.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"total_storage": {
+				Description: "Storage capacity of the database in GB (not relevant for serverless databases).",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"used_storage": {
+				Description: "Storage used by the database in GB, if reported by Astra.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"datacenters": {
+				Description: "Health of each datacenter of the database.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {
+							Description: "The datacenter ID.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: "The cloud provider region of the datacenter.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The datacenter status.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	db := resp.JSON200
+	if db == nil {
+		return diag.Errorf("error fetching database: %s", string(resp.Body))
+	}
+
+	d.SetId(fmt.Sprintf("%s/health", databaseID))
+	for k, v := range flattenDatabaseHealth(db) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func flattenDatabaseHealth(db *astra.Database) map[string]interface{} {
+	healthy := db.Status == astra.ACTIVE
+	datacenters := make([]map[string]interface{}, 0)
+	if db.Info.Datacenters != nil {
+		for _, dc := range *db.Info.Datacenters {
+			datacenters = append(datacenters, map[string]interface{}{
+				"datacenter_id": astra.StringValue(dc.Id),
+				"region":        dc.Region,
+				"status":        dc.Status,
+			})
+			if dc.Status != string(astra.ACTIVE) {
+				healthy = false
+			}
+		}
+	}
+
+	availableActions := make([]string, 0)
+	if db.AvailableActions != nil {
+		for _, action := range *db.AvailableActions {
+			availableActions = append(availableActions, string(action))
+		}
+	}
+
+	flatHealth := map[string]interface{}{
+		"status":            string(db.Status),
+		"healthy":           healthy,
+		"message":           astra.StringValue(db.Message),
+		"available_actions": availableActions,
+		"total_storage":     0,
+		"used_storage":      0,
+		"datacenters":       datacenters,
+	}
+	if db.Storage != nil {
+		flatHealth["total_storage"] = db.Storage.TotalStorage
+		if db.Storage.UsedStorage != nil {
+			flatHealth["used_storage"] = *db.Storage.UsedStorage
+		}
+	}
+	return flatHealth
+}
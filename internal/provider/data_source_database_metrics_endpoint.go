@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDatabaseMetricsEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_database_metrics_endpoint` provides a datasource with the Prometheus-compatible metrics endpoint of an Astra database and the credentials to scrape it. The endpoint is authenticated with the token of the provider as a bearer token.",
+
+		ReadContext: dataSourceDatabaseMetricsEndpointRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Computed
+			"url": {
+				Description: "The full URL of the metrics endpoint.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"scheme": {
+				Description: "The scheme of the metrics endpoint, for the `scheme` of a Prometheus scrape config.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"host": {
+				Description: "The host of the metrics endpoint, for the `targets` of a Prometheus scrape config.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"metrics_path": {
+				Description: "The path of the metrics endpoint, for the `metrics_path` of a Prometheus scrape config.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"bearer_token": {
+				Description: "The bearer token used to scrape the metrics endpoint.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseMetricsEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+
+	// Make sure the database exists, so a wrong ID does not produce an endpoint that can never be scraped
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.JSON200 == nil {
+		return diag.Errorf("error fetching database: %s", string(resp.Body))
+	}
+
+	endpoint, err := url.Parse(client.ClientInterface.(*astra.Client).Server)
+	if err != nil {
+		return diag.Errorf("invalid Astra API URL: %s", err)
+	}
+	endpoint = endpoint.JoinPath("v2", "databases", databaseID, "metrics")
+
+	d.SetId(fmt.Sprintf("%s/metrics", databaseID))
+	if err := d.Set("url", endpoint.String()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("scheme", endpoint.Scheme); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("host", endpoint.Host); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metrics_path", endpoint.Path); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("bearer_token", meta.(astraClients).token); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDatabaseMetricsEndpointDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseMetricsEndpointDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_database_metrics_endpoint.dev", "metrics_path", fmt.Sprintf("/v2/databases/%s/metrics", databaseID)),
+					resource.TestCheckResourceAttrSet("data.astra_database_metrics_endpoint.dev", "host"),
+					resource.TestCheckResourceAttrSet("data.astra_database_metrics_endpoint.dev", "bearer_token"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccDatabaseMetricsEndpointDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+data "astra_database_metrics_endpoint" "dev" {
+  database_id = "%s"
+}
+`, databaseID)
+}
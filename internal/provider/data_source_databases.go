@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -9,6 +11,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// databasesPageSize is the maximum number of databases returned by one call to the list databases endpoint
+const databasesPageSize = 100
+
 func dataSourceDatabases() *schema.Resource {
 	return &schema.Resource{
 		Description: "`astra_databases` provides a datasource for a list of Astra databases. This can be used to select databases within your Astra Organization.",
@@ -155,14 +160,11 @@ func dataSourceDatabasesRead(ctx context.Context, d *schema.ResourceData, meta i
 		params.Provider = &providerParam
 	}
 
-	resp, err := client.ListDatabasesWithResponse(ctx, params)
+	dbs, err := listDatabases(ctx, client, params)
 	if err != nil {
 		return diag.FromErr(err)
-	} else if resp.StatusCode() != 200 {
-		return diag.Errorf("unexpected list databases response: %s", string(resp.Body))
 	}
 
-	dbs := astra.DatabaseSlice(resp.JSON200)
 	flatDbs := make([]map[string]interface{}, 0, len(dbs))
 	for _, db := range dbs {
 		flatDbs = append(flatDbs, flattenDatabase(&db))
@@ -175,3 +177,27 @@ func dataSourceDatabasesRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	return nil
 }
+
+// listDatabases follows the pages of the list databases endpoint until all matching databases are returned
+func listDatabases(ctx context.Context, client *astra.ClientWithResponses, params *astra.ListDatabasesParams) ([]astra.Database, error) {
+	limit := databasesPageSize
+	params.Limit = &limit
+
+	var dbs []astra.Database
+	for {
+		resp, err := client.ListDatabasesWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		} else if resp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("unexpected list databases response: %s", string(resp.Body))
+		}
+
+		page := astra.DatabaseSlice(resp.JSON200)
+		dbs = append(dbs, page...)
+		if len(page) < limit {
+			return dbs, nil
+		}
+		startingAfter := page[len(page)-1].Id
+		params.StartingAfter = &startingAfter
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDatacenters() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_datacenters` provides a datasource that lists the datacenters of an Astra database.",
+
+		ReadContext: dataSourceDatacentersRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Optional inputs
+			"include_terminated": {
+				Description: "Also return datacenters in `TERMINATED` status. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			// Computed
+			"results": {
+				Description: "The list of datacenters of the database.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {
+							Description: "The datacenter ID.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The datacenter name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cloud_provider": {
+							Description: "The cloud provider of the datacenter.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: "The cloud provider region of the datacenter.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tier": {
+							Description: "The tier of the datacenter.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The datacenter status.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"capacity_units": {
+							Description: "The capacity units of the datacenter.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatacentersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	includeTerminated := d.Get("include_terminated").(bool)
+
+	params := astra.ListDatacentersParams{
+		All: &includeTerminated,
+	}
+	resp, err := client.ListDatacentersWithResponse(ctx, databaseID, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return diag.Errorf("error listing datacenters for database %s: %s", databaseID, string(resp.Body))
+	}
+
+	results := make([]map[string]interface{}, 0, len(*resp.JSON200))
+	for _, dc := range *resp.JSON200 {
+		results = append(results, flattenDatacenter(&dc))
+	}
+
+	d.SetId(fmt.Sprintf("%s/datacenters", databaseID))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenDatacenter(dc *astra.Datacenter) map[string]interface{} {
+	capacityUnits := 0
+	if dc.CapacityUnits != nil {
+		capacityUnits = *dc.CapacityUnits
+	}
+	return map[string]interface{}{
+		"datacenter_id":  astra.StringValue(dc.Id),
+		"name":           astra.StringValue(dc.Name),
+		"cloud_provider": string(dc.CloudProvider),
+		"region":         dc.Region,
+		"tier":           string(dc.Tier),
+		"status":         dc.Status,
+		"capacity_units": capacityUnits,
+	}
+}
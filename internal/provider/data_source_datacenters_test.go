@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDatacentersDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatacentersDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_datacenters.dev", "database_id", databaseID),
+					resource.TestCheckResourceAttrSet("data.astra_datacenters.dev", "results.0.datacenter_id"),
+					resource.TestCheckResourceAttrSet("data.astra_datacenters.dev", "results.0.region"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccDatacentersDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+data "astra_datacenters" "dev" {
+  database_id = "%s"
+}
+`, databaseID)
+}
@@ -2,9 +2,9 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -12,7 +12,7 @@ import (
 
 func dataSourceKeyspace() *schema.Resource {
 	return &schema.Resource{
-		Description: "`astra_keyspace` provides a datasource for a particular keyspace. See `astra_keyspaces` if you're looking to fetch all the keyspaces for a particular database.",
+		Description: "`astra_keyspace` provides a datasource for a particular keyspace, so configurations can depend on keyspaces managed elsewhere. See `astra_keyspaces` if you're looking to fetch all the keyspaces for a particular database.",
 
 		ReadContext: dataSourceKeyspaceRead,
 
@@ -25,9 +25,23 @@ func dataSourceKeyspace() *schema.Resource {
 				ValidateFunc: validation.IsUUID,
 			},
 			"name": {
-				Description: "The keyspace name.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "The keyspace name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			// Optional inputs
+			"must_exist": {
+				Description: "Whether or not to fail when the keyspace does not exist. Set to `false` to check for the keyspace with `exists` instead. Defaults to `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			// Computed outputs
+			"exists": {
+				Description: "Whether or not the keyspace exists in the database.",
+				Type:        schema.TypeBool,
+				Computed:    true,
 			},
 		},
 	}
@@ -44,13 +58,20 @@ func dataSourceKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
+	exists := false
 	for _, ks := range keyspaces {
 		if ks == keyspaceName {
-			d.SetId(fmt.Sprintf("%s/keyspace/%s", databaseID, ks))
-			return nil
+			exists = true
+			break
 		}
 	}
+	if !exists && d.Get("must_exist").(bool) {
+		return diag.Errorf("keyspace %s not found in database %s", keyspaceName, databaseID)
+	}
 
-	d.SetId("")
+	d.SetId(resourceid.Keyspace.Format(databaseID, keyspaceName))
+	if err := d.Set("exists", exists); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
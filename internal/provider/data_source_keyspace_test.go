@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestKeyspaceDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyspaceDataSource(databaseID, "terraform_missing_keyspace", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_keyspace.dev", "database_id", databaseID),
+					resource.TestCheckResourceAttr("data.astra_keyspace.dev", "exists", "false"),
+				),
+			},
+			{
+				Config:      testAccKeyspaceDataSource(databaseID, "terraform_missing_keyspace", true),
+				ExpectError: regexp.MustCompile("keyspace terraform_missing_keyspace not found"),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccKeyspaceDataSource(databaseID, name string, mustExist bool) string {
+	return fmt.Sprintf(`
+data "astra_keyspace" "dev" {
+  database_id = "%s"
+  name        = "%s"
+  must_exist  = %t
+}
+`, databaseID, name, mustExist)
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceOrganizationLimits() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_organization_limits` provides a datasource with the limits and current usage of the organization. This can be used to validate that a database or streaming resource can be created before attempting it.",
+
+		ReadContext: dataSourceOrganizationLimitsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Optional filters
+			"cloud_provider": {
+				Description:      "Only return database limits for the given cloud provider, if supplied.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringInSlice(availableCloudProviders, true),
+				DiffSuppressFunc: ignoreCase,
+			},
+			"region": {
+				Description: "Only return database limits for the given cloud provider region, if supplied.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"streaming_tenant": {
+				Description: "Name of a streaming tenant to fetch the namespace and topic limits of, if supplied.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			// Computed
+			"database_limits": {
+				Description: "Database limits and usage of the organization by tier, cloud provider and region.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tier": {
+							Description: "The database tier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cloud_provider": {
+							Description: "The cloud provider.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: "The cloud provider region.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"database_count_limit": {
+							Description: "The maximum number of databases.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"database_count_used": {
+							Description: "The number of databases in use.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"capacity_units_limit": {
+							Description: "The maximum number of capacity units.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"capacity_units_used": {
+							Description: "The number of capacity units in use.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"streaming_tenant_limits": {
+				Description: "Limits and usage of the streaming tenant, when `streaming_tenant` is supplied.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace_limit": {
+							Description: "The maximum number of namespaces in the tenant.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"namespace_count_used": {
+							Description: "The number of namespaces in the tenant.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"topic_per_namespace_limit": {
+							Description: "The maximum number of topics per namespace.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationLimitsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	cloudProvider := d.Get("cloud_provider").(string)
+	region := d.Get("region").(string)
+	tenantName := d.Get("streaming_tenant").(string)
+
+	resp, err := client.ListAvailableRegionsWithResponse(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return diag.Errorf("unexpected list available regions response: %s", string(resp.Body))
+	}
+
+	databaseLimits := make([]map[string]interface{}, 0, len(*resp.JSON200))
+	for _, r := range *resp.JSON200 {
+		if cloudProvider != "" && !strings.EqualFold(string(r.CloudProvider), cloudProvider) {
+			continue
+		}
+		if region != "" && !strings.EqualFold(r.Region, region) {
+			continue
+		}
+		databaseLimits = append(databaseLimits, map[string]interface{}{
+			"tier":                 string(r.Tier),
+			"cloud_provider":       string(r.CloudProvider),
+			"region":               r.Region,
+			"database_count_limit": r.DatabaseCountLimit,
+			"database_count_used":  r.DatabaseCountUsed,
+			"capacity_units_limit": r.CapacityUnitsLimit,
+			"capacity_units_used":  r.CapacityUnitsUsed,
+		})
+	}
+
+	tenantLimits := make([]map[string]interface{}, 0, 1)
+	if tenantName != "" {
+		limits, err := getStreamingTenantLimits(ctx, streamingClient, tenantName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tenantLimits = append(tenantLimits, limits)
+	}
+
+	d.SetId(id.UniqueId())
+	if err := d.Set("database_limits", databaseLimits); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("streaming_tenant_limits", tenantLimits); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getStreamingTenantLimits(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, tenantName string) (map[string]interface{}, error) {
+	resp, err := streamingClient.GetLimitsWithResponse(ctx, tenantName)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected streaming tenant limits response: %s", string(resp.Body))
+	}
+
+	limits := map[string]interface{}{
+		"namespace_limit":           0,
+		"namespace_count_used":      0,
+		"topic_per_namespace_limit": 0,
+	}
+	if body := resp.JSON200.Body; body != nil {
+		if body.NamespaceLimit != nil {
+			limits["namespace_limit"] = int(*body.NamespaceLimit)
+		}
+		if body.TopicPerNamespaceLimit != nil {
+			limits["topic_per_namespace_limit"] = int(*body.TopicPerNamespaceLimit)
+		}
+		if body.Usage != nil {
+			limits["namespace_count_used"] = len(*body.Usage)
+		}
+	}
+	return limits, nil
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestOrganizationLimitsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationLimitsDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_organization_limits.dev", "database_limits.0.cloud_provider", "gcp"),
+					resource.TestCheckResourceAttrSet("data.astra_organization_limits.dev", "database_limits.0.database_count_limit"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccOrganizationLimitsDataSource() string {
+	return `
+data "astra_organization_limits" "dev" {
+  cloud_provider = "gcp"
+}
+`
+}
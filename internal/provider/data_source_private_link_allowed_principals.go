@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePrivateLinkAllowedPrincipals() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_private_link_allowed_principals` provides a datasource that lists the allowed principals and endpoint connections of the private links of an Astra database. This can be used to reconcile the Astra side of a private link with the cloud provider side.",
+
+		ReadContext: dataSourcePrivateLinkAllowedPrincipalsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Optional
+			"datacenter_id": {
+				Description: "Only return the private link of the given datacenter, if supplied.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			// Computed
+			"results": {
+				Type:        schema.TypeList,
+				Description: "The private links of the database, one per datacenter.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {
+							Description: "The datacenter ID.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"service_name": {
+							Description: "The endpoint service name to connect to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"allowed_principals": {
+							Description: "The principals allowed to connect to the endpoint service.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"endpoint_connections": {
+							Description: "The endpoint connections to the endpoint service.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"endpoint_id": {
+										Description: "The ID of the endpoint on the cloud provider side.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"link_id": {
+										Description: "The ID of the connection between the endpoint service and the endpoint.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"status": {
+										Description: "The connection status.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"description": {
+										Description: "The endpoint description.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"create_time": {
+										Description: "The time the connection was created.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePrivateLinkAllowedPrincipalsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	datacenterID := d.Get("datacenter_id").(string)
+
+	var privateLinks []astra.PrivateLinkDatacenterOutput
+	if datacenterID != "" {
+		resp, err := client.GetPrivateLinksForDatacenterWithResponse(ctx, databaseID, datacenterID)
+		if err != nil {
+			return diag.FromErr(err)
+		} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return diag.Errorf("error fetching private link for datacenter %s: %s", datacenterID, string(resp.Body))
+		}
+		privateLinks = append(privateLinks, *resp.JSON200)
+	} else {
+		resp, err := client.ListPrivateLinksForClusterWithResponse(ctx, databaseID)
+		if err != nil {
+			return diag.FromErr(err)
+		} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return diag.Errorf("error listing private links for database %s: %s", databaseID, string(resp.Body))
+		}
+		if resp.JSON200.Datacenters != nil {
+			privateLinks = *resp.JSON200.Datacenters
+		}
+	}
+
+	results := make([]map[string]interface{}, 0, len(privateLinks))
+	for i := range privateLinks {
+		results = append(results, flattenPrivateLinkAllowedPrincipals(&privateLinks[i]))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", databaseID, datacenterID))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenPrivateLinkAllowedPrincipals(pl *astra.PrivateLinkDatacenterOutput) map[string]interface{} {
+	allowedPrincipals := make([]string, 0)
+	if pl.AllowedPrincipals != nil {
+		allowedPrincipals = append(allowedPrincipals, *pl.AllowedPrincipals...)
+	}
+
+	connections := make([]map[string]interface{}, 0)
+	if pl.Endpoints != nil {
+		for _, e := range *pl.Endpoints {
+			status := ""
+			if e.Status != nil {
+				status = string(*e.Status)
+			}
+			connections = append(connections, map[string]interface{}{
+				"endpoint_id": astra.StringValue(e.EndpointID),
+				"link_id":     astra.StringValue(e.LinkID),
+				"status":      status,
+				"description": astra.StringValue(e.Description),
+				"create_time": astra.StringValue(e.CreatedDateTime),
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"datacenter_id":        astra.StringValue(pl.DatacenterID),
+		"service_name":         astra.StringValue(pl.ServiceName),
+		"allowed_principals":   allowedPrincipals,
+		"endpoint_connections": connections,
+	}
+}
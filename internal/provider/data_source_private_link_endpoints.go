@@ -102,6 +102,9 @@ func listPrivateLinkEndpoints(ctx context.Context, client *astra.ClientWithRespo
 		return nil, err
 	}
 
+	if err := checkReadStatus(resp.StatusCode(), resp.Body, fmt.Sprintf("database %s", databaseID)); err != nil {
+		return nil, err
+	}
 	db := resp.JSON200
 	if db == nil {
 		return nil, fmt.Errorf("error fetching database: %s", string(resp.Body))
@@ -116,10 +119,11 @@ func listPrivateLinkEndpoints(ctx context.Context, client *astra.ClientWithRespo
 	if err != nil {
 		return nil, err
 	}
+	if err := checkReadStatus(plResponse.StatusCode(), plResponse.Body, fmt.Sprintf("private link endpoint %s", endpointID)); err != nil {
+		return nil, err
+	}
 
-	privateLinkEndpointOutput := plResponse.JSON200
-
-	return privateLinkEndpointOutput, err
+	return plResponse.JSON200, nil
 }
 
 func privateLinkEndpointsToMap(privateLinkEndpoints *astra.PrivateLinkEndpoint) []map[string]interface{} {
@@ -103,6 +103,9 @@ func listPrivateLinks(ctx context.Context, client *astra.ClientWithResponses, da
 		return nil, err
 	}
 
+	if err := checkReadStatus(resp.StatusCode(), resp.Body, fmt.Sprintf("database %s", databaseID)); err != nil {
+		return nil, err
+	}
 	db := resp.JSON200
 	if db == nil {
 		return nil, fmt.Errorf("error fetching database: %s", string(resp.Body))
@@ -114,10 +117,14 @@ func listPrivateLinks(ctx context.Context, client *astra.ClientWithResponses, da
 	}
 
 	plResponse, err := client.GetPrivateLinksForDatacenterWithResponse(ctx, databaseID, datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReadStatus(plResponse.StatusCode(), plResponse.Body, fmt.Sprintf("private links of datacenter %s", datacenterID)); err != nil {
+		return nil, err
+	}
 
-	privateLinkOutput := plResponse.JSON200
-
-	return privateLinkOutput, err
+	return plResponse.JSON200, nil
 }
 
 func privateLinksToMap(privateLinks *astra.PrivateLinkDatacenterOutput) []map[string]interface{} {
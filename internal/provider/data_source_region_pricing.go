@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var regionPricingProducts = []string{
+	"database",
+	"streaming",
+}
+
+func dataSourceRegionPricing() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_region_pricing` provides a datasource with the cost of running Astra databases and streaming tenants in each available cloud region. All costs are in US cents.",
+
+		ReadContext: dataSourceRegionPricingRead,
+
+		Schema: map[string]*schema.Schema{
+			// Optional filters
+			"product": {
+				Description:  "Only return pricing for the given product, if supplied. One of `database` or `streaming`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(regionPricingProducts, false),
+			},
+			"cloud_provider": {
+				Description:      "Only return pricing for the given cloud provider, if supplied. (Currently supported: aws, azure, gcp)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringInSlice(availableCloudProviders, true),
+				DiffSuppressFunc: ignoreCase,
+			},
+			"region": {
+				Description: "Only return pricing for the given cloud provider region, if supplied.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			// Computed
+			"results": {
+				Description: "The list of region costs by product, cloud provider and tier.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"product": {
+							Description: "The product the cost applies to, `database` or `streaming`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tier": {
+							Description: "The tier the cost applies to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cloud_provider": {
+							Description: "The cloud provider",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: "The cloud provider region",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cost_per_min_cents": {
+							Description: "Cost per minute in cents.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_hour_cents": {
+							Description: "Cost per hour in cents.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_day_cents": {
+							Description: "Cost per day in cents.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_month_cents": {
+							Description: "Cost per month in cents.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_min_parked_cents": {
+							Description: "Cost per minute in cents while parked.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_hour_parked_cents": {
+							Description: "Cost per hour in cents while parked.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_day_parked_cents": {
+							Description: "Cost per day in cents while parked.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_month_parked_cents": {
+							Description: "Cost per month in cents while parked.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_network_gb_cents": {
+							Description: "Cost per GB of network transfer in cents (streaming only).",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_written_gb_cents": {
+							Description: "Cost per GB written in cents (streaming only).",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"cost_per_read_gb_cents": {
+							Description: "Cost per GB read in cents (streaming only).",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRegionPricingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	product := d.Get("product").(string)
+	cloudProvider := d.Get("cloud_provider").(string)
+	region := d.Get("region").(string)
+
+	results := make([]map[string]interface{}, 0)
+	if product == "" || product == "database" {
+		dbCosts, err := listDatabaseRegionCosts(ctx, client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results = append(results, dbCosts...)
+	}
+	if product == "" || product == "streaming" {
+		streamingCosts, err := listStreamingRegionCosts(ctx, streamingClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results = append(results, streamingCosts...)
+	}
+
+	flatCosts := make([]map[string]interface{}, 0, len(results))
+	for _, cost := range results {
+		if cloudProvider != "" && !strings.EqualFold(cost["cloud_provider"].(string), cloudProvider) {
+			continue
+		}
+		if region != "" && !strings.EqualFold(cost["region"].(string), region) {
+			continue
+		}
+		flatCosts = append(flatCosts, cost)
+	}
+
+	d.SetId(id.UniqueId())
+	if err := d.Set("results", flatCosts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func listDatabaseRegionCosts(ctx context.Context, client *astra.ClientWithResponses) ([]map[string]interface{}, error) {
+	resp, err := client.ListAvailableRegionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected list available regions response: %s", string(resp.Body))
+	}
+
+	costs := make([]map[string]interface{}, 0, len(*resp.JSON200))
+	for _, r := range *resp.JSON200 {
+		costs = append(costs, map[string]interface{}{
+			"product":                     "database",
+			"tier":                        string(r.Tier),
+			"cloud_provider":              string(r.CloudProvider),
+			"region":                      r.Region,
+			"cost_per_min_cents":          floatValue(r.Cost.CostPerMinCents),
+			"cost_per_hour_cents":         floatValue(r.Cost.CostPerHourCents),
+			"cost_per_day_cents":          floatValue(r.Cost.CostPerDayCents),
+			"cost_per_month_cents":        floatValue(r.Cost.CostPerMonthCents),
+			"cost_per_min_parked_cents":   floatValue(r.Cost.CostPerMinParkedCents),
+			"cost_per_hour_parked_cents":  floatValue(r.Cost.CostPerHourParkedCents),
+			"cost_per_day_parked_cents":   floatValue(r.Cost.CostPerDayParkedCents),
+			"cost_per_month_parked_cents": floatValue(r.Cost.CostPerMonthParkedCents),
+			"cost_per_network_gb_cents":   0.0,
+			"cost_per_written_gb_cents":   0.0,
+			"cost_per_read_gb_cents":      0.0,
+		})
+	}
+	return costs, nil
+}
+
+func listStreamingRegionCosts(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses) ([]map[string]interface{}, error) {
+	regions, err := listStreamingRegions(ctx, streamingClient)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make([]map[string]interface{}, 0, len(regions))
+	for _, r := range regions {
+		costs = append(costs, map[string]interface{}{
+			"product":                     "streaming",
+			"tier":                        r.Tier,
+			"cloud_provider":              r.CloudProvider,
+			"region":                      r.Region,
+			"cost_per_min_cents":          r.Cost.CostPerMinCents,
+			"cost_per_hour_cents":         r.Cost.CostPerHourCents,
+			"cost_per_day_cents":          r.Cost.CostPerDayCents,
+			"cost_per_month_cents":        r.Cost.CostPerMonthCents,
+			"cost_per_min_parked_cents":   r.Cost.CostPerMinParkedCents,
+			"cost_per_hour_parked_cents":  r.Cost.CostPerHourParkedCents,
+			"cost_per_day_parked_cents":   r.Cost.CostPerDayParkedCents,
+			"cost_per_month_parked_cents": r.Cost.CostPerMonthParkedCents,
+			"cost_per_network_gb_cents":   r.Cost.CostPerNetworkGbCents,
+			"cost_per_written_gb_cents":   r.Cost.CostPerWrittenGbCents,
+			"cost_per_read_gb_cents":      r.Cost.CostPerReadGbCents,
+		})
+	}
+	return costs, nil
+}
+
+func listStreamingRegions(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses) (ServerlessStreamingAvailableRegionsResult, error) {
+	resp, err := streamingClient.ListAvailableRegionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("unexpected list streaming regions response: %s", string(resp.Body))
+	}
+
+	// the typed response omits the per GB costs, so decode the full payload
+	var regions ServerlessStreamingAvailableRegionsResult
+	if err := json.Unmarshal(resp.Body, &regions); err != nil {
+		return nil, fmt.Errorf("failed to decode streaming regions: %w", err)
+	}
+	return regions, nil
+}
+
+func floatValue(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
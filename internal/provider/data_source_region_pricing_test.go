@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRegionPricingDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRegionPricingDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_region_pricing.dev", "results.0.product", "database"),
+					resource.TestCheckResourceAttrSet("data.astra_region_pricing.dev", "results.0.cost_per_hour_cents"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccRegionPricingDataSource() string {
+	return `
+data "astra_region_pricing" "dev" {
+  product = "database"
+}
+`
+}
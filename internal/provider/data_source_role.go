@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -56,6 +58,11 @@ func dataSourceRole() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"policy_document": {
+				Description: "Canonical JSON of the effective policy of the role: its effect and its sorted actions and resources. It can be compared between roles, audited, or decoded with `jsondecode` to create a similar role.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -86,21 +93,22 @@ func listRole(ctx context.Context, client *astra.ClientWithResponses, roleID str
 		return nil, err
 	}
 
-	if resp.StatusCode() > 200 {
-		return nil, fmt.Errorf("fetching role \"%s\" was not successful. Message: %s", roleID, string(resp.Body))
+	if err := checkReadStatus(resp.StatusCode(), resp.Body, fmt.Sprintf("role %s", roleID)); err != nil {
+		return nil, err
 	}
 
-	return resp.JSON200, err
+	return resp.JSON200, nil
 }
 
 func flattenRole(role astra.Role) map[string]interface{} {
 	flatRole := map[string]interface{}{
-		"role_id":     *role.Id,
-		"role_name":   *role.Name,
-		"description": "",
-		"effect":      "",
-		"resources":   []string{},
-		"policy":      []string{},
+		"role_id":         *role.Id,
+		"role_name":       *role.Name,
+		"description":     "",
+		"effect":          "",
+		"resources":       []string{},
+		"policy":          []string{},
+		"policy_document": "",
 	}
 
 	if role.Policy != nil {
@@ -115,11 +123,34 @@ func flattenRole(role astra.Role) map[string]interface{} {
 			}
 			flatRole["policy"] = policies
 		}
+		flatRole["policy_document"], _ = rolePolicyDocument(*role.Policy)
 	}
 
 	return flatRole
 }
 
+// rolePolicyDocument returns the policy as canonical JSON, with sorted actions and resources. The description is left
+// out, as it does not change what the policy grants.
+func rolePolicyDocument(policy astra.Policy) (string, error) {
+	actions := make([]string, len(policy.Actions))
+	for index, a := range policy.Actions {
+		actions[index] = string(a)
+	}
+	sort.Strings(actions)
+	resources := append([]string{}, policy.Resources...)
+	sort.Strings(resources)
+	document, err := json.Marshal(struct {
+		Effect    string   `json:"effect"`
+		Actions   []string `json:"actions"`
+		Resources []string `json:"resources"`
+	}{
+		Effect:    string(policy.Effect),
+		Actions:   actions,
+		Resources: resources,
+	})
+	return string(document), err
+}
+
 func setRoleData(d *schema.ResourceData, role *astra.Role) error {
 	flatRole := flattenRole(*role)
 	for k, v := range flatRole {
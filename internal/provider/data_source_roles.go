@@ -61,6 +61,11 @@ func dataSourceRoles() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+						"policy_document": {
+							Description: "Canonical JSON of the effective policy of the role: its effect and its sorted actions and resources. It can be compared between roles, audited, or decoded with `jsondecode` to create a similar role.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSecureConnectBundleURLs() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_secure_connect_bundle_urls` provides a datasource that generates temporary secure connect bundle URLs for every datacenter of a database, keyed by datacenter ID. These URLs last five minutes. This is useful for multi-region databases where each regional deployment needs the bundle of its own datacenter.",
+
+		ReadContext: dataSourceSecureConnectBundleURLsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Computed
+			"urls": {
+				Description: "Map of datacenter ID to the temporary download url of its secure connect bundle zip file.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"internal_urls": {
+				Description: "Map of datacenter ID to the temporary internal download url of its secure connect bundle zip file.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSecureConnectBundleURLsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+
+	creds, err := getSecureConnectBundles(ctx, client, databaseID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	urls := make(map[string]string, len(creds))
+	internalURLs := make(map[string]string, len(creds))
+	downloadURLs := make([]string, 0, len(creds))
+	for _, bundle := range creds {
+		// DevOps APi has a misspelling that they might fix
+		var datacenterID string
+		if bundle.DatacenterID != nil {
+			datacenterID = *bundle.DatacenterID
+		} else if bundle.DatcenterID != nil {
+			datacenterID = *bundle.DatcenterID
+		}
+		urls[datacenterID] = bundle.DownloadURL
+		internalURLs[datacenterID] = bundle.DownloadURLInternal
+		downloadURLs = append(downloadURLs, bundle.DownloadURL)
+	}
+
+	d.SetId(fmt.Sprintf("%s/secure-connect-bundles/%s", databaseID, keyFromStrings(downloadURLs)))
+	if err := d.Set("urls", urls); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("internal_urls", internalURLs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
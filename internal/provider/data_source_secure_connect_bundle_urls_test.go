@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestSecureConnectBundleURLsDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecureConnectBundleURLsDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_secure_connect_bundle_urls.dev", "urls.%"),
+					resource.TestCheckResourceAttrSet("data.astra_secure_connect_bundle_urls.dev", fmt.Sprintf("urls.%s-1", databaseID)),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccSecureConnectBundleURLsDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+data "astra_secure_connect_bundle_urls" "dev" {
+  database_id = "%s"
+}
+`, databaseID)
+}
@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	streamingConnectorTypeSink   = "sink"
+	streamingConnectorTypeSource = "source"
+)
+
+func dataSourceStreamingConnectorStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_streaming_connector_status` provides a datasource with the runtime status of a sink or a source of a namespace of a streaming tenant, like `astra_streaming_sink`. This can be used to check that a connector actually started after it was created, for example in a `check` block.",
+
+		ReadContext: dataSourceStreamingConnectorStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"tenant_name": {
+				Description: "Name of the streaming tenant.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"cluster_name": {
+				Description: "Name of the Pulsar Cluster. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"namespace": {
+				Description: "Pulsar namespace of the connector.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "Name of the connector, for example the `sink_name` of `astra_streaming_sink`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Optional inputs
+			"type": {
+				Description:  "Type of the connector, `sink` or `source`. Defaults to `sink`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      streamingConnectorTypeSink,
+				ValidateFunc: validation.StringInSlice([]string{streamingConnectorTypeSink, streamingConnectorTypeSource}, false),
+			},
+			// Computed
+			"num_instances": {
+				Description: "The number of instances of the connector.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"num_running": {
+				Description: "The number of running instances of the connector.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"running": {
+				Description: "Whether all the instances of the connector are running.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"num_restarts": {
+				Description: "The total number of restarts of the instances of the connector.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"last_error": {
+				Description: "The latest error of the instances of the connector, empty when there was no error.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"instances": {
+				Description: "The status of each instance of the connector.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Description: "The ID of the instance.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"running": {
+							Description: "Whether the instance is running.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"num_restarts": {
+							Description: "The number of restarts of the instance.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"worker_id": {
+							Description: "The ID of the function worker running the instance.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_error": {
+							Description: "The latest error of the instance, empty when there was no error.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_error_time": {
+							Description: "The time of the latest exception of the instance in RFC3339 format, empty when there was no exception.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// StreamingConnectorStatus is the status of a sink or a source in the Pulsar admin API
+type StreamingConnectorStatus struct {
+	NumInstances int                                `json:"numInstances"`
+	NumRunning   int                                `json:"numRunning"`
+	Instances    []StreamingConnectorInstanceStatus `json:"instances"`
+}
+
+// StreamingConnectorInstanceStatus is the status of an instance of a sink or a source
+type StreamingConnectorInstanceStatus struct {
+	InstanceID int `json:"instanceId"`
+	Status     struct {
+		Running                bool                          `json:"running"`
+		Error                  string                        `json:"error"`
+		NumRestarts            int                           `json:"numRestarts"`
+		WorkerID               string                        `json:"workerId"`
+		LatestSystemExceptions []StreamingConnectorException `json:"latestSystemExceptions"`
+		LatestSinkExceptions   []StreamingConnectorException `json:"latestSinkExceptions"`
+		LatestSourceExceptions []StreamingConnectorException `json:"latestSourceExceptions"`
+	} `json:"status"`
+}
+
+// StreamingConnectorException is an exception raised by an instance of a sink or a source
+type StreamingConnectorException struct {
+	ExceptionString string `json:"exceptionString"`
+	TimestampMs     int64  `json:"timestampMs"`
+}
+
+func dataSourceStreamingConnectorStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenant := d.Get("tenant_name").(string)
+	pulsarCluster := d.Get("cluster_name").(string)
+	namespace := d.Get("namespace").(string)
+	name := d.Get("name").(string)
+	connectorType := d.Get("type").(string)
+
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := fmt.Sprintf("admin/v3/%ss/%s/%s/%s/status", connectorType, tenant, namespace, name)
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, path, pulsarCluster, pulsarToken)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if statusCode == http.StatusNotFound {
+		return diag.Errorf("%s %s not found in namespace %s/%s", connectorType, name, tenant, namespace)
+	}
+	if statusCode != http.StatusOK {
+		return diag.Errorf("error fetching status of %s %s: %s", connectorType, name, string(body))
+	}
+	var status StreamingConnectorStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return diag.Errorf("failed to decode status of %s %s: %s", connectorType, name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s/%s/status", pulsarCluster, tenant, namespace, connectorType, name))
+	for attribute, value := range flattenStreamingConnectorStatus(status) {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func flattenStreamingConnectorStatus(status StreamingConnectorStatus) map[string]interface{} {
+	numRestarts := 0
+	lastError := ""
+	var lastErrorTime int64
+	instances := make([]map[string]interface{}, 0, len(status.Instances))
+	for _, instance := range status.Instances {
+		instanceError, instanceErrorTime := latestStreamingConnectorError(instance)
+		if instanceError != "" && (lastError == "" || instanceErrorTime > lastErrorTime) {
+			lastError, lastErrorTime = instanceError, instanceErrorTime
+		}
+		numRestarts += instance.Status.NumRestarts
+
+		errorTime := ""
+		if instanceErrorTime > 0 {
+			errorTime = time.UnixMilli(instanceErrorTime).UTC().Format(time.RFC3339)
+		}
+		instances = append(instances, map[string]interface{}{
+			"instance_id":     instance.InstanceID,
+			"running":         instance.Status.Running,
+			"num_restarts":    instance.Status.NumRestarts,
+			"worker_id":       instance.Status.WorkerID,
+			"last_error":      instanceError,
+			"last_error_time": errorTime,
+		})
+	}
+	return map[string]interface{}{
+		"num_instances": status.NumInstances,
+		"num_running":   status.NumRunning,
+		"running":       status.NumInstances > 0 && status.NumRunning == status.NumInstances,
+		"num_restarts":  numRestarts,
+		"last_error":    lastError,
+		"instances":     instances,
+	}
+}
+
+// latestStreamingConnectorError returns the latest exception of the instance and its time in milliseconds. The error
+// of the status, set when the instance failed to start, is used when there was no exception.
+func latestStreamingConnectorError(instance StreamingConnectorInstanceStatus) (string, int64) {
+	latest := StreamingConnectorException{}
+	for _, exceptions := range [][]StreamingConnectorException{
+		instance.Status.LatestSystemExceptions,
+		instance.Status.LatestSinkExceptions,
+		instance.Status.LatestSourceExceptions,
+	} {
+		for _, e := range exceptions {
+			if e.ExceptionString != "" && (latest.ExceptionString == "" || e.TimestampMs > latest.TimestampMs) {
+				latest = e
+			}
+		}
+	}
+	if latest.ExceptionString == "" {
+		return instance.Status.Error, 0
+	}
+	return latest.ExceptionString, latest.TimestampMs
+}
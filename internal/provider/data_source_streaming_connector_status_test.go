@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingConnectorStatusDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TENANT", "ASTRA_TEST_STREAMING_CLUSTER")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+	cluster := os.Getenv("ASTRA_TEST_STREAMING_CLUSTER")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStreamingConnectorStatusDataSource(tenant, cluster),
+				ExpectError: regexp.MustCompile("sink terraform-test-missing not found"),
+			},
+		},
+	})
+}
+
+func testAccStreamingConnectorStatusDataSource(tenant, cluster string) string {
+	return fmt.Sprintf(`
+data "astra_streaming_connector_status" "dev" {
+  tenant_name  = "%s"
+  cluster_name = "%s"
+  namespace    = "default"
+  name         = "terraform-test-missing"
+}
+`, tenant, cluster)
+}
+
+func TestFlattenStreamingConnectorStatus(t *testing.T) {
+	var status StreamingConnectorStatus
+	err := json.Unmarshal([]byte(`{
+  "numInstances": 2,
+  "numRunning": 1,
+  "instances": [
+    {"instanceId": 0, "status": {"running": true, "numRestarts": 1, "workerId": "w1",
+      "latestSystemExceptions": [{"exceptionString": "old", "timestampMs": 1700000000000}],
+      "latestSinkExceptions": [{"exceptionString": "connection refused", "timestampMs": 1700000060000}]}},
+    {"instanceId": 1, "status": {"running": false, "error": "failed to start", "numRestarts": 2, "workerId": "w2"}}
+  ]
+}`), &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flat := flattenStreamingConnectorStatus(status)
+	if flat["running"] != false || flat["num_restarts"] != 3 || flat["last_error"] != "connection refused" {
+		t.Fatalf("unexpected status: %v", flat)
+	}
+	instances := flat["instances"].([]map[string]interface{})
+	if instances[0]["last_error_time"] != "2023-11-14T22:14:20Z" {
+		t.Fatalf("unexpected time of the latest error: %v", instances[0]["last_error_time"])
+	}
+	if instances[1]["last_error"] != "failed to start" || instances[1]["last_error_time"] != "" {
+		t.Fatalf("expected the error of the status when there is no exception, got %v", instances[1])
+	}
+
+	flat = flattenStreamingConnectorStatus(StreamingConnectorStatus{NumInstances: 1, NumRunning: 1})
+	if flat["running"] != true || flat["last_error"] != "" {
+		t.Fatalf("unexpected status: %v", flat)
+	}
+}
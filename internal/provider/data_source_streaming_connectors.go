@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStreamingConnectors() *schema.Resource {
+	connectorSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The connector name, which is the `sink_name` of `astra_streaming_sink`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "The connector description.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"archive": {
+				Description: "The archive of the connector, in the format `builtin://<name>`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"class_name": {
+				Description: "The Java class of the connector.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "`astra_streaming_connectors` provides a datasource that lists the builtin Pulsar IO sink and source connectors available to a streaming tenant. This can be used to check the `sink_name` of `astra_streaming_sink` at plan time.",
+
+		ReadContext: dataSourceStreamingConnectorsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"tenant_name": {
+				Description: "Name of the streaming tenant.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"cluster_name": {
+				Description: "Name of the Pulsar Cluster. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Computed
+			"pulsar_version": {
+				Description: "The Pulsar version of the cluster, which determines the versions of the connectors.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sinks": {
+				Description: "The builtin sink connectors.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        connectorSchema,
+			},
+			"sources": {
+				Description: "The builtin source connectors.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        connectorSchema,
+			},
+		},
+	}
+}
+
+func dataSourceStreamingConnectorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenant := d.Get("tenant_name").(string)
+	pulsarCluster := d.Get("cluster_name").(string)
+
+	pulsarToken, org, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sinks, err := listStreamingConnectors(ctx, streamingClientv3, "admin/v3/sinks/builtinsinks", pulsarCluster, pulsarToken)
+	if err != nil {
+		return diag.Errorf("error listing builtin sinks: %s", err)
+	}
+	sources, err := listStreamingConnectors(ctx, streamingClientv3, "admin/v3/sources/builtinsources", pulsarCluster, pulsarToken)
+	if err != nil {
+		return diag.Errorf("error listing builtin sources: %s", err)
+	}
+
+	pulsarVersion := ""
+	clustersResp, err := streamingClient.GetPulsarClustersWithResponse(ctx, org.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if clustersResp.StatusCode() != http.StatusOK {
+		return diag.Errorf("error listing streaming clusters: %s", string(clustersResp.Body))
+	}
+	var clusters StreamingClusters
+	if err := json.Unmarshal(clustersResp.Body, &clusters); err != nil {
+		return diag.Errorf("failed to decode streaming clusters: %s", err)
+	}
+	for _, c := range clusters {
+		if c.TenantName == tenant && c.ClusterName == pulsarCluster {
+			pulsarVersion = c.PulsarVersion
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/connectors", tenant, pulsarCluster))
+	if err := d.Set("pulsar_version", pulsarVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("sinks", flattenStreamingConnectors(sinks, true)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("sources", flattenStreamingConnectors(sources, false)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// StreamingConnectorDefinition is the definition of a builtin Pulsar IO connector
+type StreamingConnectorDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SinkClass   string `json:"sinkClass"`
+	SourceClass string `json:"sourceClass"`
+}
+
+func listStreamingConnectors(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, path, pulsarCluster, pulsarToken string) ([]StreamingConnectorDefinition, error) {
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, path, pulsarCluster, pulsarToken)
+	if err != nil {
+		return nil, err
+	} else if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", string(body))
+	}
+	var connectors []StreamingConnectorDefinition
+	if err := json.Unmarshal(body, &connectors); err != nil {
+		return nil, fmt.Errorf("failed to decode connectors: %w", err)
+	}
+	return connectors, nil
+}
+
+func flattenStreamingConnectors(connectors []StreamingConnectorDefinition, sinks bool) []map[string]interface{} {
+	flat := make([]map[string]interface{}, 0, len(connectors))
+	for _, c := range connectors {
+		className := c.SourceClass
+		if sinks {
+			className = c.SinkClass
+		}
+		flat = append(flat, map[string]interface{}{
+			"name":        c.Name,
+			"description": c.Description,
+			"archive":     fmt.Sprintf("builtin://%s", c.Name),
+			"class_name":  className,
+		})
+	}
+	return flat
+}
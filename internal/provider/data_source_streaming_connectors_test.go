@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingConnectorsDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TENANT", "ASTRA_TEST_STREAMING_CLUSTER")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+	cluster := os.Getenv("ASTRA_TEST_STREAMING_CLUSTER")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingConnectorsDataSource(tenant, cluster),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_streaming_connectors.dev", "sinks.0.name"),
+					resource.TestCheckResourceAttrSet("data.astra_streaming_connectors.dev", "sinks.0.archive"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccStreamingConnectorsDataSource(tenant, cluster string) string {
+	return fmt.Sprintf(`
+data "astra_streaming_connectors" "dev" {
+  tenant_name  = "%s"
+  cluster_name = "%s"
+}
+`, tenant, cluster)
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStreamingNamespaces() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_streaming_namespaces` provides a datasource that lists the namespaces of a streaming tenant and their policies.",
+
+		ReadContext: dataSourceStreamingNamespacesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"tenant_name": {
+				Description: "Name of the streaming tenant.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"cluster_name": {
+				Description: "Name of the Pulsar Cluster. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Computed
+			"results": {
+				Description: "The list of namespaces in the tenant.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace": {
+							Description: "The namespace name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"message_ttl_seconds": {
+							Description: "Time to live of unacknowledged messages in seconds, 0 when not set.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"retention_time_minutes": {
+							Description: "Retention time of acknowledged messages in minutes, 0 when not set.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"retention_size_mb": {
+							Description: "Retention size of acknowledged messages in MB, 0 when not set.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"deduplication_enabled": {
+							Description: "Whether message deduplication is enabled.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"schema_validation_enforced": {
+							Description: "Whether producers without a schema are rejected.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"subscription_expiration_time_minutes": {
+							Description: "Time in minutes after which inactive subscriptions are deleted, 0 when not set.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"inactive_topic_delete_enabled": {
+							Description: "Whether inactive topics are deleted.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"inactive_topic_delete_mode": {
+							Description: "Which inactive topics are deleted, `delete_when_no_subscriptions` or `delete_when_subscriptions_caught_up`. Empty when not set.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"inactive_topic_max_duration_seconds": {
+							Description: "Time in seconds after which a topic without producers and consumers is inactive, 0 when not set.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"schema_compatibility_strategy": {
+							Description: "The strategy new schemas are checked against, `UNDEFINED` when the namespace uses the strategy of the brokers.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"schema_auto_update_enabled": {
+							Description: "Whether producers can register new schemas.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"auto_topic_creation_overridden": {
+							Description: "Whether the namespace overrides the auto topic creation policy of the brokers. The other auto topic creation attributes are only set when it does.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"allow_auto_topic_creation": {
+							Description: "Whether topics are created when a producer or a consumer uses a topic which does not exist.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"auto_topic_creation_type": {
+							Description: "The type of the topics created automatically, `non-partitioned` or `partitioned`. Empty when not set.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"auto_topic_creation_default_num_partitions": {
+							Description: "The number of partitions of the topics created automatically, 0 when not set.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStreamingNamespacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	token := meta.(astraClients).token
+
+	tenant := d.Get("tenant_name").(string)
+	pulsarCluster := d.Get("cluster_name").(string)
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, fmt.Sprintf("admin/v2/namespaces/%s", tenant), pulsarCluster, pulsarToken)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if statusCode != http.StatusOK {
+		return diag.Errorf("error listing namespaces of tenant %s: %s", tenant, string(body))
+	}
+	var namespaces []string
+	if err := json.Unmarshal(body, &namespaces); err != nil {
+		return diag.Errorf("failed to decode namespaces: %s", err)
+	}
+
+	results := make([]map[string]interface{}, 0, len(namespaces))
+	for _, ns := range namespaces {
+		// namespaces are returned as tenant/namespace
+		name := ns[strings.LastIndex(ns, "/")+1:]
+		policies, err := getStreamingNamespacePolicies(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenant, name)
+		if err != nil {
+			return diag.FromErr(err)
+		} else if policies == nil {
+			return diag.Errorf("namespace %s/%s not found", tenant, name)
+		}
+		results = append(results, flattenStreamingNamespace(name, policies))
+	}
+
+	d.SetId(fmt.Sprintf("%s/namespaces", tenant))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getStreamingNamespacePolicies returns the policies of the namespace, or nil when the namespace does not exist
+func getStreamingNamespacePolicies(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, pulsarCluster, pulsarToken, tenant, namespace string) (*StreamingNamespacePolicies, error) {
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, fmt.Sprintf("admin/v2/namespaces/%s/%s", tenant, namespace), pulsarCluster, pulsarToken)
+	if err != nil {
+		return nil, err
+	} else if statusCode == http.StatusNotFound {
+		return nil, nil
+	} else if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching policies of namespace %s/%s: %s", tenant, namespace, string(body))
+	}
+	var policies StreamingNamespacePolicies
+	if err := json.Unmarshal(body, &policies); err != nil {
+		return nil, fmt.Errorf("failed to decode policies of namespace %s/%s: %w", tenant, namespace, err)
+	}
+	return &policies, nil
+}
+
+// streamingNamespaceID returns the ID of the policies of a namespace: tenant_name/cluster_name/namespace
+func streamingNamespaceID(tenantName, clusterName, namespace string) string {
+	return resourceid.StreamingNamespace.Format(tenantName, clusterName, namespace)
+}
+
+func parseStreamingNamespaceID(id string) (string, string, string, error) {
+	parts, err := resourceid.StreamingNamespace.Parse(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func flattenStreamingNamespace(name string, policies *StreamingNamespacePolicies) map[string]interface{} {
+	flatNamespace := map[string]interface{}{
+		"namespace":                                  name,
+		"message_ttl_seconds":                        0,
+		"retention_time_minutes":                     0,
+		"retention_size_mb":                          0,
+		"deduplication_enabled":                      false,
+		"schema_validation_enforced":                 policies.SchemaValidationEnforced,
+		"schema_compatibility_strategy":              policies.SchemaCompatibilityStrategy,
+		"schema_auto_update_enabled":                 schemaAutoUpdateEnabled(policies),
+		"subscription_expiration_time_minutes":       0,
+		"inactive_topic_delete_enabled":              false,
+		"inactive_topic_delete_mode":                 "",
+		"inactive_topic_max_duration_seconds":        0,
+		"auto_topic_creation_overridden":             false,
+		"allow_auto_topic_creation":                  false,
+		"auto_topic_creation_type":                   "",
+		"auto_topic_creation_default_num_partitions": 0,
+	}
+	if policies.MessageTTLInSeconds != nil {
+		flatNamespace["message_ttl_seconds"] = *policies.MessageTTLInSeconds
+	}
+	if policies.RetentionPolicies != nil {
+		flatNamespace["retention_time_minutes"] = policies.RetentionPolicies.RetentionTimeInMinutes
+		flatNamespace["retention_size_mb"] = policies.RetentionPolicies.RetentionSizeInMB
+	}
+	if policies.DeduplicationEnabled != nil {
+		flatNamespace["deduplication_enabled"] = *policies.DeduplicationEnabled
+	}
+	if policies.SubscriptionExpirationTimeMinutes != nil {
+		flatNamespace["subscription_expiration_time_minutes"] = *policies.SubscriptionExpirationTimeMinutes
+	}
+	if policies.InactiveTopicPolicies != nil {
+		flatNamespace["inactive_topic_delete_enabled"] = policies.InactiveTopicPolicies.DeleteWhileInactive
+		flatNamespace["inactive_topic_delete_mode"] = policies.InactiveTopicPolicies.InactiveTopicDeleteMode
+		flatNamespace["inactive_topic_max_duration_seconds"] = policies.InactiveTopicPolicies.MaxInactiveDurationSeconds
+	}
+	if override := policies.AutoTopicCreationOverride; override != nil {
+		flatNamespace["auto_topic_creation_overridden"] = true
+		flatNamespace["allow_auto_topic_creation"] = override.AllowAutoTopicCreation
+		flatNamespace["auto_topic_creation_type"] = override.TopicType
+		if override.DefaultNumPartitions != nil {
+			flatNamespace["auto_topic_creation_default_num_partitions"] = *override.DefaultNumPartitions
+		}
+	}
+	return flatNamespace
+}
+
+type StreamingNamespacePolicies struct {
+	MessageTTLInSeconds *int `json:"message_ttl_in_seconds,omitempty"`
+	RetentionPolicies   *struct {
+		RetentionTimeInMinutes int `json:"retentionTimeInMinutes"`
+		RetentionSizeInMB      int `json:"retentionSizeInMB"`
+	} `json:"retention_policies,omitempty"`
+	DeduplicationEnabled              *bool `json:"deduplicationEnabled,omitempty"`
+	SchemaValidationEnforced          bool  `json:"schema_validation_enforced"`
+	SubscriptionExpirationTimeMinutes *int  `json:"subscription_expiration_time_minutes,omitempty"`
+	InactiveTopicPolicies             *struct {
+		InactiveTopicDeleteMode    string `json:"inactiveTopicDeleteMode"`
+		MaxInactiveDurationSeconds int    `json:"maxInactiveDurationSeconds"`
+		DeleteWhileInactive        bool   `json:"deleteWhileInactive"`
+	} `json:"inactive_topic_policies,omitempty"`
+	AutoTopicCreationOverride   *autoTopicCreationOverride `json:"autoTopicCreationOverride,omitempty"`
+	SchemaCompatibilityStrategy string                     `json:"schema_compatibility_strategy,omitempty"`
+	IsAllowAutoUpdateSchema     *bool                      `json:"is_allow_auto_update_schema,omitempty"`
+}
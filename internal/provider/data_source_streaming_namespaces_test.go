@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingNamespacesDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TENANT", "ASTRA_TEST_STREAMING_CLUSTER")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+	cluster := os.Getenv("ASTRA_TEST_STREAMING_CLUSTER")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingNamespacesDataSource(tenant, cluster),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_streaming_namespaces.dev", "tenant_name", tenant),
+					resource.TestCheckResourceAttrSet("data.astra_streaming_namespaces.dev", "results.0.namespace"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccStreamingNamespacesDataSource(tenant, cluster string) string {
+	return fmt.Sprintf(`
+data "astra_streaming_namespaces" "dev" {
+  tenant_name  = "%s"
+  cluster_name = "%s"
+}
+`, tenant, cluster)
+}
+
+func TestFlattenStreamingNamespaceExpirationPolicies(t *testing.T) {
+	var policies StreamingNamespacePolicies
+	body := `{"subscription_expiration_time_minutes":60,"inactive_topic_policies":{"inactiveTopicDeleteMode":"delete_when_no_subscriptions","maxInactiveDurationSeconds":600,"deleteWhileInactive":true}}`
+	if err := json.Unmarshal([]byte(body), &policies); err != nil {
+		t.Fatal(err)
+	}
+	ns := flattenStreamingNamespace("default", &policies)
+	if ns["subscription_expiration_time_minutes"] != 60 ||
+		ns["inactive_topic_delete_enabled"] != true ||
+		ns["inactive_topic_delete_mode"] != "delete_when_no_subscriptions" ||
+		ns["inactive_topic_max_duration_seconds"] != 600 {
+		t.Fatalf("unexpected namespace policies: %v", ns)
+	}
+
+	ns = flattenStreamingNamespace("default", &StreamingNamespacePolicies{})
+	if ns["subscription_expiration_time_minutes"] != 0 || ns["inactive_topic_delete_enabled"] != false {
+		t.Fatalf("expected no expiration policies, got %v", ns)
+	}
+}
+
+func TestFlattenStreamingNamespaceAutoTopicCreation(t *testing.T) {
+	var policies StreamingNamespacePolicies
+	body := `{"autoTopicCreationOverride":{"allowAutoTopicCreation":true,"topicType":"partitioned","defaultNumPartitions":4}}`
+	if err := json.Unmarshal([]byte(body), &policies); err != nil {
+		t.Fatal(err)
+	}
+	ns := flattenStreamingNamespace("default", &policies)
+	if ns["auto_topic_creation_overridden"] != true ||
+		ns["allow_auto_topic_creation"] != true ||
+		ns["auto_topic_creation_type"] != "partitioned" ||
+		ns["auto_topic_creation_default_num_partitions"] != 4 {
+		t.Fatalf("unexpected auto topic creation policy: %v", ns)
+	}
+
+	ns = flattenStreamingNamespace("default", &StreamingNamespacePolicies{})
+	if ns["auto_topic_creation_overridden"] != false || ns["auto_topic_creation_type"] != "" {
+		t.Fatalf("expected no auto topic creation override, got %v", ns)
+	}
+}
+
+func TestFlattenStreamingNamespaceSchemaPolicy(t *testing.T) {
+	var policies StreamingNamespacePolicies
+	body := `{"schema_compatibility_strategy":"FULL_TRANSITIVE","is_allow_auto_update_schema":false}`
+	if err := json.Unmarshal([]byte(body), &policies); err != nil {
+		t.Fatal(err)
+	}
+	ns := flattenStreamingNamespace("default", &policies)
+	if ns["schema_compatibility_strategy"] != "FULL_TRANSITIVE" || ns["schema_auto_update_enabled"] != false {
+		t.Fatalf("unexpected schema policy: %v", ns)
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStreamingServiceURLs() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_streaming_service_urls` provides a datasource with the Pulsar service URLs of the cluster of a streaming tenant, for example to pass them to the Pulsar clients of applications deployed by other providers.",
+
+		ReadContext: dataSourceStreamingServiceURLsRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"tenant_name": {
+				Description: "Name of the streaming tenant.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Computed
+			"cluster_name": {
+				Description: "Name of the Pulsar cluster of the tenant.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"broker_service_url": {
+				Description: "The Pulsar Binary Protocol URL used for production and consumption of messages.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"web_service_url": {
+				Description: "URL used for administrative operations.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"web_socket_url": {
+				Description: "URL used for web socket operations.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"web_socket_query_param_url": {
+				Description: "URL used for web socket operations, with the token passed as a query parameter.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceStreamingServiceURLsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	astraClient := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName := d.Get("tenant_name").(string)
+
+	orgID, err := getCurrentOrgID(ctx, astraClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := streamingClient.GetStreamingTenantWithResponse(ctx, orgID, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return diag.Errorf("error fetching streaming tenant %s: %s", tenantName, string(resp.Body))
+	}
+	tenant := resp.JSON200
+
+	d.SetId(tenantName)
+	urls := map[string]string{
+		"cluster_name":               astra.StringValue(tenant.ClusterName),
+		"broker_service_url":         astra.StringValue(tenant.PulsarURL),
+		"web_service_url":            astra.StringValue(tenant.AdminURL),
+		"web_socket_url":             astra.StringValue(tenant.WebsocketURL),
+		"web_socket_query_param_url": astra.StringValue(tenant.WebsocketQueryParamURL),
+	}
+	for attribute, value := range urls {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
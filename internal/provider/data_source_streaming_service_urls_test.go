@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingServiceURLsDataSource(t *testing.T) {
+	t.Parallel()
+	tenantName := "terraform-test-" + randomString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingServiceURLsDataSource(tenantName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.astra_streaming_service_urls.urls", "broker_service_url", "astra_streaming_tenant.streaming_tenant_1", "broker_service_url"),
+					resource.TestCheckResourceAttrPair("data.astra_streaming_service_urls.urls", "web_socket_url", "astra_streaming_tenant.streaming_tenant_1", "web_socket_url"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccStreamingServiceURLsDataSource(tenantName string) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "streaming_tenant_1" {
+  tenant_name         = "%s"
+  cloud_provider      = "gcp"
+  region              = "useast-4"
+  user_email          = "terraform-test-user@datastax.com"
+  deletion_protection = false
+}
+
+data "astra_streaming_service_urls" "urls" {
+  tenant_name = astra_streaming_tenant.streaming_tenant_1.tenant_name
+}
+`, tenantName)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceStreamingTopicSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_streaming_topic_schema` provides a datasource with the schema registered on a Pulsar topic, like the schemas generated for the data topics of `astra_cdc`. The attributes are empty when no schema is registered on the topic yet.",
+
+		ReadContext: dataSourceStreamingTopicSchemaRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_name": {
+				Description: "Name of the Pulsar Cluster. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"topic": {
+				Description: "The full name of the topic, `persistent://<tenant>/<namespace>/<topic>`, for example the `data_topic` of `astra_cdc`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Optional inputs
+			"version": {
+				Description:  "The version of the schema, starting at 0. Defaults to the latest version.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Computed
+			"type": {
+				Description: "The schema type, for example `AVRO`, `JSON` or `KEY_VALUE`. Empty when no schema is registered.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"definition": {
+				Description: "The schema definition as registered. For `KEY_VALUE` schemas it holds both the key and the value schemas.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"key_schema": {
+				Description: "The schema of the message keys of `KEY_VALUE` schemas, empty for other types.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"value_schema": {
+				Description: "The schema of the message values of `KEY_VALUE` schemas, or the schema definition for other types.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"properties": {
+				Description: "The properties of the schema.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStreamingTopicSchemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	topic := d.Get("topic").(string)
+	pulsarCluster := d.Get("cluster_name").(string)
+	// Schema versions start at 0, so the version is only set when it is in the configuration
+	var version *int
+	if !d.GetRawConfig().GetAttr("version").IsNull() {
+		v := d.Get("version").(int)
+		version = &v
+	}
+
+	tenant, namespace, topicName, err := parsePulsarTopicName(topic)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	schemaInfo, found, err := getPulsarSchema(ctx, streamingClientv3, topic, pulsarCluster, pulsarToken, version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !found && version != nil {
+		return diag.Errorf("version %d of the schema of topic %s not found", *version, topic)
+	}
+	keySchema, valueSchema, err := splitPulsarSchema(schemaInfo)
+	if err != nil {
+		return diag.Errorf("failed to decode schema of topic %s: %s", topic, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s/schema", pulsarCluster, tenant, namespace, topicName))
+	values := map[string]interface{}{
+		"version":      schemaInfo.Version,
+		"type":         schemaInfo.Type,
+		"definition":   schemaInfo.Data,
+		"key_schema":   keySchema,
+		"value_schema": valueSchema,
+		"properties":   schemaInfo.Properties,
+	}
+	for attribute, value := range values {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
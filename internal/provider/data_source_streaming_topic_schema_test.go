@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingTopicSchemaDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TENANT", "ASTRA_TEST_STREAMING_CLUSTER")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+	cluster := os.Getenv("ASTRA_TEST_STREAMING_CLUSTER")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// No schema is registered on a topic without messages
+				Config: testAccStreamingTopicSchemaDataSource(tenant, cluster),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_streaming_topic_schema.dev", "type", ""),
+					resource.TestCheckResourceAttr("data.astra_streaming_topic_schema.dev", "definition", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccStreamingTopicSchemaDataSource(tenant, cluster string) string {
+	return fmt.Sprintf(`
+data "astra_streaming_topic_schema" "dev" {
+  cluster_name = "%s"
+  topic        = "persistent://%s/default/terraform-test-no-schema"
+}
+`, cluster, tenant)
+}
+
+func TestGetPulsarSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/admin/v2/schemas/tenant/ns/topic/schema":
+			w.Write([]byte(`{"version":2,"type":"AVRO","data":"{\"type\":\"string\"}","properties":{"owner":"team"}}`))
+		case "/admin/v2/schemas/tenant/ns/topic/schema/0":
+			w.Write([]byte(`{"version":0,"type":"STRING","data":""}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := astrastreaming.NewClientWithResponses(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	schemaInfo, found, err := getPulsarSchema(ctx, client, "persistent://tenant/ns/topic", "pulsar-gcp-useast4", "token", nil)
+	if err != nil || !found {
+		t.Fatalf("expected the latest schema, got %v, %v", found, err)
+	}
+	if schemaInfo.Version != 2 || schemaInfo.Type != "AVRO" || schemaInfo.Properties["owner"] != "team" {
+		t.Fatalf("unexpected latest schema: %+v", schemaInfo)
+	}
+
+	version := 0
+	schemaInfo, found, err = getPulsarSchema(ctx, client, "tenant/ns/topic", "pulsar-gcp-useast4", "token", &version)
+	if err != nil || !found || schemaInfo.Type != "STRING" {
+		t.Fatalf("expected version 0 of the schema, got %+v, %v, %v", schemaInfo, found, err)
+	}
+
+	if _, found, err := getPulsarSchema(ctx, client, "persistent://tenant/ns/other", "pulsar-gcp-useast4", "token", nil); err != nil || found {
+		t.Fatalf("expected no schema, got %v, %v", found, err)
+	}
+}
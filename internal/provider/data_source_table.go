@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// cqlUnquotedIdentifierRegex matches the identifiers which do not need to be quoted
+var cqlUnquotedIdentifierRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func dataSourceTable() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_table` provides a datasource that reads the schema of an existing table.",
+
+		ReadContext: dataSourceTableRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description: "The region of the database used to reach the Stargate REST API.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"keyspace": {
+				Description:      "The keyspace of the table.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			"table": {
+				Description:      "The name of the table.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			// Computed
+			"partition_keys": {
+				Description: "The partition key column(s) of the table.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clustering_columns": {
+				Description: "The clustering column(s) of the table.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"column_definitions": tableColumnDefinitionsSchema(),
+			"clustering_order":   tableClusteringOrderSchema(),
+			"default_time_to_live": {
+				Description: "The default time to live of the table in seconds, 0 when disabled.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"create_table_cql": tableCreateCQLSchema(),
+		},
+	}
+}
+
+func tableColumnDefinitionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The columns of the table.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The column name.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"type_definition": {
+					Description: "The CQL type of the column.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"static": {
+					Description: "Whether the column is shared by all rows of a partition.",
+					Type:        schema.TypeBool,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func tableClusteringOrderSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The clustering order of the table.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"column": {
+					Description: "The clustering column.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"order": {
+					Description: "The clustering order, `ASC` or `DESC`.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func tableCreateCQLSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The CQL statement creating the table, with the columns, the primary key, the clustering order and the default time to live. The columns are in the order of the primary key, then sorted by name, so the statement can be compared with schema files.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+func dataSourceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	token := meta.(astraClients).token
+
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	tableName := d.Get("table").(string)
+
+	restClient, err := getRestClient(meta, databaseID, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	raw := true
+	params := astrarestapi.GetTableParams{
+		Raw:             &raw,
+		XCassandraToken: token,
+	}
+	resp, err := restClient.GetTableWithResponse(ctx, keyspaceName, tableName, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return diag.Errorf("error fetching table %s.%s: %s", keyspaceName, tableName, string(resp.Body))
+	}
+
+	d.SetId(resourceid.Table.Format(databaseID, keyspaceName, tableName))
+	for k, v := range flattenTable(keyspaceName, resp.JSON200) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// getRestClient returns a Stargate REST API client for the given database, using the provider cache if possible
+func getRestClient(meta interface{}, databaseID, region string) (*astrarestapi.ClientWithResponses, error) {
+	stargateCache := meta.(astraClients).stargateClientCache
+	if val, ok := stargateCache[databaseID]; ok {
+		return &astrarestapi.ClientWithResponses{ClientInterface: &val}, nil
+	}
+	restClient, err := newRestClient(meta, databaseID, region)
+	if err != nil {
+		return nil, err
+	}
+	return &astrarestapi.ClientWithResponses{ClientInterface: &restClient}, nil
+}
+
+func flattenTable(keyspaceName string, table *astrarestapi.Table) map[string]interface{} {
+	clusteringColumns := make([]string, 0)
+	if table.PrimaryKey.ClusteringKey != nil {
+		clusteringColumns = *table.PrimaryKey.ClusteringKey
+	}
+
+	columns := make([]map[string]interface{}, 0, len(table.ColumnDefinitions))
+	for _, column := range table.ColumnDefinitions {
+		static := false
+		if column.Static != nil {
+			static = *column.Static
+		}
+		columns = append(columns, map[string]interface{}{
+			"name":            column.Name,
+			"type_definition": string(column.TypeDefinition),
+			"static":          static,
+		})
+	}
+
+	clusteringOrder := make([]map[string]interface{}, 0)
+	defaultTTL := 0
+	if table.TableOptions != nil {
+		if table.TableOptions.ClusteringExpression != nil {
+			for _, expr := range *table.TableOptions.ClusteringExpression {
+				clusteringOrder = append(clusteringOrder, map[string]interface{}{
+					"column": expr.Column,
+					"order":  string(expr.Order),
+				})
+			}
+		}
+		if table.TableOptions.DefaultTimeToLive != nil {
+			defaultTTL = *table.TableOptions.DefaultTimeToLive
+		}
+	}
+
+	return map[string]interface{}{
+		"table":                table.Name,
+		"partition_keys":       table.PrimaryKey.PartitionKey,
+		"clustering_columns":   clusteringColumns,
+		"column_definitions":   columns,
+		"clustering_order":     clusteringOrder,
+		"default_time_to_live": defaultTTL,
+		"create_table_cql":     tableCreateCQL(keyspaceName, table),
+	}
+}
+
+// tableCreateCQL returns the CREATE TABLE statement of the table. The REST API only returns the columns, the primary
+// key, the clustering order and the default time to live, so the other table options are not included.
+func tableCreateCQL(keyspaceName string, table *astrarestapi.Table) string {
+	clusteringColumns := []string{}
+	if table.PrimaryKey.ClusteringKey != nil {
+		clusteringColumns = *table.PrimaryKey.ClusteringKey
+	}
+	clusteringOrders := map[string]string{}
+	defaultTTL := 0
+	if table.TableOptions != nil {
+		if table.TableOptions.ClusteringExpression != nil {
+			for _, expr := range *table.TableOptions.ClusteringExpression {
+				clusteringOrders[expr.Column] = strings.ToUpper(string(expr.Order))
+			}
+		}
+		if table.TableOptions.DefaultTimeToLive != nil {
+			defaultTTL = *table.TableOptions.DefaultTimeToLive
+		}
+	}
+
+	// Primary key columns first, in the order of the key, then the other columns by name
+	keyPositions := map[string]int{}
+	for i, column := range append(append([]string{}, table.PrimaryKey.PartitionKey...), clusteringColumns...) {
+		keyPositions[column] = i
+	}
+	columns := append([]astrarestapi.ColumnDefinition{}, table.ColumnDefinitions...)
+	sort.SliceStable(columns, func(i, j int) bool {
+		pi, iKey := keyPositions[columns[i].Name]
+		pj, jKey := keyPositions[columns[j].Name]
+		switch {
+		case iKey && jKey:
+			return pi < pj
+		case iKey != jKey:
+			return iKey
+		}
+		return columns[i].Name < columns[j].Name
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s.%s (\n", cqlQuoteIdentifier(keyspaceName), cqlQuoteIdentifier(table.Name))
+	for _, column := range columns {
+		fmt.Fprintf(&b, "    %s %s", cqlQuoteIdentifier(column.Name), column.TypeDefinition)
+		if column.Static != nil && *column.Static {
+			b.WriteString(" static")
+		}
+		b.WriteString(",\n")
+	}
+
+	partitionKey := cqlQuoteIdentifiers(table.PrimaryKey.PartitionKey)
+	if len(table.PrimaryKey.PartitionKey) > 1 {
+		partitionKey = "(" + partitionKey + ")"
+	}
+	primaryKey := append([]string{partitionKey}, cqlQuoteIdentifiers(clusteringColumns))
+	if len(clusteringColumns) == 0 {
+		primaryKey = primaryKey[:1]
+	}
+	fmt.Fprintf(&b, "    PRIMARY KEY (%s)\n)", strings.Join(primaryKey, ", "))
+
+	var options []string
+	if len(clusteringColumns) > 0 {
+		orders := make([]string, 0, len(clusteringColumns))
+		for _, column := range clusteringColumns {
+			order := clusteringOrders[column]
+			if order == "" {
+				order = "ASC"
+			}
+			orders = append(orders, fmt.Sprintf("%s %s", cqlQuoteIdentifier(column), order))
+		}
+		options = append(options, fmt.Sprintf("CLUSTERING ORDER BY (%s)", strings.Join(orders, ", ")))
+	}
+	if defaultTTL > 0 {
+		options = append(options, fmt.Sprintf("default_time_to_live = %d", defaultTTL))
+	}
+	if len(options) > 0 {
+		b.WriteString(" WITH " + strings.Join(options, "\n    AND "))
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+// cqlQuoteIdentifier quotes the identifier when it is case sensitive or a reserved keyword, like cqlsh does
+func cqlQuoteIdentifier(identifier string) string {
+	if cqlUnquotedIdentifierRegex.MatchString(identifier) && !cqlReservedWords[identifier] {
+		return identifier
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func cqlQuoteIdentifiers(identifiers []string) string {
+	quoted := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		quoted = append(quoted, cqlQuoteIdentifier(identifier))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestTableDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTableDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_table.dev", "partition_keys.#", "2"),
+					resource.TestCheckResourceAttr("data.astra_table.dev", "clustering_columns.#", "2"),
+					resource.TestCheckResourceAttr("data.astra_table.dev", "column_definitions.#", "6"),
+					resource.TestCheckResourceAttrPair("data.astra_table.dev", "create_table_cql", "astra_table.table-1", "create_table_cql"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccTableDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+%s
+
+data "astra_table" "dev" {
+  database_id = astra_table.table-1.database_id
+  region      = astra_table.table-1.region
+  keyspace    = astra_table.table-1.keyspace
+  table       = astra_table.table-1.table
+}
+`, testAccTableConfiguration(databaseID))
+}
+
+func TestTableCreateCQL(t *testing.T) {
+	static := true
+	ttl := 3600
+	table := &astrarestapi.Table{
+		Name: "Events",
+		ColumnDefinitions: []astrarestapi.ColumnDefinition{
+			{Name: "payload", TypeDefinition: "map<text, text>"},
+			{Name: "ts", TypeDefinition: "timestamp"},
+			{Name: "bucket", TypeDefinition: "int"},
+			{Name: "owner", TypeDefinition: "text", Static: &static},
+			{Name: "device_id", TypeDefinition: "uuid"},
+			{Name: "select", TypeDefinition: "text"},
+		},
+		PrimaryKey: astrarestapi.PrimaryKey{
+			PartitionKey:  []string{"device_id", "bucket"},
+			ClusteringKey: &[]string{"ts"},
+		},
+		TableOptions: &astrarestapi.TableOptions{
+			ClusteringExpression: &[]astrarestapi.ClusteringExpression{{Column: "ts", Order: "DESC"}},
+			DefaultTimeToLive:    &ttl,
+		},
+	}
+	expected := `CREATE TABLE ks."Events" (
+    device_id uuid,
+    bucket int,
+    ts timestamp,
+    owner text static,
+    payload map<text, text>,
+    "select" text,
+    PRIMARY KEY ((device_id, bucket), ts)
+) WITH CLUSTERING ORDER BY (ts DESC)
+    AND default_time_to_live = 3600;`
+	if cql := tableCreateCQL("ks", table); cql != expected {
+		t.Errorf("unexpected CREATE TABLE statement:\n%s\nexpected:\n%s", cql, expected)
+	}
+
+	table = &astrarestapi.Table{
+		Name:              "kv",
+		ColumnDefinitions: []astrarestapi.ColumnDefinition{{Name: "value", TypeDefinition: "blob"}, {Name: "key", TypeDefinition: "text"}},
+		PrimaryKey:        astrarestapi.PrimaryKey{PartitionKey: []string{"key"}},
+	}
+	expected = `CREATE TABLE ks.kv (
+    key text,
+    value blob,
+    PRIMARY KEY (key)
+);`
+	if cql := tableCreateCQL("ks", table); cql != expected {
+		t.Errorf("unexpected CREATE TABLE statement:\n%s\nexpected:\n%s", cql, expected)
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTables() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_tables` provides a datasource that lists the tables in a keyspace.",
+
+		ReadContext: dataSourceTablesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description: "The region of the database used to reach the Stargate REST API.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"keyspace": {
+				Description:      "The keyspace to list the tables of.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			// Computed
+			"results": {
+				Description: "The list of tables in the keyspace.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"table": {
+							Description: "The name of the table.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"partition_keys": {
+							Description: "The partition key column(s) of the table.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"clustering_columns": {
+							Description: "The clustering column(s) of the table.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"column_definitions": tableColumnDefinitionsSchema(),
+						"clustering_order":   tableClusteringOrderSchema(),
+						"default_time_to_live": {
+							Description: "The default time to live of the table in seconds, 0 when disabled.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"create_table_cql": tableCreateCQLSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	token := meta.(astraClients).token
+
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	keyspaceName := d.Get("keyspace").(string)
+
+	restClient, err := getRestClient(meta, databaseID, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := astrarestapi.GetTablesParams{
+		XCassandraToken: token,
+	}
+	resp, err := restClient.GetTablesWithResponse(ctx, keyspaceName, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return diag.Errorf("error listing tables in keyspace %s: %s", keyspaceName, string(resp.Body))
+	}
+
+	results := make([]map[string]interface{}, 0)
+	if resp.JSON200.Data != nil {
+		for i := range *resp.JSON200.Data {
+			results = append(results, flattenTable(keyspaceName, &(*resp.JSON200.Data)[i]))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", databaseID, keyspaceName))
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestTablesDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTablesDataSource(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_tables.dev", "results.0.table"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccTablesDataSource(databaseID string) string {
+	return fmt.Sprintf(`
+%s
+
+data "astra_tables" "dev" {
+  database_id = astra_table.table-1.database_id
+  region      = astra_table.table-1.region
+  keyspace    = astra_table.table-1.keyspace
+}
+`, testAccTableConfiguration(databaseID))
+}
@@ -78,8 +78,8 @@ func listToken(ctx context.Context, client *astra.ClientWithResponses, clientID
 	resp, err := client.GetClientsForOrgWithResponse(ctx)
 	if err != nil {
 		return nil, err
-	} else if resp.StatusCode() >= 400 {
-		return nil, fmt.Errorf("error fetching client tokens, Status code: %d, msg: %s", resp.StatusCode(), string(resp.Body))
+	} else if err := checkReadStatus(resp.StatusCode(), resp.Body, "client tokens"); err != nil {
+		return nil, err
 	}
 
 	tokens := (*resp.JSON200).(map[string]interface{})["clients"].([]interface{})
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// uuidPattern matches the IDs in API paths, so notices for the same endpoint of different databases are only reported once
+var uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// deprecationNotices collects the deprecation notices returned by the Astra APIs, so they can be reported as warnings.
+// Each notice is only reported once per provider instance.
+type deprecationNotices struct {
+	lock    sync.Mutex
+	seen    map[string]bool
+	pending []string
+}
+
+func (n *deprecationNotices) record(resp *http.Response) {
+	notice, ok := deprecationNotice(resp)
+	if !ok {
+		return
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.seen[notice] {
+		return
+	}
+	if n.seen == nil {
+		n.seen = make(map[string]bool)
+	}
+	n.seen[notice] = true
+	n.pending = append(n.pending, notice)
+}
+
+// drain returns the notices which have not been reported yet
+func (n *deprecationNotices) drain() []string {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	pending := n.pending
+	n.pending = nil
+	return pending
+}
+
+// deprecationNotice returns a notice for responses with a Deprecation (RFC 9745) or Sunset (RFC 8594) header, or with a
+// miscellaneous persistent warning (code 299)
+func deprecationNotice(resp *http.Response) (string, bool) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	var warnings []string
+	for _, w := range resp.Header.Values("Warning") {
+		if strings.HasPrefix(w, "299 ") {
+			warnings = append(warnings, w)
+		}
+	}
+	if deprecation == "" && sunset == "" && len(warnings) == 0 {
+		return "", false
+	}
+
+	endpoint := resp.Request.Method + " " + uuidPattern.ReplaceAllString(resp.Request.URL.Path, "{id}")
+	var notice strings.Builder
+	if deprecation != "" || sunset != "" {
+		fmt.Fprintf(&notice, "The Astra API endpoint %s is deprecated", endpoint)
+		if sunset != "" {
+			fmt.Fprintf(&notice, " and will be removed after %s", sunset)
+		}
+		notice.WriteString(".")
+	} else {
+		fmt.Fprintf(&notice, "The Astra API endpoint %s returned a warning.", endpoint)
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(&notice, " %s", warningText(w))
+	}
+	if link := deprecationLink(resp.Header.Values("Link")); link != "" {
+		fmt.Fprintf(&notice, " See %s", link)
+	}
+	return notice.String(), true
+}
+
+// warningText returns the quoted text of a Warning header, like 299 - "this endpoint is deprecated"
+func warningText(warning string) string {
+	start := strings.Index(warning, `"`)
+	end := strings.LastIndex(warning, `"`)
+	if start < 0 || end <= start {
+		return warning
+	}
+	return warning[start+1 : end]
+}
+
+// deprecationLink returns the target of the deprecation or sunset link, if any
+func deprecationLink(links []string) string {
+	for _, header := range links {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			for _, param := range parts[1:] {
+				param = strings.ReplaceAll(strings.TrimSpace(param), `"`, "")
+				if param == "rel=deprecation" || param == "rel=sunset" {
+					return target
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// deprecationTransport records the deprecation notices of the responses
+type deprecationTransport struct {
+	base    http.RoundTripper
+	notices *deprecationNotices
+}
+
+// newDeprecationClient wraps the transport of the client to record deprecation notices
+func newDeprecationClient(client *http.Client, notices *deprecationNotices) *http.Client {
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &deprecationTransport{
+			base:    transport,
+			notices: notices,
+		},
+		CheckRedirect: client.CheckRedirect,
+		Jar:           client.Jar,
+		Timeout:       client.Timeout,
+	}
+}
+
+func (t *deprecationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.notices.record(resp)
+	}
+	return resp, err
+}
+
+// withDeprecationWarnings appends the pending deprecation notices to the diagnostics of every operation of the
+// resources and data sources, so they are shown during refresh and plan
+func withDeprecationWarnings(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		r.CreateContext = appendDeprecationWarnings(r.CreateContext)
+		r.ReadContext = appendDeprecationWarnings(r.ReadContext)
+		r.UpdateContext = appendDeprecationWarnings(r.UpdateContext)
+		r.DeleteContext = appendDeprecationWarnings(r.DeleteContext)
+	}
+}
+
+func appendDeprecationWarnings(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		clients, ok := meta.(astraClients)
+		if !ok || clients.deprecationNotices == nil {
+			return diags
+		}
+		for _, notice := range clients.deprecationNotices.drain() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Deprecated Astra API",
+				Detail:   notice,
+			})
+		}
+		return diags
+	}
+}
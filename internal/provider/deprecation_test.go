@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDeprecationNotices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/databases/"):
+			w.Header().Set("Deprecation", "@1735689600")
+			w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+			w.Header().Set("Link", `<https://docs.datastax.com/deprecations>; rel="deprecation"`)
+		case r.URL.Path == "/v2/warning":
+			w.Header().Add("Warning", `299 - "use v3 instead"`)
+		}
+	}))
+	defer server.Close()
+
+	notices := &deprecationNotices{}
+	client := newDeprecationClient(server.Client(), notices)
+	for _, path := range []string{
+		"/v2/databases/8d8b3c2e-5c5b-4c36-9d9e-6d1b9d2c1a11",
+		"/v2/databases/a6b2b0cf-9f32-4e6d-8a57-52b4d9f1b0e2",
+		"/v2/warning",
+		"/v2/current",
+	} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	pending := notices.drain()
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 notices, got %v", pending)
+	}
+	expected := "The Astra API endpoint GET /v2/databases/{id} is deprecated and will be removed after Wed, 01 Jul 2026 00:00:00 GMT. See https://docs.datastax.com/deprecations"
+	if pending[0] != expected {
+		t.Fatalf("unexpected notice: %s", pending[0])
+	}
+	if pending[1] != "The Astra API endpoint GET /v2/warning returned a warning. use v3 instead" {
+		t.Fatalf("unexpected notice: %s", pending[1])
+	}
+	if pending := notices.drain(); len(pending) != 0 {
+		t.Fatalf("expected the notices to be reported once, got %v", pending)
+	}
+}
+
+func TestAppendDeprecationWarnings(t *testing.T) {
+	notices := &deprecationNotices{pending: []string{"deprecated"}}
+	read := appendDeprecationWarnings(func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return nil
+	})
+	diags := read(context.Background(), nil, astraClients{deprecationNotices: notices})
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || diags[0].Detail != "deprecated" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if appendDeprecationWarnings(nil) != nil {
+		t.Fatal("expected missing operations to stay missing")
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var _ fwprovider.ProviderWithFunctions = &frameworkProvider{}
+
+// frameworkProvider is served next to the SDK provider and only implements the provider functions, which are not
+// supported by the SDK. Its schema must match the schema of the SDK provider.
+type frameworkProvider struct {
+	version string
+}
+
+func NewFrameworkProvider(version string) func() fwprovider.Provider {
+	return func() fwprovider.Provider {
+		return &frameworkProvider{
+			version: version,
+		}
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "astra"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"token": fwschema.StringAttribute{
+				MarkdownDescription: "Authentication token for Astra API.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"token_command": fwschema.StringAttribute{
+				MarkdownDescription: "Shell command printing the authentication token for Astra API, for example to read it from Vault or a cloud secret store. The command runs when the provider is configured, and its output takes precedence over `token`.",
+				Optional:            true,
+			},
+			"astra_api_url": fwschema.StringAttribute{
+				MarkdownDescription: "URL for Astra API.",
+				Optional:            true,
+			},
+			"streaming_api_url": fwschema.StringAttribute{
+				MarkdownDescription: "URL for Astra Streaming API.",
+				Optional:            true,
+			},
+			"otel_exporter_endpoint": fwschema.StringAttribute{
+				MarkdownDescription: "OTLP/HTTP endpoint receiving OpenTelemetry traces of the Astra DevOps and Streaming API calls, for example `http://localhost:4318`. Every API request is recorded as a span. Tracing is disabled unless this is set.",
+				Optional:            true,
+			},
+			"response_cache_ttl": fwschema.StringAttribute{
+				MarkdownDescription: "Time to live of the cached responses of the Astra API GET requests, like `30s` or `2m`, to speed up the refresh of large states which read the same databases and tenants many times. Requests which change an object invalidate its cached responses, but changes made outside of Terraform, and the progress of long operations like database creation, are only seen once the cached responses expire. Caching is disabled unless this is set.",
+				Optional:            true,
+			},
+			"http_keep_alive": fwschema.StringAttribute{
+				MarkdownDescription: "How long idle connections to the Astra APIs are kept open to be reused by the next requests, like `90s`, which is the default. Set `0s` to disable keep-alive, so every request opens a new connection.",
+				Optional:            true,
+			},
+			"http_max_idle_connections": fwschema.Int64Attribute{
+				MarkdownDescription: "Number of idle connections kept open per host. Raise it when many resources of the same database or tenant are applied in parallel, so they reuse connections instead of opening new ones. Defaults to the number of CPUs plus one.",
+				Optional:            true,
+			},
+			"http_request_timeout": fwschema.StringAttribute{
+				MarkdownDescription: "Timeout of each attempt of an Astra API request, including reading the response, like `30s`. Reads which time out are retried, other requests which time out fail without being retried. Requests have no timeout unless this is set.",
+				Optional:            true,
+			},
+			"mock": fwschema.BoolAttribute{
+				MarkdownDescription: "Serve the Astra DevOps API from an in-process fake instead of `astra_api_url`, so configurations can be planned and applied without credentials or costs. Only databases, keyspaces and access lists are supported, and the fake state is lost when the provider process exits.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Configure does nothing, the provider functions do not call any API
+func (p *frameworkProvider) Configure(_ context.Context, _ fwprovider.ConfigureRequest, _ *fwprovider.ConfigureResponse) {
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newPulsarClusterNameFunction,
+		newBuildTopicFQNFunction,
+	}
+}
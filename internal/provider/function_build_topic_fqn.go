@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var _ function.Function = &buildTopicFQNFunction{}
+
+type buildTopicFQNFunction struct{}
+
+func newBuildTopicFQNFunction() function.Function {
+	return &buildTopicFQNFunction{}
+}
+
+func (f *buildTopicFQNFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_topic_fqn"
+}
+
+func (f *buildTopicFQNFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the fully qualified name of a Pulsar topic",
+		MarkdownDescription: "Returns the fully qualified name of a persistent Pulsar topic, `persistent://<tenant>/<namespace>/<topic>`. The tenant, namespace and topic names are validated with the same rules as the `astra_streaming_topic` resource.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "tenant",
+				MarkdownDescription: "The streaming tenant name.",
+			},
+			function.StringParameter{
+				Name:                "namespace",
+				MarkdownDescription: "The Pulsar namespace name.",
+			},
+			function.StringParameter{
+				Name:                "topic",
+				MarkdownDescription: "The topic name, without the `persistent://tenant/namespace/` prefix.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildTopicFQNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tenant, namespace, topic string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &tenant, &namespace, &topic))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(
+		argumentFuncError(0, validateStreamingTenantName(tenant, cty.Path{})),
+		argumentFuncError(1, validateStreamingNamespaceName(namespace, cty.Path{})),
+		argumentFuncError(2, validateStreamingTopicName(topic, cty.Path{})),
+	)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fmt.Sprintf("persistent://%s/%s/%s", tenant, namespace, topic)))
+}
+
+// argumentFuncError converts the diagnostics of a schema validator into a function argument error
+func argumentFuncError(argument int64, diags diag.Diagnostics) *function.FuncError {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return function.NewArgumentFuncError(argument, d.Detail)
+		}
+	}
+	return nil
+}
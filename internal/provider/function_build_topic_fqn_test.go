@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Provider functions require Terraform 1.8 or later
+func TestBuildTopicFQNFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "topic" {
+  value = provider::astra::build_topic_fqn("mytenant", "default", "orders")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("topic", "persistent://mytenant/default/orders"),
+				),
+			},
+			{
+				Config: `
+output "topic" {
+  value = provider::astra::build_topic_fqn("mytenant", "default", "persistent://mytenant/default/orders")
+}
+`,
+				ExpectError: regexp.MustCompile("invalid topic name"),
+			},
+		},
+	})
+}
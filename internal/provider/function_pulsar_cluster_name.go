@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &pulsarClusterNameFunction{}
+
+type pulsarClusterNameFunction struct{}
+
+func newPulsarClusterNameFunction() function.Function {
+	return &pulsarClusterNameFunction{}
+}
+
+func (f *pulsarClusterNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pulsar_cluster_name"
+}
+
+func (f *pulsarClusterNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Return the name of the Pulsar cluster of a cloud provider region",
+		MarkdownDescription: "Returns the name of the Astra Streaming Pulsar cluster for a cloud provider and region, in the format `pulsar-<cloud provider>-<cloud region>`. Dashes are removed from the region, so `us-east1` on `gcp` becomes `pulsar-gcp-useast1`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cloud_provider",
+				MarkdownDescription: "The cloud provider, one of `aws`, `gcp` or `azure`.",
+			},
+			function.StringParameter{
+				Name:                "region",
+				MarkdownDescription: "The cloud provider region.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *pulsarClusterNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cloudProvider, region string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cloudProvider, &region))
+	if resp.Error != nil {
+		return
+	}
+
+	if !stringInSliceFold(availableCloudProviders, cloudProvider) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("cloud_provider must be one of %s, got %q", strings.Join(availableCloudProviders, ", "), cloudProvider))
+		return
+	}
+	if region == "" {
+		resp.Error = function.NewArgumentFuncError(1, "region must not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, GetPulsarCluster(cloudProvider, region)))
+}
+
+func stringInSliceFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
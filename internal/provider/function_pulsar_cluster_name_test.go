@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Provider functions require Terraform 1.8 or later
+func TestPulsarClusterNameFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "cluster" {
+  value = provider::astra::pulsar_cluster_name("gcp", "us-east1")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("cluster", "pulsar-gcp-useast1"),
+				),
+			},
+		},
+	})
+}
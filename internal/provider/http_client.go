@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// httpClientOptions tune the connections of the HTTP clients of the Astra APIs. All the clients share one transport,
+// so the connections to the same hosts are reused by all the resources.
+type httpClientOptions struct {
+	// keepAlive is how long idle connections are kept open, keep-alive is disabled when it is 0 and the default of
+	// the transport is used when it is negative
+	keepAlive time.Duration
+	// maxIdleConnections is the number of idle connections kept open per host, the default of the transport is used
+	// when it is 0
+	maxIdleConnections int
+	// requestTimeout is the timeout of each attempt of a request, including reading the response body. Requests
+	// have no timeout when it is 0.
+	requestTimeout time.Duration
+}
+
+// defaultHTTPClientOptions keep the defaults of the transport
+var defaultHTTPClientOptions = httpClientOptions{keepAlive: -1}
+
+// parseHTTPClientOptions reads the HTTP client options of the provider configuration
+func parseHTTPClientOptions(keepAlive string, maxIdleConnections int, requestTimeout string) (httpClientOptions, error) {
+	options := defaultHTTPClientOptions
+	if keepAlive != "" {
+		duration, err := time.ParseDuration(keepAlive)
+		if err != nil || duration < 0 {
+			return options, fmt.Errorf("invalid http_keep_alive %q: expected a duration like 90s, or 0s to disable keep-alive", keepAlive)
+		}
+		options.keepAlive = duration
+	}
+	if maxIdleConnections < 0 {
+		return options, fmt.Errorf("invalid http_max_idle_connections %d: expected a non-negative number, or 0 for the default", maxIdleConnections)
+	}
+	options.maxIdleConnections = maxIdleConnections
+	if requestTimeout != "" {
+		duration, err := time.ParseDuration(requestTimeout)
+		if err != nil || duration < 0 {
+			return options, fmt.Errorf("invalid http_request_timeout %q: expected a duration like 30s, or 0s for no timeout", requestTimeout)
+		}
+		options.requestTimeout = duration
+	}
+	return options, nil
+}
+
+// newHTTPTransport returns a pooled transport with the connection options
+func newHTTPTransport(options httpClientOptions) *http.Transport {
+	transport := cleanhttp.DefaultPooledTransport()
+	switch {
+	case options.keepAlive == 0:
+		transport.DisableKeepAlives = true
+	case options.keepAlive > 0:
+		transport.IdleConnTimeout = options.keepAlive
+	}
+	if options.maxIdleConnections > 0 {
+		transport.MaxIdleConnsPerHost = options.maxIdleConnections
+		if transport.MaxIdleConns < options.maxIdleConnections {
+			transport.MaxIdleConns = options.maxIdleConnections
+		}
+	}
+	return transport
+}
+
+// newHTTPClient returns a client sending the requests on the transport with the request timeout
+func newHTTPClient(transport http.RoundTripper, options httpClientOptions) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   options.requestTimeout,
+	}
+}
+
+// newRetryClient builds a retryable http client on the transport to automatically handle intermittent api errors
+func newRetryClient(transport http.RoundTripper, options httpClientOptions) *retryablehttp.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = newHTTPClient(transport, options)
+	retryClient.RetryMax = 10
+	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		// Never retry POST requests because of side effects
+		if resp != nil && resp.Request.Method == http.MethodPost {
+			return false, err
+		}
+		// Without a response, like when the connection was reset or the request timed out, only the idempotent
+		// reads are retried
+		if resp == nil && !idempotentRequestError(err) {
+			return false, err
+		}
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+	return retryClient
+}
+
+// idempotentRequestError returns whether the error is the transport error of a GET or HEAD request. The http client
+// reports the method of the failed request in the Op of the url.Error, like "Get".
+func idempotentRequestError(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	return strings.EqualFold(urlErr.Op, http.MethodGet) || strings.EqualFold(urlErr.Op, http.MethodHead)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseHTTPClientOptions(t *testing.T) {
+	options, err := parseHTTPClientOptions("", 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options != defaultHTTPClientOptions {
+		t.Errorf("expected the default options, got %+v", options)
+	}
+	transport := newHTTPTransport(options)
+	if transport.DisableKeepAlives || transport.IdleConnTimeout != 90*time.Second {
+		t.Errorf("expected the default keep-alive, got %v, %v", transport.DisableKeepAlives, transport.IdleConnTimeout)
+	}
+
+	options, err = parseHTTPClientOptions("2m", 200, "30s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport = newHTTPTransport(options)
+	if transport.IdleConnTimeout != 2*time.Minute || transport.MaxIdleConnsPerHost != 200 || transport.MaxIdleConns != 200 {
+		t.Errorf("unexpected transport: idle timeout %v, max idle connections %d per host, %d total", transport.IdleConnTimeout, transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+	if client := newHTTPClient(transport, options); client.Timeout != 30*time.Second {
+		t.Errorf("expected a 30s request timeout, got %v", client.Timeout)
+	}
+
+	options, err = parseHTTPClientOptions("0s", 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !newHTTPTransport(options).DisableKeepAlives {
+		t.Error("expected keep-alive to be disabled")
+	}
+
+	for _, c := range []struct {
+		keepAlive, requestTimeout string
+		maxIdleConnections        int
+	}{
+		{keepAlive: "forever"},
+		{keepAlive: "-1s"},
+		{requestTimeout: "30"},
+		{maxIdleConnections: -1},
+	} {
+		if _, err := parseHTTPClientOptions(c.keepAlive, c.maxIdleConnections, c.requestTimeout); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+}
+
+func TestRetryClientTimeout(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	options := httpClientOptions{keepAlive: -1, requestTimeout: 50 * time.Millisecond}
+	retryClient := newRetryClient(newHTTPTransport(options), options)
+	retryClient.RetryMax = 2
+	retryClient.RetryWaitMin = time.Millisecond
+	retryClient.RetryWaitMax = time.Millisecond
+	client := retryClient.StandardClient()
+
+	// Reads are retried after transport errors
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected the GET request to be sent 3 times, got %d", n)
+	}
+
+	atomic.StoreInt32(&requests, 0)
+	if _, err := client.Post(server.URL, "application/json", strings.NewReader("{}")); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected the POST request to be sent once, got %d", n)
+	}
+}
@@ -0,0 +1,410 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+)
+
+// mockOrgID is the organization of the mock Astra API
+const mockOrgID = "00000000-0000-0000-0000-000000000000"
+
+// mockServerlessRegions are the serverless regions of the mock Astra API
+var mockServerlessRegions = []astra.ServerlessRegion{
+	{CloudProvider: "AWS", Name: "us-east-1", DisplayName: "US East (N. Virginia)", Zone: "na", Classification: "standard"},
+	{CloudProvider: "AWS", Name: "us-west-2", DisplayName: "US West (Oregon)", Zone: "na", Classification: "standard"},
+	{CloudProvider: "AWS", Name: "eu-west-1", DisplayName: "Europe (Ireland)", Zone: "emea", Classification: "standard"},
+	{CloudProvider: "GCP", Name: "us-east1", DisplayName: "Moncks Corner, South Carolina", Zone: "na", Classification: "standard"},
+	{CloudProvider: "GCP", Name: "us-central1", DisplayName: "Council Bluffs, Iowa", Zone: "na", Classification: "standard"},
+	{CloudProvider: "GCP", Name: "europe-west1", DisplayName: "St. Ghislain, Belgium", Zone: "emea", Classification: "standard"},
+	{CloudProvider: "AZURE", Name: "eastus", DisplayName: "Washington, Virginia", Zone: "na", Classification: "standard"},
+	{CloudProvider: "AZURE", Name: "westus2", DisplayName: "Washington (West Central)", Zone: "na", Classification: "standard"},
+}
+
+// mockDatabase is a database of the mock Astra API
+type mockDatabase struct {
+	db         astra.Database
+	dbType     string
+	accessList astra.AccessListResponse
+}
+
+// mockAstraAPI is an in-memory fake of the Astra DevOps API, used when the provider runs in mock mode. It supports the
+// database lifecycle, regions, keyspaces, secure connect bundles and access lists. Databases are active as soon as they
+// are created. Other requests, including all Astra Streaming requests, fail with 400 Bad Request rather than 501 Not
+// Implemented, because the resources retry 5XX errors until they time out.
+type mockAstraAPI struct {
+	lock      sync.Mutex
+	databases map[string]*mockDatabase
+}
+
+var (
+	mockAPIOnce sync.Once
+	mockAPIURL  string
+	mockAPIErr  error
+)
+
+// startMockAstraAPI serves the mock Astra API on a local port and returns its URL. The server is shared by all the
+// provider instances of the process, so its state outlives a single configuration, like the steps of an acceptance test.
+func startMockAstraAPI() (string, error) {
+	mockAPIOnce.Do(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			mockAPIErr = fmt.Errorf("failed to start mock Astra API: %w", err)
+			return
+		}
+		server := &http.Server{Handler: newMockAstraAPI().handler()}
+		go server.Serve(listener)
+		mockAPIURL = fmt.Sprintf("http://%s/", listener.Addr().String())
+	})
+	return mockAPIURL, mockAPIErr
+}
+
+func newMockAstraAPI() *mockAstraAPI {
+	return &mockAstraAPI{
+		databases: make(map[string]*mockDatabase),
+	}
+}
+
+func (m *mockAstraAPI) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/currentOrg", func(w http.ResponseWriter, r *http.Request) {
+		writeMockJSON(w, http.StatusOK, map[string]string{"id": mockOrgID, "name": "mock"})
+	})
+	mux.HandleFunc("GET /v2/regions/serverless", func(w http.ResponseWriter, r *http.Request) {
+		writeMockJSON(w, http.StatusOK, mockServerlessRegions)
+	})
+	mux.HandleFunc("GET /v2/databases", m.listDatabases)
+	mux.HandleFunc("POST /v2/databases", m.createDatabase)
+	mux.HandleFunc("GET /v2/databases/{id}", m.withDatabase(m.getDatabase))
+	mux.HandleFunc("POST /v2/databases/{id}/terminate", m.withDatabase(m.terminateDatabase))
+	mux.HandleFunc("POST /v2/databases/{id}/resize", m.withDatabase(m.resizeDatabase))
+	mux.HandleFunc("GET /v2/databases/{id}/keyspaces/{keyspace}", m.withDatabase(m.getKeyspace))
+	mux.HandleFunc("POST /v2/databases/{id}/keyspaces/{keyspace}", m.withDatabase(m.addKeyspace))
+	mux.HandleFunc("DELETE /v2/databases/{id}/keyspaces/{keyspace}", m.withDatabase(m.dropKeyspace))
+	mux.HandleFunc("GET /v2/databases/{id}/datacenters", m.withDatabase(m.listDatacenters))
+	mux.HandleFunc("POST /v2/databases/{id}/datacenters", m.withDatabase(m.addDatacenters))
+	mux.HandleFunc("POST /v2/databases/{id}/datacenters/{datacenter}/terminate", m.withDatabase(m.terminateDatacenter))
+	mux.HandleFunc("POST /v2/databases/{id}/secureBundleURL", m.withDatabase(m.generateSecureBundleURLs))
+	mux.HandleFunc("GET /v2/databases/{id}/access-list", m.withDatabase(m.getAccessList))
+	mux.HandleFunc("PUT /v2/databases/{id}/access-list", m.withDatabase(m.replaceAccessList))
+	mux.HandleFunc("PATCH /v2/databases/{id}/access-list", m.withDatabase(m.replaceAccessList))
+	mux.HandleFunc("POST /v2/databases/{id}/access-list", m.withDatabase(m.addAccessListAddresses))
+	mux.HandleFunc("DELETE /v2/databases/{id}/access-list", m.withDatabase(m.deleteAccessList))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("%s %s is not supported in mock mode", r.Method, r.URL.Path))
+	})
+	return mux
+}
+
+// withDatabase locks the mock API and passes the database of the request to the handler, or responds with 404
+func (m *mockAstraAPI) withDatabase(handler func(http.ResponseWriter, *http.Request, *mockDatabase)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		db, ok := m.databases[r.PathValue("id")]
+		if !ok {
+			writeMockError(w, http.StatusNotFound, fmt.Sprintf("database %s not found", r.PathValue("id")))
+			return
+		}
+		handler(w, r, db)
+	}
+}
+
+func (m *mockAstraAPI) listDatabases(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	ids := make([]string, 0, len(m.databases))
+	for id, db := range m.databases {
+		if db.db.Status != astra.TERMINATED {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	databases := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		databases = append(databases, m.databases[id].json())
+	}
+	writeMockJSON(w, http.StatusOK, databases)
+}
+
+func (m *mockAstraAPI) createDatabase(w http.ResponseWriter, r *http.Request) {
+	var req createDatabaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("invalid database: %s", err))
+		return
+	}
+	if req.Name == "" || req.Region == "" || req.CloudProvider == "" {
+		writeMockError(w, http.StatusBadRequest, "name, cloudProvider and region are required")
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	id := mockUUID()
+	tier := req.Tier
+	capacityUnits := req.CapacityUnits
+	keyspace := req.Keyspace
+	cloudProvider := req.CloudProvider
+	region := req.Region
+	name := req.Name
+	used := 0
+	db := &mockDatabase{
+		db: astra.Database{
+			Id:      id,
+			OrgId:   mockOrgID,
+			OwnerId: mockOrgID,
+			Status:  astra.ACTIVE,
+			Info: astra.DatabaseInfo{
+				Name:                &name,
+				Keyspace:            &keyspace,
+				CloudProvider:       &cloudProvider,
+				Region:              &region,
+				Tier:                &tier,
+				CapacityUnits:       &capacityUnits,
+				AdditionalKeyspaces: &[]string{},
+				Datacenters:         &[]astra.Datacenter{},
+			},
+			Storage: &astra.Storage{
+				NodeCount:         3,
+				ReplicationFactor: 3,
+				TotalStorage:      capacityUnits * 500,
+				UsedStorage:       &used,
+			},
+		},
+		dbType: req.DbType,
+		accessList: astra.AccessListResponse{
+			Addresses:      &[]astra.AddressResponse{},
+			Configurations: &astra.AccessListConfigurations{},
+		},
+	}
+	if tier == astra.Serverless {
+		db.db.Storage.TotalStorage = 0
+	}
+	db.addDatacenter(region)
+	m.databases[id] = db
+
+	w.Header().Set("Location", id)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (m *mockAstraAPI) getDatabase(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	writeMockJSON(w, http.StatusOK, db.json())
+}
+
+func (m *mockAstraAPI) terminateDatabase(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	db.db.Status = astra.TERMINATED
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (m *mockAstraAPI) resizeDatabase(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	var req astra.CapacityUnits
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CapacityUnits == nil {
+		writeMockError(w, http.StatusBadRequest, "capacityUnits is required")
+		return
+	}
+	if *req.CapacityUnits-*db.db.Info.CapacityUnits > maxCapacityUnitsIncrease || *req.CapacityUnits < *db.db.Info.CapacityUnits {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("invalid capacity units %d", *req.CapacityUnits))
+		return
+	}
+	db.db.Info.CapacityUnits = req.CapacityUnits
+	db.db.Storage.TotalStorage = *req.CapacityUnits * 500
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockAstraAPI) getKeyspace(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	if !db.hasKeyspace(r.PathValue("keyspace")) {
+		writeMockError(w, http.StatusNotFound, fmt.Sprintf("keyspace %s not found", r.PathValue("keyspace")))
+		return
+	}
+	writeMockJSON(w, http.StatusOK, map[string]string{"name": r.PathValue("keyspace")})
+}
+
+func (m *mockAstraAPI) addKeyspace(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	// Like in Astra, the keyspace name is not quoted and stored in lower case
+	keyspace := strings.ToLower(r.PathValue("keyspace"))
+	if !db.hasKeyspace(keyspace) {
+		*db.db.Info.AdditionalKeyspaces = append(*db.db.Info.AdditionalKeyspaces, keyspace)
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (m *mockAstraAPI) dropKeyspace(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	keyspace := strings.ToLower(r.PathValue("keyspace"))
+	keyspaces := []string{}
+	for _, k := range *db.db.Info.AdditionalKeyspaces {
+		if k != keyspace {
+			keyspaces = append(keyspaces, k)
+		}
+	}
+	db.db.Info.AdditionalKeyspaces = &keyspaces
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (m *mockAstraAPI) listDatacenters(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	writeMockJSON(w, http.StatusOK, *db.db.Info.Datacenters)
+}
+
+func (m *mockAstraAPI) addDatacenters(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	var datacenters []astra.Datacenter
+	if err := json.NewDecoder(r.Body).Decode(&datacenters); err != nil {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("invalid datacenters: %s", err))
+		return
+	}
+	for _, dc := range datacenters {
+		db.addDatacenter(dc.Region)
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (m *mockAstraAPI) terminateDatacenter(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	datacenters := []astra.Datacenter{}
+	for _, dc := range *db.db.Info.Datacenters {
+		if astra.StringValue(dc.Id) != r.PathValue("datacenter") {
+			datacenters = append(datacenters, dc)
+		}
+	}
+	db.db.Info.Datacenters = &datacenters
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (m *mockAstraAPI) generateSecureBundleURLs(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	bundles := []astra.CredsURL{}
+	for _, dc := range *db.db.Info.Datacenters {
+		datacenterID := astra.StringValue(dc.Id)
+		bundles = append(bundles, astra.CredsURL{
+			DatacenterID:        &datacenterID,
+			DownloadURL:         fmt.Sprintf("https://mock.astra.local/bundles/%s/secure-connect.zip", datacenterID),
+			DownloadURLInternal: fmt.Sprintf("https://mock.astra.local/bundles/%s/secure-connect-internal.zip", datacenterID),
+		})
+	}
+	writeMockJSON(w, http.StatusOK, bundles)
+}
+
+func (m *mockAstraAPI) getAccessList(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	writeMockJSON(w, http.StatusOK, db.accessList)
+}
+
+func (m *mockAstraAPI) replaceAccessList(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	var req astra.AccessListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("invalid access list: %s", err))
+		return
+	}
+	if req.Addresses != nil {
+		db.accessList.Addresses = &[]astra.AddressResponse{}
+		db.addAccessListAddresses(*req.Addresses)
+	}
+	if req.Configurations != nil {
+		db.accessList.Configurations = req.Configurations
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (m *mockAstraAPI) addAccessListAddresses(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	var addresses []astra.AddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&addresses); err != nil {
+		writeMockError(w, http.StatusBadRequest, fmt.Sprintf("invalid addresses: %s", err))
+		return
+	}
+	db.addAccessListAddresses(addresses)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (m *mockAstraAPI) deleteAccessList(w http.ResponseWriter, r *http.Request, db *mockDatabase) {
+	remove := map[string]bool{}
+	for _, address := range r.URL.Query()["addresses"] {
+		remove[address] = true
+	}
+	addresses := []astra.AddressResponse{}
+	if len(remove) > 0 {
+		for _, a := range *db.accessList.Addresses {
+			if !remove[astra.StringValue(a.Address)] {
+				addresses = append(addresses, a)
+			}
+		}
+	}
+	db.accessList.Addresses = &addresses
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// json returns the database as returned by the Astra API, including the database type which is not known by the client
+func (db *mockDatabase) json() map[string]interface{} {
+	body, _ := json.Marshal(db.db)
+	var database map[string]interface{}
+	json.Unmarshal(body, &database)
+	if db.dbType != "" {
+		database["info"].(map[string]interface{})["dbType"] = db.dbType
+	}
+	return database
+}
+
+func (db *mockDatabase) hasKeyspace(keyspace string) bool {
+	if astra.StringValue(db.db.Info.Keyspace) == keyspace {
+		return true
+	}
+	for _, k := range *db.db.Info.AdditionalKeyspaces {
+		if k == keyspace {
+			return true
+		}
+	}
+	return false
+}
+
+func (db *mockDatabase) addDatacenter(region string) {
+	for _, dc := range *db.db.Info.Datacenters {
+		if dc.Region == region {
+			return
+		}
+	}
+	id := fmt.Sprintf("%s-%d", db.db.Id, len(*db.db.Info.Datacenters)+1)
+	cqlshURL := fmt.Sprintf("https://%s-%s.apps.astra.datastax.com/cqlsh", db.db.Id, region)
+	*db.db.Info.Datacenters = append(*db.db.Info.Datacenters, astra.Datacenter{
+		Id:            &id,
+		CloudProvider: *db.db.Info.CloudProvider,
+		Region:        region,
+		Tier:          *db.db.Info.Tier,
+		CapacityUnits: db.db.Info.CapacityUnits,
+		CqlshUrl:      &cqlshURL,
+		Status:        string(astra.ACTIVE),
+	})
+}
+
+func (db *mockDatabase) addAccessListAddresses(addresses []astra.AddressRequest) {
+	for _, a := range addresses {
+		address := a.Address
+		description := a.Description
+		enabled := a.Enabled
+		*db.accessList.Addresses = append(*db.accessList.Addresses, astra.AddressResponse{
+			Address:     &address,
+			Description: &description,
+			Enabled:     &enabled,
+		})
+	}
+}
+
+func mockUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func writeMockJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeMockError(w http.ResponseWriter, statusCode int, message string) {
+	writeMockJSON(w, statusCode, map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestMockDatabaseLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	client := p.Meta().(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	resp, err := client.CreateDatabaseWithResponse(ctx, astra.CreateDatabaseJSONRequestBody{
+		Name:          "mockdb",
+		Keyspace:      "ks1",
+		CloudProvider: "gcp",
+		Region:        "us-east1",
+		Tier:          astra.Serverless,
+		CapacityUnits: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 creating database, got %d: %s", resp.StatusCode(), resp.Body)
+	}
+	databaseID := resp.HTTPResponse.Header.Get("Location")
+
+	keyspaceResp, err := client.AddKeyspaceWithResponse(ctx, databaseID, "ks2")
+	if err != nil {
+		t.Fatal(err)
+	} else if keyspaceResp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 adding keyspace, got %d: %s", keyspaceResp.StatusCode(), keyspaceResp.Body)
+	}
+
+	database := p.ResourcesMap["astra_database"]
+	d := schema.TestResourceDataWithIdentityRaw(t, database.Schema, database.Identity.SchemaFunc(), map[string]string{"id": databaseID})
+	d.SetId(databaseID)
+	if diags := database.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatalf("failed to read database: %v", diags)
+	}
+	if d.Get("name") != "mockdb" || d.Get("status") != "ACTIVE" || d.Get("cloud_provider") != "gcp" {
+		t.Errorf("unexpected database %v, %v, %v", d.Get("name"), d.Get("status"), d.Get("cloud_provider"))
+	}
+	if d.Get("datacenters").(map[string]interface{})["gcp.us-east1"] == nil {
+		t.Errorf("expected a datacenter in us-east1, got %v", d.Get("datacenters"))
+	}
+	if preferred := d.Get("preferred_datacenters").([]interface{}); len(preferred) != 1 || preferred[0] != d.Get("datacenters").(map[string]interface{})["gcp.us-east1"] {
+		t.Errorf("expected the us-east1 datacenter to be preferred, got %v", preferred)
+	}
+
+	if diags := database.DeleteContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatalf("failed to delete database: %v", diags)
+	}
+	d.SetId(databaseID)
+	if diags := database.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatalf("failed to read deleted database: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the deleted database to be removed from state, got %q", d.Id())
+	}
+}
+
+func TestMockUnsupportedRequest(t *testing.T) {
+	url, err := startMockAstraAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(url + "v2/streaming/tenants")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+}
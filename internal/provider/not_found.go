@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// errNotFound is wrapped by the errors of the lookups of objects which do not exist. The reads of the resources remove
+// those from the state, while the data sources report the error.
+var errNotFound = errors.New("not found")
+
+// notFoundError returns an error wrapping errNotFound for the object, for example "role 1234"
+func notFoundError(object string) error {
+	return fmt.Errorf("%s %w", object, errNotFound)
+}
+
+// checkReadStatus checks the status code of the response to a read of the object: 404 is a not found error, and
+// any other status code which is not a success is an error with the response body.
+func checkReadStatus(statusCode int, body []byte, object string) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return notFoundError(object)
+	case statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices:
+		return fmt.Errorf("error reading %s, status code: %d, msg: %s", object, statusCode, string(body))
+	}
+	return nil
+}
+
+// readError returns the diagnostics of a read of a resource which failed. The resource is only removed from the state
+// when its object was not found. Any other error fails the read, so that transient failures like timeouts or 5xx
+// responses do not make Terraform plan to create the resource again.
+func readError(ctx context.Context, d *schema.ResourceData, err error) diag.Diagnostics {
+	if errors.Is(err, errNotFound) {
+		tflog.Warn(ctx, fmt.Sprintf("%v, removing %s from state", err, d.Id()))
+		d.SetId("")
+		return nil
+	}
+	return diag.FromErr(err)
+}
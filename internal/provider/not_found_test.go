@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCheckReadStatus(t *testing.T) {
+	if err := checkReadStatus(http.StatusOK, nil, "role 1234"); err != nil {
+		t.Fatalf("expected no error for a success, got %v", err)
+	}
+	err := checkReadStatus(http.StatusNotFound, []byte("not found"), "role 1234")
+	if !errors.Is(err, errNotFound) || err.Error() != "role 1234 not found" {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	for _, statusCode := range []int{http.StatusUnauthorized, http.StatusConflict, http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		err := checkReadStatus(statusCode, []byte("failed"), "role 1234")
+		if err == nil || errors.Is(err, errNotFound) {
+			t.Fatalf("expected an error which is not a not found error for status code %d, got %v", statusCode, err)
+		}
+	}
+}
+
+func TestReadError(t *testing.T) {
+	resource := &schema.Resource{Schema: map[string]*schema.Schema{}}
+
+	d := resource.TestResourceData()
+	d.SetId("1234")
+	if diags := readError(context.Background(), d, checkReadStatus(http.StatusNotFound, nil, "role 1234")); diags.HasError() {
+		t.Fatalf("expected no error for a resource which was not found, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the resource to be removed from the state, got ID %q", d.Id())
+	}
+
+	d = resource.TestResourceData()
+	d.SetId("1234")
+	if diags := readError(context.Background(), d, checkReadStatus(http.StatusInternalServerError, nil, "role 1234")); !diags.HasError() {
+		t.Fatal("expected an error for a failed read")
+	}
+	if d.Id() != "1234" {
+		t.Fatalf("expected the resource to stay in the state after a failed read, got ID %q", d.Id())
+	}
+}
+
+func TestContainsPulsarTopic(t *testing.T) {
+	topics := []string{"persistent://tenant1/default/topic1", "persistent://tenant1/default/topic2-partition-0"}
+	if !containsPulsarTopic(topics, "tenant1", "default", "topic1") {
+		t.Fatal("expected topic1 to be found")
+	}
+	if containsPulsarTopic(topics, "tenant1", "default", "topic2") {
+		t.Fatal("expected topic2 not to be found")
+	}
+	if containsPulsarTopic(topics, "tenant1", "other", "topic1") {
+		t.Fatal("expected topic1 not to be found in another namespace")
+	}
+}
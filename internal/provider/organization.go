@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+)
+
+// OrgId is the current organization returned by the Astra API
+type OrgId struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// getCurrentOrg returns the organization of the token from the Astra server. An error is returned when the request
+// fails or when the response does not hold an organization ID, so that it is not sent empty to other APIs.
+func getCurrentOrg(ctx context.Context, astraClient *astra.ClientWithResponses) (OrgId, error) {
+	var org OrgId
+	resp, err := astraClient.GetCurrentOrganizationWithResponse(ctx)
+	if err != nil {
+		return org, fmt.Errorf("failed to get current organization data: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return org, fmt.Errorf("error fetching current organization: %s", string(resp.Body))
+	}
+	if err := json.Unmarshal(resp.Body, &org); err != nil {
+		return org, fmt.Errorf("failed to decode current organization: %w", err)
+	}
+	if org.ID == "" {
+		return org, fmt.Errorf("current organization has no ID: %s", string(resp.Body))
+	}
+	return org, nil
+}
+
+// getCurrentOrgID returns the organization ID from the Astra server
+func getCurrentOrgID(ctx context.Context, astraClient *astra.ClientWithResponses) (string, error) {
+	org, err := getCurrentOrg(ctx, astraClient)
+	if err != nil {
+		return "", err
+	}
+	return org.ID, nil
+}
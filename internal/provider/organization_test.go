@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+)
+
+func TestGetCurrentOrg(t *testing.T) {
+	body := `{"id": "org1", "name": "test"}`
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client, err := astra.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org, err := getCurrentOrg(context.Background(), client)
+	if err != nil || org.ID != "org1" || org.Name != "test" {
+		t.Fatalf("unexpected organization: %v, %v", org, err)
+	}
+
+	body = `{"name": "test"}`
+	if _, err := getCurrentOrg(context.Background(), client); err == nil || !strings.Contains(err.Error(), "has no ID") {
+		t.Fatalf("expected an error for a missing organization ID, got %v", err)
+	}
+
+	body = `<html>`
+	if _, err := getCurrentOrg(context.Background(), client); err == nil || !strings.Contains(err.Error(), "failed to decode") {
+		t.Fatalf("expected an error for an invalid response, got %v", err)
+	}
+
+	status, body = http.StatusUnauthorized, `{"errors": []}`
+	if _, err := getCurrentOrgID(context.Background(), client); err == nil || !strings.Contains(err.Error(), "error fetching current organization") {
+		t.Fatalf("expected an error for an unauthorized token, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Operations of the resources which require a role permission, used in error messages like "error adding keyspace to
+// database"
+const (
+	opCreateDatabase              = "creating database"
+	opTerminateDatabase           = "terminating database"
+	opResizeDatabase              = "resizing database"
+	opRestoreBackup               = "restoring backup into database"
+	opAddKeyspace                 = "adding keyspace to database"
+	opDropKeyspace                = "dropping keyspace from database"
+	opReadAccessList              = "reading access list of database"
+	opUpdateAccessList            = "updating access list of database"
+	opCreateToken                 = "creating token"
+	opCreateRole                  = "creating role"
+	opUpdateRole                  = "updating role"
+	opCreateStreamingTenant       = "creating streaming tenant"
+	opDeleteStreamingTenant       = "deleting streaming tenant"
+	opUpgradeStreamingTenant      = "upgrading streaming tenant plan"
+	opConfigureStreamingTelemetry = "configuring streaming telemetry"
+	opConfigureAutoTopicCreation  = "configuring auto topic creation of namespace"
+	opConfigureSchemaPolicy       = "configuring schema policy of namespace"
+	opEnableCDC                   = "enabling CDC"
+	opDeleteCDC                   = "deleting CDC"
+)
+
+// requiredPermissions maps operations to the Astra role permission they require, as documented by the security
+// requirements of the DevOps API. The Astra Streaming API does not document the permissions of its operations.
+var requiredPermissions = map[string]string{
+	opCreateDatabase:    "org-db-create",
+	opTerminateDatabase: "org-db-terminate",
+	opResizeDatabase:    "org-db-expand",
+	opAddKeyspace:       "db-keyspace-create",
+	opDropKeyspace:      "db-keyspace-drop",
+	opReadAccessList:    "accesslist-read",
+	opUpdateAccessList:  "accesslist-write",
+	opCreateToken:       "org-token-write",
+	opCreateRole:        "org-role-write",
+	opUpdateRole:        "org-role-write",
+}
+
+// errInsufficientPermissions is wrapped by the errors of permissionError, so that optional reads can be skipped when
+// the role of the token does not allow them
+var errInsufficientPermissions = errors.New("insufficient permissions")
+
+// permissionError returns an error naming the role permission required by the operation when the response status is
+// 401 Unauthorized or 403 Forbidden, and nil otherwise
+func permissionError(operation string, statusCode int, body []byte) error {
+	if statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden {
+		return nil
+	}
+	if permission, ok := requiredPermissions[operation]; ok {
+		return fmt.Errorf("error %s (%w, role missing '%s'): %s", operation, errInsufficientPermissions, permission, string(body))
+	}
+	return fmt.Errorf("error %s (%w, the role of the token does not allow this operation): %s", operation, errInsufficientPermissions, string(body))
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPermissionError(t *testing.T) {
+	if err := permissionError(opAddKeyspace, http.StatusBadRequest, nil); err != nil {
+		t.Fatalf("expected no permission error for a bad request, got %v", err)
+	}
+	err := permissionError(opAddKeyspace, http.StatusUnauthorized, []byte("unauthorized"))
+	if err == nil || !strings.Contains(err.Error(), "error adding keyspace to database (insufficient permissions, role missing 'db-keyspace-create')") {
+		t.Fatalf("unexpected permission error: %v", err)
+	}
+	err = permissionError(opCreateStreamingTenant, http.StatusForbidden, []byte("forbidden"))
+	if err == nil || !strings.Contains(err.Error(), "error creating streaming tenant (insufficient permissions") {
+		t.Fatalf("unexpected permission error: %v", err)
+	}
+	if !errors.Is(err, errInsufficientPermissions) {
+		t.Fatalf("expected the permission error to wrap errInsufficientPermissions, got %v", err)
+	}
+}
@@ -1,16 +1,23 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
 
 	"github.com/datastax/astra-client-go/v2/astra"
-	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -40,33 +47,66 @@ func New(version string) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			DataSourcesMap: map[string]*schema.Resource{
-				"astra_database":                  dataSourceDatabase(),
-				"astra_databases":                 dataSourceDatabases(),
-				"astra_keyspace":                  dataSourceKeyspace(),
-				"astra_keyspaces":                 dataSourceKeyspaces(),
-				"astra_secure_connect_bundle_url": dataSourceSecureConnectBundleURL(),
-				"astra_available_regions":         dataSourceAvailableRegions(),
-				"astra_private_links":             dataSourcePrivateLinks(),
-				"astra_private_link_endpoints":    dataSourcePrivateLinkEndpoints(),
-				"astra_access_list":               dataSourceAccessList(),
-				"astra_role":                      dataSourceRole(),
-				"astra_roles":                     dataSourceRoles(),
-				"astra_users":                     dataSourceUsers(),
-				"astra_streaming_tenant_tokens":   dataSourceStreamingTenantTokens(),
+				"astra_database":                        dataSourceDatabase(),
+				"astra_databases":                       dataSourceDatabases(),
+				"astra_database_health":                 dataSourceDatabaseHealth(),
+				"astra_database_metrics_endpoint":       dataSourceDatabaseMetricsEndpoint(),
+				"astra_database_guardrails":             dataSourceDatabaseGuardrails(),
+				"astra_database_connection_info":        dataSourceDatabaseConnectionInfo(),
+				"astra_datacenters":                     dataSourceDatacenters(),
+				"astra_backups":                         dataSourceBackups(),
+				"astra_keyspace":                        dataSourceKeyspace(),
+				"astra_keyspaces":                       dataSourceKeyspaces(),
+				"astra_table":                           dataSourceTable(),
+				"astra_tables":                          dataSourceTables(),
+				"astra_collections":                     dataSourceCollections(),
+				"astra_secure_connect_bundle_url":       dataSourceSecureConnectBundleURL(),
+				"astra_secure_connect_bundle_urls":      dataSourceSecureConnectBundleURLs(),
+				"astra_available_regions":               dataSourceAvailableRegions(),
+				"astra_available_tiers":                 dataSourceAvailableTiers(),
+				"astra_region_pricing":                  dataSourceRegionPricing(),
+				"astra_cost_estimate":                   dataSourceCostEstimate(),
+				"astra_cloud_providers":                 dataSourceCloudProviders(),
+				"astra_capabilities":                    dataSourceCapabilities(),
+				"astra_organization_limits":             dataSourceOrganizationLimits(),
+				"astra_private_links":                   dataSourcePrivateLinks(),
+				"astra_private_link_endpoints":          dataSourcePrivateLinkEndpoints(),
+				"astra_private_link_allowed_principals": dataSourcePrivateLinkAllowedPrincipals(),
+				"astra_access_list":                     dataSourceAccessList(),
+				"astra_role":                            dataSourceRole(),
+				"astra_roles":                           dataSourceRoles(),
+				"astra_users":                           dataSourceUsers(),
+				"astra_streaming_tenant_tokens":         dataSourceStreamingTenantTokens(),
+				"astra_streaming_service_urls":          dataSourceStreamingServiceURLs(),
+				"astra_streaming_namespaces":            dataSourceStreamingNamespaces(),
+				"astra_streaming_connectors":            dataSourceStreamingConnectors(),
+				"astra_streaming_connector_status":      dataSourceStreamingConnectorStatus(),
+				"astra_streaming_topic_schema":          dataSourceStreamingTopicSchema(),
+				"astra_current_token_info":              dataSourceCurrentTokenInfo(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"astra_database":              resourceDatabase(),
-				"astra_keyspace":              resourceKeyspace(),
-				"astra_private_link":          resourcePrivateLink(),
-				"astra_private_link_endpoint": resourcePrivateLinkEndpoint(),
-				"astra_access_list":           resourceAccessList(),
-				"astra_role":                  resourceRole(),
-				"astra_token":                 resourceToken(),
-				"astra_cdc":                   resourceCDC(),
-				"astra_streaming_tenant":      resourceStreamingTenant(),
-				"astra_streaming_sink":        resourceStreamingSink(),
-				"astra_streaming_topic":       resourceStreamingTopic(),
-				"astra_table":                 resourceTable(),
+				"astra_database":                                resourceDatabase(),
+				"astra_keyspace":                                resourceKeyspace(),
+				"astra_keyspaces":                               resourceKeyspaces(),
+				"astra_private_link":                            resourcePrivateLink(),
+				"astra_private_link_endpoint":                   resourcePrivateLinkEndpoint(),
+				"astra_access_list":                             resourceAccessList(),
+				"astra_role":                                    resourceRole(),
+				"astra_token":                                   resourceToken(),
+				"astra_tokens":                                  resourceTokens(),
+				"astra_cdc":                                     resourceCDC(),
+				"astra_cdc_keyspace":                            resourceCDCKeyspace(),
+				"astra_streaming_tenant":                        resourceStreamingTenant(),
+				"astra_streaming_sink":                          resourceStreamingSink(),
+				"astra_streaming_astra_db_sink":                 resourceStreamingAstraDBSink(),
+				"astra_streaming_transform_function":            resourceStreamingTransformFunction(),
+				"astra_streaming_topic":                         resourceStreamingTopic(),
+				"astra_streaming_telemetry":                     resourceStreamingTelemetry(),
+				"astra_streaming_namespace_auto_topic_creation": resourceStreamingNamespaceAutoTopicCreation(),
+				"astra_streaming_namespace_schema_policy":       resourceStreamingNamespaceSchemaPolicy(),
+				"astra_table":                                   resourceTable(),
+				"astra_collection":                              resourceCollection(),
+				"astra_data_api_namespace":                      resourceDataAPINamespace(),
 			},
 			Schema: map[string]*schema.Schema{
 				"token": {
@@ -76,6 +116,12 @@ func New(version string) func() *schema.Provider {
 					Description: "Authentication token for Astra API.",
 					Sensitive:   true,
 				},
+				"token_command": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_API_TOKEN_COMMAND", ""),
+					Description: "Shell command printing the authentication token for Astra API, for example to read it from Vault or a cloud secret store. The command runs when the provider is configured, and its output takes precedence over `token`.",
+				},
 				"astra_api_url": {
 					Type:        schema.TypeString,
 					Optional:    true,
@@ -88,17 +134,55 @@ func New(version string) func() *schema.Provider {
 					DefaultFunc: schema.EnvDefaultFunc("ASTRA_STREAMING_API_URL", DefaultStreamingAPIURL),
 					Description: "URL for Astra Streaming API.",
 				},
+				"otel_exporter_endpoint": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_OTEL_EXPORTER_ENDPOINT", ""),
+					Description: "OTLP/HTTP endpoint receiving OpenTelemetry traces of the Astra DevOps and Streaming API calls, for example `http://localhost:4318`. Every API request is recorded as a span. Tracing is disabled unless this is set.",
+				},
+				"response_cache_ttl": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_RESPONSE_CACHE_TTL", ""),
+					Description: "Time to live of the cached responses of the Astra API GET requests, like `30s` or `2m`, to speed up the refresh of large states which read the same databases and tenants many times. Requests which change an object invalidate its cached responses, but changes made outside of Terraform, and the progress of long operations like database creation, are only seen once the cached responses expire. Caching is disabled unless this is set.",
+				},
+				"http_keep_alive": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_HTTP_KEEP_ALIVE", ""),
+					Description: "How long idle connections to the Astra APIs are kept open to be reused by the next requests, like `90s`, which is the default. Set `0s` to disable keep-alive, so every request opens a new connection.",
+				},
+				"http_max_idle_connections": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_HTTP_MAX_IDLE_CONNECTIONS", 0),
+					Description: "Number of idle connections kept open per host. Raise it when many resources of the same database or tenant are applied in parallel, so they reuse connections instead of opening new ones. Defaults to the number of CPUs plus one.",
+				},
+				"http_request_timeout": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_HTTP_REQUEST_TIMEOUT", ""),
+					Description: "Timeout of each attempt of an Astra API request, including reading the response, like `30s`. Reads which time out are retried, other requests which time out fail without being retried. Requests have no timeout unless this is set.",
+				},
+				"mock": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ASTRA_MOCK", false),
+					Description: "Serve the Astra DevOps API from an in-process fake instead of `astra_api_url`, so configurations can be planned and applied without credentials or costs. Only databases, keyspaces and access lists are supported, and the fake state is lost when the provider process exits.",
+				},
 			},
 		}
 
 		p.ConfigureContextFunc = configure(version, p)
+		withDeprecationWarnings(p.ResourcesMap)
+		withDeprecationWarnings(p.DataSourcesMap)
 
 		return p
 	}
 }
 
 func configure(providerVersion string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	return func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		userAgent := p.UserAgent("terraform-provider-astra", providerVersion)
 		astraAPIServerURL := d.Get("astra_api_url").(string)
 		if _, err := url.Parse(astraAPIServerURL); err != nil {
@@ -109,23 +193,51 @@ func configure(providerVersion string, p *schema.Provider) func(context.Context,
 			return nil, diag.FromErr(fmt.Errorf("invalid Astra Streaming server API URL: %w", err))
 		}
 		token := d.Get("token").(string)
+		if command := d.Get("token_command").(string); command != "" {
+			commandToken, err := runTokenCommand(ctx, command)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			token = commandToken
+		}
+		if d.Get("mock").(bool) {
+			mockURL, err := startMockAstraAPI()
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			astraAPIServerURL = mockURL
+			streamingAPIServerURL = mockURL
+			if token == "" {
+				token = "mock"
+			}
+		}
+		tracerProvider, err := newTracerProvider(ctx, d.Get("otel_exporter_endpoint").(string), providerVersion)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		notices := &deprecationNotices{}
+		var cache *responseCache
+		if ttl := d.Get("response_cache_ttl").(string); ttl != "" {
+			duration, err := time.ParseDuration(ttl)
+			if err != nil || duration < 0 {
+				return nil, diag.Errorf("invalid response_cache_ttl %q: expected a duration like 30s, or 0s to disable caching", ttl)
+			}
+			if duration > 0 {
+				cache = newResponseCache(duration)
+			}
+		}
+		httpOptions, err := parseHTTPClientOptions(d.Get("http_keep_alive").(string), d.Get("http_max_idle_connections").(int), d.Get("http_request_timeout").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		httpTransport := newHTTPTransport(httpOptions)
 		authorization := fmt.Sprintf("Bearer %s", token)
 		clientVersion := fmt.Sprintf("go/%s", astra.Version)
 
-		// Build a retryable http astraClient to automatically
-		// handle intermittent api errors
-		retryClient := retryablehttp.NewClient()
-		retryClient.RetryMax = 10
-		retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
-			// Never retry POST requests because of side effects
-			if resp.Request.Method == "POST" {
-				return false, err
-			}
-			return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-		}
+		retryClient := newRetryClient(httpTransport, httpOptions)
 
 		astraClient, err := astra.NewClientWithResponses(astraAPIServerURL, func(c *astra.Client) error {
-			c.Client = retryClient.StandardClient()
+			c.Client = newCachingClient(newDeprecationClient(newTracingClient(retryClient.StandardClient(), tracerProvider, "devops"), notices), cache)
 			c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
 				req.Header.Set("Authorization", authorization)
 				req.Header.Set("User-Agent", userAgent)
@@ -140,7 +252,7 @@ func configure(providerVersion string, p *schema.Provider) func(context.Context,
 		}
 
 		streamingClient, err := astrastreaming.NewClientWithResponses(astraAPIServerURL, func(c *astrastreaming.Client) error {
-			c.Client = retryClient.StandardClient()
+			c.Client = newCachingClient(newDeprecationClient(newTracingClient(retryClient.StandardClient(), tracerProvider, "streaming"), notices), cache)
 			c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
 				req.Header.Set("Authorization", authorization)
 				req.Header.Set("User-Agent", userAgent)
@@ -155,7 +267,7 @@ func configure(providerVersion string, p *schema.Provider) func(context.Context,
 		}
 
 		streamingV3Client, err := astrastreaming.NewClientWithResponses(streamingAPIServerURL, func(c *astrastreaming.Client) error {
-			c.Client = retryClient.StandardClient()
+			c.Client = newCachingClient(newDeprecationClient(newTracingClient(retryClient.StandardClient(), tracerProvider, "streaming"), notices), cache)
 			c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
 				req.Header.Set("User-Agent", userAgent)
 				req.Header.Set("X-Astra-Provider-Version", providerVersion)
@@ -178,24 +290,21 @@ func configure(providerVersion string, p *schema.Provider) func(context.Context,
 			stargateClientCache:    clientCache,
 			providerVersion:        providerVersion,
 			userAgent:              userAgent,
+			serverlessRegions:      &serverlessRegionsCache{},
+			pulsarTokens:           newPulsarTokenCache(),
+			deprecationNotices:     notices,
+			httpTransport:          httpTransport,
+			httpOptions:            httpOptions,
 		}
 		return clients, nil
 	}
 }
 
-func newRestClient(dbid string, providerVersion string, userAgent string, region string) (astrarestapi.Client, error) {
+func newRestClient(meta interface{}, dbid string, region string) (astrarestapi.Client, error) {
+	providerVersion := meta.(astraClients).providerVersion
+	userAgent := meta.(astraClients).userAgent
 	clientVersion := fmt.Sprintf("go/%s", astra.Version)
-	// Build a retryable http astraClient to automatically
-	// handle intermittent api errors
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 10
-	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
-		// Never retry POST requests because of side effects
-		if resp.Request.Method == "POST" {
-			return false, err
-		}
-		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-	}
+	retryClient := newRetryClient(meta.(astraClients).httpTransport, meta.(astraClients).httpOptions)
 
 	serverURL := fmt.Sprintf("https://%s-%s.apps.astra.datastax.com/api/rest/", dbid, region)
 	restClient, err := astrarestapi.NewClient(serverURL, func(c *astrarestapi.Client) error {
@@ -214,6 +323,166 @@ func newRestClient(dbid string, providerVersion string, userAgent string, region
 	return *restClient, nil
 }
 
+// astraAPIGet sends a GET request for a DevOps API path that is not covered by the generated client
+func astraAPIGet(ctx context.Context, client *astra.ClientWithResponses, path string) (int, []byte, error) {
+	return astraAPIRequest(ctx, client, http.MethodGet, path, nil)
+}
+
+// astraAPIRequest sends a request for a DevOps API path that is not covered by the generated client. The request body
+// is encoded as JSON, unless it is nil.
+func astraAPIRequest(ctx context.Context, client *astra.ClientWithResponses, method, path string, requestBody interface{}) (int, []byte, error) {
+	c := client.ClientInterface.(*astra.Client)
+	var bodyReader io.Reader
+	if requestBody != nil {
+		encoded, err := json.Marshal(requestBody)
+		if err != nil {
+			return 0, nil, err
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.Server+strings.TrimPrefix(path, "/"), bodyReader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, edit := range c.RequestEditors {
+		if err := edit(ctx, req); err != nil {
+			return 0, nil, err
+		}
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// streamingAPIRequest sends a request for an Astra Streaming API path of the organization that is not covered by the
+// generated client. The request body is encoded as JSON, unless it is nil.
+func streamingAPIRequest(ctx context.Context, client *astrastreaming.ClientWithResponses, method, path, orgID string, requestBody interface{}) (int, []byte, error) {
+	c := client.ClientInterface.(*astrastreaming.Client)
+	var bodyReader io.Reader
+	if requestBody != nil {
+		encoded, err := json.Marshal(requestBody)
+		if err != nil {
+			return 0, nil, err
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.Server+strings.TrimPrefix(path, "/"), bodyReader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-DataStax-Current-Org", orgID)
+	for _, edit := range c.RequestEditors {
+		if err := edit(ctx, req); err != nil {
+			return 0, nil, err
+		}
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// streamingAdminGet sends a GET request for a Pulsar admin API path that is not covered by the generated client
+func streamingAdminGet(ctx context.Context, client *astrastreaming.ClientWithResponses, path, pulsarCluster, pulsarToken string) (int, []byte, error) {
+	return streamingAdminRequest(ctx, client, http.MethodGet, path, pulsarCluster, pulsarToken, nil)
+}
+
+// streamingAdminRequest sends a request for a Pulsar admin API path that is not covered by the generated client. The
+// request body is encoded as JSON, unless it is nil.
+func streamingAdminRequest(ctx context.Context, client *astrastreaming.ClientWithResponses, method, path, pulsarCluster, pulsarToken string, requestBody interface{}) (int, []byte, error) {
+	if requestBody == nil {
+		return doStreamingAdminRequest(ctx, client, method, path, pulsarCluster, pulsarToken, "", nil)
+	}
+	encoded, err := json.Marshal(requestBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	return doStreamingAdminRequest(ctx, client, method, path, pulsarCluster, pulsarToken, "application/json", bytes.NewReader(encoded))
+}
+
+// streamingAdminFormRequest sends a multipart form request for a Pulsar admin API path, like the registration of a
+// function. Each part is encoded as JSON.
+func streamingAdminFormRequest(ctx context.Context, client *astrastreaming.ClientWithResponses, method, path, pulsarCluster, pulsarToken string, parts map[string]interface{}) (int, []byte, error) {
+	names := make([]string, 0, len(parts))
+	for name := range parts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, name := range names {
+		encoded, err := json.Marshal(parts[name])
+		if err != nil {
+			return 0, nil, err
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, name))
+		header.Set("Content-Type", "application/json")
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return 0, nil, err
+		}
+		if _, err := part.Write(encoded); err != nil {
+			return 0, nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return 0, nil, err
+	}
+	return doStreamingAdminRequest(ctx, client, method, path, pulsarCluster, pulsarToken, writer.FormDataContentType(), &body)
+}
+
+func doStreamingAdminRequest(ctx context.Context, client *astrastreaming.ClientWithResponses, method, path, pulsarCluster, pulsarToken, contentType string, body io.Reader) (int, []byte, error) {
+	c := client.ClientInterface.(*astrastreaming.Client)
+	req, err := http.NewRequestWithContext(ctx, method, c.Server+strings.TrimPrefix(path, "/"), body)
+	if err != nil {
+		return 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-DataStax-Pulsar-Cluster", pulsarCluster)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", pulsarToken))
+	for _, edit := range c.RequestEditors {
+		if err := edit(ctx, req); err != nil {
+			return 0, nil, err
+		}
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
 type astraClients struct {
 	astraClient            interface{}
 	astraStreamingClient   interface{}
@@ -222,4 +491,10 @@ type astraClients struct {
 	stargateClientCache    map[string]astrarestapi.Client
 	providerVersion        string
 	userAgent              string
+	serverlessRegions      *serverlessRegionsCache
+	pulsarTokens           *pulsarTokenCache
+	deprecationNotices     *deprecationNotices
+	// httpTransport is shared by the clients of all the APIs, including the clients of the databases
+	httpTransport http.RoundTripper
+	httpOptions   httpClientOptions
 }
@@ -1,16 +1,36 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/terraform-providers/terraform-provider-aws/aws"
 )
 
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// testAccProtoV5ProviderFactories serve the SDK and the framework provider together, like main does. They are needed
+// to test the provider functions.
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"astra": func() (tfprotov5.ProviderServer, error) {
+		muxServer, err := tf5muxserver.NewMuxServer(context.Background(),
+			testAccProvider.GRPCProvider,
+			providerserver.NewProtocol5(NewFrameworkProvider("1")()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return muxServer.ProviderServer(), nil
+	},
+}
+
 func init() {
 	testAccProvider = New("1")()
 	testAccProviders = map[string]*schema.Provider{
@@ -21,8 +41,11 @@ func init() {
 }
 
 func testAccPreCheck(t *testing.T) {
+	if os.Getenv("ASTRA_MOCK") != "" {
+		return
+	}
 	if err := os.Getenv("ASTRA_API_TOKEN"); err == "" {
-		t.Fatal("ASTRA_API_TOKEN must be set for acceptance tests")
+		t.Fatal("ASTRA_API_TOKEN or ASTRA_MOCK must be set for acceptance tests")
 	}
 }
 
@@ -31,3 +54,24 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestDeletionPolicyAbandon(t *testing.T) {
+	resources := New("dev")().ResourcesMap
+	for _, name := range []string{"astra_database", "astra_keyspace", "astra_streaming_tenant", "astra_cdc"} {
+		r := resources[name]
+		d := r.Data(&terraform.InstanceState{
+			ID: "abandoned",
+			Attributes: map[string]string{
+				"deletion_policy":     "abandon",
+				"deletion_protection": "true",
+			},
+		})
+		// No client is configured, so the delete would fail if it called the API
+		if diags := r.DeleteContext(context.Background(), d, astraClients{}); diags.HasError() {
+			t.Errorf("%s: unexpected error abandoning the resource: %v", name, diags)
+		}
+		if d.Id() != "" {
+			t.Errorf("%s: expected the resource to be removed from the state", name)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,7 +20,9 @@ func resourceAccessList() *schema.Resource {
 		Description:   "`astra_access_list` resource represents a database access list, used to limit the ip's / CIDR groups that have access to a database.",
 		CreateContext: resourceAccessListCreate,
 		ReadContext:   resourceAccessListRead,
+		UpdateContext: resourceAccessListUpdate,
 		DeleteContext: resourceAccessListDelete,
+		CustomizeDiff: resourceAccessListCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -40,10 +45,11 @@ func resourceAccessList() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"address": {
-							Description: "IP Address/CIDR group that should have access",
-							Type:        schema.TypeString,
-							Required:    true,
-							ForceNew:    true,
+							Description:      "IP Address/CIDR group that should have access",
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validateAccessListAddress,
 						},
 						"description": {
 							Description: "Description for the IP Address/CIDR group",
@@ -57,14 +63,25 @@ func resourceAccessList() *schema.Resource {
 							Required:    true,
 							ForceNew:    true,
 						},
+						"expires_at": {
+							Description:  "The time (RFC3339) after which this IP Address/CIDR group is no longer needed, for temporary access grants. The expiry is recorded in the description of the address in Astra, it does not remove the address.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"expired": {
+							Description: "Whether the expiry of this IP Address/CIDR group has passed.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
 					},
 				},
 			},
 			"enabled": {
-				Description: "Public access restrictions enabled or disabled",
+				Description: "Whether the access list is enforced. When `true`, access to the database is denied by default and only the enabled addresses of the list are allowed. When `false`, the database is publicly accessible and the addresses are ignored. Can be changed without recreating the access list.",
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 			},
 		},
 	}
@@ -85,6 +102,8 @@ func resourceAccessListCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	if err != nil {
 		return diag.FromErr(err)
+	} else if err := permissionError(opUpdateAccessList, addResp.StatusCode(), addResp.Body); err != nil {
+		return diag.FromErr(err)
 	} else if addResp.StatusCode() >= 400 {
 		return diag.Errorf("error adding access list to database: (%d) %s", addResp.StatusCode(), addResp.Body)
 	}
@@ -102,6 +121,8 @@ func resourceAccessListCreate(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		fmt.Print(err)
 		return diag.FromErr(err)
+	} else if err := permissionError(opUpdateAccessList, updResp.StatusCode(), updResp.Body); err != nil {
+		return diag.FromErr(err)
 	} else if updResp.StatusCode() >= 400 {
 		return diag.Errorf("error updating access list configuration: %d\n%s", updResp.StatusCode(), updResp.Body)
 	}
@@ -109,6 +130,68 @@ func resourceAccessListCreate(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+func resourceAccessListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID, err := parseAccessListID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the default behavior can change in place, the addresses force a new access list
+	if d.HasChange("enabled") {
+		addressList := getAddressList(d.Get("addresses").([]interface{}))
+		accessListConfig := astra.AccessListConfigurations{AccessListEnabled: d.Get("enabled").(bool)}
+		updResp, err := client.UpdateAccessListForDatabaseWithResponse(ctx,
+			astra.DatabaseIdParam(databaseID),
+			astra.UpdateAccessListForDatabaseJSONRequestBody{
+				Addresses:      &addressList,
+				Configurations: &accessListConfig,
+			},
+		)
+		if err != nil {
+			return diag.FromErr(err)
+		} else if err := permissionError(opUpdateAccessList, updResp.StatusCode(), updResp.Body); err != nil {
+			return diag.FromErr(err)
+		} else if updResp.StatusCode() >= 400 {
+			return diag.Errorf("error updating access list configuration: %d\n%s", updResp.StatusCode(), updResp.Body)
+		}
+	}
+
+	return resourceAccessListRead(ctx, d, meta)
+}
+
+// resourceAccessListCustomizeDiff rejects duplicate and overlapping addresses at plan time, since the API would
+// reject them after some of the addresses were already added
+func resourceAccessListCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("addresses") {
+		return nil
+	}
+
+	var addresses []string
+	var networks []*net.IPNet
+	for _, a := range d.Get("addresses").([]interface{}) {
+		addrRequest, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, _ := addrRequest["address"].(string)
+		network, err := parseAccessListAddress(address)
+		if err != nil {
+			// Unknown or invalid addresses are reported by the address validation
+			continue
+		}
+		for i, other := range networks {
+			if network.Contains(other.IP) || other.Contains(network.IP) {
+				return fmt.Errorf("access list addresses %s and %s overlap", addresses[i], address)
+			}
+		}
+		addresses = append(addresses, address)
+		networks = append(networks, network)
+	}
+	return nil
+}
+
 func resourceAccessListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 
@@ -165,10 +248,11 @@ func resourceAccessListRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	accessList, err := listAccessList(ctx, client, databaseID)
 	if err != nil {
-		return diag.FromErr(err)
+		return readError(ctx, d, err)
 	}
 
-	if string(*accessList.DatabaseId) == databaseID {
+	// The access list is nil when the database is terminated
+	if accessList != nil && accessList.DatabaseId != nil && string(*accessList.DatabaseId) == databaseID {
 		if err := setAccessListData(d, accessList); err != nil {
 			return diag.FromErr(err)
 		}
@@ -191,10 +275,13 @@ func setAccessListData(d *schema.ResourceData, accessList *astra.AccessListRespo
 	addresses := *accessList.Addresses
 	requests := make([]map[string]interface{}, 0, len(addresses))
 	for _, addr := range addresses {
+		description, expiresAt := splitAccessListDescription(*addr.Description)
 		reqMap := map[string]interface{}{
 			"address":     *addr.Address,
-			"description": *addr.Description,
+			"description": description,
 			"enabled":     *addr.Enabled,
+			"expires_at":  expiresAt,
+			"expired":     accessListAddressExpired(expiresAt, time.Now()),
 		}
 		requests = append(requests, reqMap)
 	}
@@ -208,11 +295,11 @@ func setAccessListData(d *schema.ResourceData, accessList *astra.AccessListRespo
 }
 
 func parseAccessListID(id string) (string, error) {
-	idParts := strings.Split(strings.ToLower(id), "/")
-	if len(idParts) != 1 {
-		return "", errors.New("invalid access list id format: expected databaseId/")
+	parts, err := resourceid.AccessList.Parse(id)
+	if err != nil {
+		return "", err
 	}
-	return idParts[0], nil
+	return parts[0], nil
 }
 
 func getAddressList(addresses []interface{}) []astra.AddressRequest {
@@ -222,8 +309,44 @@ func getAddressList(addresses []interface{}) []astra.AddressRequest {
 		addressList[index] = astra.AddressRequest{
 			Address:     address["address"].(string),
 			Enabled:     address["enabled"].(bool),
-			Description: address["description"].(string),
+			Description: accessListDescription(address["description"].(string), address["expires_at"].(string)),
 		}
 	}
 	return addressList
 }
+
+// accessListExpiryPattern matches the expiry annotation appended to the description of temporary addresses
+var accessListExpiryPattern = regexp.MustCompile(`^(.*?) ?\[expires (\S+)\]$`)
+
+// accessListDescription returns the description of an address stored in Astra, with the expiry annotation if any, so
+// temporary access grants are also documented in the Astra UI
+func accessListDescription(description, expiresAt string) string {
+	if expiresAt == "" {
+		return description
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s [expires %s]", description, expiresAt))
+}
+
+// splitAccessListDescription splits the description of an address stored in Astra into the description and the expiry
+func splitAccessListDescription(description string) (string, string) {
+	match := accessListExpiryPattern.FindStringSubmatch(description)
+	if match == nil {
+		return description, ""
+	}
+	if _, err := time.Parse(time.RFC3339, match[2]); err != nil {
+		return description, ""
+	}
+	return match[1], match[2]
+}
+
+// accessListAddressExpired returns whether the expiry of an address is before now, addresses without expiry never expire
+func accessListAddressExpired(expiresAt string, now time.Time) bool {
+	if expiresAt == "" {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false
+	}
+	return now.After(expiry)
+}
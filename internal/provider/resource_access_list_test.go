@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -40,12 +41,53 @@ resource "astra_access_list" "example" {
   addresses {
       address= "0.0.0.3/0"
       enabled= true
+      description = "temporary access"
+      expires_at = "2030-01-01T00:00:00Z"
   }
   enabled = true
 }
 `, databaseID)
 }
 
+func TestAccessListDescription(t *testing.T) {
+	cases := []struct {
+		description string
+		expiresAt   string
+		stored      string
+	}{
+		{"", "", ""},
+		{"office", "", "office"},
+		{"contractor", "2030-01-01T00:00:00Z", "contractor [expires 2030-01-01T00:00:00Z]"},
+		{"", "2030-01-01T00:00:00Z", "[expires 2030-01-01T00:00:00Z]"},
+	}
+	for _, c := range cases {
+		stored := accessListDescription(c.description, c.expiresAt)
+		if stored != c.stored {
+			t.Errorf("accessListDescription(%q, %q) = %q, expected %q", c.description, c.expiresAt, stored, c.stored)
+		}
+		description, expiresAt := splitAccessListDescription(stored)
+		if description != c.description || expiresAt != c.expiresAt {
+			t.Errorf("splitAccessListDescription(%q) = %q, %q, expected %q, %q", stored, description, expiresAt, c.description, c.expiresAt)
+		}
+	}
+
+	// Descriptions which merely look like an annotation are kept as is
+	if description, expiresAt := splitAccessListDescription("vpn [expires soon]"); description != "vpn [expires soon]" || expiresAt != "" {
+		t.Errorf("unexpected split of invalid expiry: %q, %q", description, expiresAt)
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if accessListAddressExpired("", now) {
+		t.Error("address without expiry should not be expired")
+	}
+	if accessListAddressExpired("2030-01-01T00:00:00Z", now) {
+		t.Error("address expiring in the future should not be expired")
+	}
+	if !accessListAddressExpired("2025-12-31T23:59:59Z", now) {
+		t.Error("address expiring in the past should be expired")
+	}
+}
+
 func TestTimeUnmarshal(t *testing.T) {
 	msg := `{"lastUpdateDateTime":"2021-08-03 15:20:29.008 +0000 UTC"}`
 	//msg := `{"lastUpdateDateTime":"2021-08-03T15:20:29Z"}`
@@ -3,40 +3,87 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
-	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Mutexes for synchronizing CDC operations, keyed by tenant name. Enabling CDC for several tables of the same tenant
+// in parallel fails with 401 errors from the streaming API, so these operations are serialized.
+var cdcMutex = newMutexKV()
+
+var cdcCreateTimeout = time.Minute * 20
+var cdcDeleteTimeout = time.Minute * 20
+
+// cdcConnectorStatusRunning is the connector status of a healthy CDC configuration
+const cdcConnectorStatusRunning = "Running"
+
 func resourceCDC() *schema.Resource {
 	return &schema.Resource{
 		Description:   "`astra_cdc` enables cdc for an Astra Serverless table.",
 		CreateContext: resourceCDCCreate,
 		ReadContext:   resourceCDCRead,
+		UpdateContext: resourceCDCUpdate,
 		DeleteContext: resourceCDCDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceCDCImport,
+		},
+
+		Identity: &schema.ResourceIdentity{
+			Version: 0,
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"database_id": {
+						Description:       "The ID of the Astra database.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"keyspace": {
+						Description:       "The keyspace of the table.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"table": {
+						Description:       "The table with CDC enabled.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"tenant_name": {
+						Description:       "The streaming tenant receiving the changes.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+				}
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: &cdcCreateTimeout,
+			Delete: &cdcDeleteTimeout,
 		},
 
 		Schema: map[string]*schema.Schema{
 			// Required
 			"table": {
-				Description:  "Astra database table.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "Astra database table. The keyspace and table must exist before CDC is enabled.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateCQLIdentifier,
 			},
 			"keyspace": {
 				Description:      "Initial keyspace name. For additional keyspaces, use the astra_keyspace resource.",
@@ -53,10 +100,11 @@ func resourceCDC() *schema.Resource {
 				ValidateFunc: validation.IsUUID,
 			},
 			"database_name": {
-				Description: "Astra database name.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "Astra database name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDatabaseName,
 			},
 			"topic_partitions": {
 				Description: "Number of partitions in cdc topic.",
@@ -65,18 +113,122 @@ func resourceCDC() *schema.Resource {
 				ForceNew:    true,
 			},
 			"tenant_name": {
-				Description: "Streaming tenant name",
+				Description:      "Streaming tenant name",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			// Optional
+			"cluster_name": {
+				Description:      "Pulsar cluster running the CDC connector, to pin it to one of the regions of a multi-region database. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`. Defaults to the cluster of the primary region of the database.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignoreCase,
+			},
+			"deletion_policy": deletionPolicySchema("CDC configuration"),
+			"expected_status": {
+				Description: "Expected status of the CDC connector. A warning is reported when the status read during a refresh is different, for example when the connector failed or was stopped. Defaults to `Running`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Default:     cdcConnectorStatusRunning,
+			},
+			"sink": {
+				Description: "Builtin sink to register on the data topic in the same apply. The sink runs in the namespace of the data topic and is deleted together with the CDC configuration.",
+				Type:        schema.TypeList,
+				Optional:    true,
 				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sink_name": {
+							Description: "Name of the builtin sink, for example `jdbc-clickhouse`.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"sink_configs": {
+							Description:  "Sink configuration as a JSON string.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"parallelism": {
+							Description:  "Parallelism for the Pulsar sink. Defaults to `1`.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"processing_guarantees": {
+							Description:  "One of `ATLEAST_ONCE`, `ATMOST_ONCE` or `EFFECTIVELY_ONCE`. Defaults to `ATLEAST_ONCE`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "ATLEAST_ONCE",
+							ValidateFunc: validation.StringInSlice([]string{"ATLEAST_ONCE", "ATMOST_ONCE", "EFFECTIVELY_ONCE"}, false),
+						},
+						"retain_ordering": {
+							Description: "Retain ordering. Defaults to `true`.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     true,
+						},
+						"auto_ack": {
+							Description: "Auto ack. Defaults to `true`.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     true,
+						},
+					},
+				},
 			},
 			"connector_status": {
 				Description: "Connector Status",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"connector_name": {
+				Description: "Name of the CDC connector created for the table, for example to monitor it with `pulsar-admin`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"connector_instances": {
+				Description: "Number of instances of the CDC connector.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 			"data_topic": {
-				Description: "Data topic name",
+				Description:      "Data topic name. The control plane names and creates the data topic. For organizations which provision topics beforehand, set it to the topic created for the table: it must exist with `topic_partitions` partitions before CDC is enabled, and enabling CDC fails if the control plane uses another topic. Format: `persistent://<tenant>/<namespace>/<topic>`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignorePulsarTopicPrefix,
+			},
+			"data_topic_schema_type": {
+				Description: "Type of the schema registered on the data topic, usually `KEY_VALUE` with an Avro key and value. Empty until the schema is registered, which happens when the first change is published.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"data_topic_schema_version": {
+				Description: "Version of the schema registered on the data topic. It is increased when the table schema changes.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"data_topic_key_schema": {
+				Description: "Avro schema of the message keys of the data topic, which hold the primary key of the table.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"data_topic_value_schema": {
+				Description: "Avro schema of the message values of the data topic, which hold the other columns of the table.",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
@@ -84,13 +236,21 @@ func resourceCDC() *schema.Resource {
 	}
 }
 
+func resourceCDCUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// In-place update not supported. This is only here to support deletion_policy
+	return nil
+}
+
 func resourceCDCDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+	if abandonedOnDelete(resourceData) {
+		// The CDC configuration is kept, only remove the resource from the state
+		resourceData.SetId("")
+		return nil
+	}
+
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
 
-	token := meta.(astraClients).token
-
 	id := resourceData.Id()
 
 	databaseId, keyspace, table, tenantName, err := parseCDCID(id)
@@ -98,27 +258,28 @@ func resourceCDCDelete(ctx context.Context, resourceData *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	orgBody, _ := client.GetCurrentOrganization(ctx)
+	cdcMutex.Lock(tenantName)
+	defer cdcMutex.Unlock(tenantName)
 
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
+	org, err := getCurrentOrg(ctx, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = json.Unmarshal(bodyBuffer, &org)
+	pulsarCluster, pulsarToken, err := prepCDC(ctx, meta, databaseId, resourceData.Get("cluster_name").(string), org, tenantName, false)
 	if err != nil {
-		fmt.Println("Can't deserialize", orgBody)
+		return diag.FromErr(err)
 	}
 
-	pulsarCluster, pulsarToken, err := prepCDC(ctx, client, databaseId, token, org, err, streamingClient, tenantName)
+	// Delete the sink before the data topic it consumes
+	spec, ok, err := expandCDCSink(resourceData, resourceData.Get("data_topic").(string))
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
-
-	deleteCDCParams := astrastreaming.DeleteCDCParams{
-		XDataStaxPulsarCluster: pulsarCluster,
-		Authorization:          pulsarToken,
+	if ok && spec.Namespace != "" {
+		if err := deleteStreamingSink(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenantName, spec.Namespace, spec.SinkName); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	deleteRequestBody := astrastreaming.DeleteCDCJSONRequestBody{
@@ -129,14 +290,8 @@ func resourceCDCDelete(ctx context.Context, resourceData *schema.ResourceData, m
 		TableName:       table,
 		TopicPartitions: resourceData.Get("topic_partitions").(int),
 	}
-	getDeleteCDCResponse, err := streamingClientv3.DeleteCDC(ctx, tenantName, &deleteCDCParams, deleteRequestBody)
-
-	if err != nil {
-		diag.FromErr(err)
-	}
-	if !strings.HasPrefix(getDeleteCDCResponse.Status, "2") {
-		body, _ := ioutil.ReadAll(getDeleteCDCResponse.Body)
-		return diag.Errorf("Error deleting cdc %s", body)
+	if err := deleteTableCDC(ctx, streamingClientv3, resourceData.Timeout(schema.TimeoutDelete), pulsarCluster, pulsarToken, tenantName, deleteRequestBody); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// Deleted. Remove from state.
@@ -146,7 +301,10 @@ func resourceCDCDelete(ctx context.Context, resourceData *schema.ResourceData, m
 
 }
 
-type CDCResult []struct {
+type CDCResult []CDCConfig
+
+// CDCConfig is the CDC configuration of a table
+type CDCConfig struct {
 	OrgID           string    `json:"orgId"`
 	ClusterName     string    `json:"clusterName"`
 	Tenant          string    `json:"tenant"`
@@ -169,78 +327,123 @@ type CDCResult []struct {
 	Memory          int       `json:"memory"`
 }
 
+// cdcResponse is a fully read response of the CDC endpoints of the streaming API. The WithResponses variants of the
+// generated client decode successful responses of these endpoints into the tenant creation response, which fails for
+// the list of CDC configurations, so the responses are read here instead.
+type cdcResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (r *cdcResponse) success() bool {
+	return r.StatusCode >= http.StatusOK && r.StatusCode < http.StatusMultipleChoices
+}
+
+func readCDCResponse(resp *http.Response, err error) (*cdcResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &cdcResponse{StatusCode: resp.StatusCode, Body: body}, nil
+}
+
+// getCDC returns the CDC configurations of the tenant, which are only decoded when the request succeeds
+func getCDC(ctx context.Context, client *astrastreaming.ClientWithResponses, tenantName string, params *astrastreaming.GetCDCParams) (CDCResult, *cdcResponse, error) {
+	resp, err := readCDCResponse(client.GetCDC(ctx, tenantName, params))
+	if err != nil || !resp.success() {
+		return nil, resp, err
+	}
+	var result CDCResult
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, resp, fmt.Errorf("failed to decode CDC configurations of tenant %s: %w", tenantName, err)
+	}
+	return result, resp, nil
+}
+
+func enableCDC(ctx context.Context, client *astrastreaming.ClientWithResponses, tenantName string, params *astrastreaming.EnableCDCParams, body astrastreaming.EnableCDCJSONRequestBody) (*cdcResponse, error) {
+	return readCDCResponse(client.EnableCDC(ctx, tenantName, params, body))
+}
+
+func deleteCDC(ctx context.Context, client *astrastreaming.ClientWithResponses, tenantName string, params *astrastreaming.DeleteCDCParams, body astrastreaming.DeleteCDCJSONRequestBody) (*cdcResponse, error) {
+	return readCDCResponse(client.DeleteCDC(ctx, tenantName, params, body))
+}
+
 func resourceCDCRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
 
-	token := meta.(astraClients).token
-
 	id := resourceData.Id()
 
 	databaseId, keyspace, table, tenantName, err := parseCDCID(id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
-	if err != nil {
+	if err := setCDCIdentity(resourceData, databaseId, keyspace, table, tenantName); err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = json.Unmarshal(bodyBuffer, &org)
+	org, err := getCurrentOrg(ctx, client)
 	if err != nil {
-		fmt.Println("Can't deserialize", orgBody)
+		return diag.FromErr(err)
 	}
 
-	pulsarCluster, pulsarToken, err := prepCDC(ctx, client, databaseId, token, org, err, streamingClient, tenantName)
+	pulsarCluster, pulsarToken, err := prepCDC(ctx, meta, databaseId, resourceData.Get("cluster_name").(string), org, tenantName, false)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
 	getCDCParams := astrastreaming.GetCDCParams{
 		XDataStaxPulsarCluster: pulsarCluster,
 		Authorization:          pulsarToken,
 	}
-	getCDCResponse, err := streamingClientv3.GetCDC(ctx, tenantName, &getCDCParams)
+	cdcResult, resp, err := getCDC(ctx, streamingClientv3, tenantName, &getCDCParams)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
-	if !strings.HasPrefix(getCDCResponse.Status, "2") {
-		body, _ := ioutil.ReadAll(getCDCResponse.Body)
-		return diag.Errorf("Error getting cdc config %s", body)
+	if !resp.success() {
+		return diag.Errorf("Error getting cdc config %s", resp.Body)
 	}
 
-	body, _ := ioutil.ReadAll(getCDCResponse.Body)
-
-	var cdcResult CDCResult
-	err = json.Unmarshal(body, &cdcResult)
-	if err != nil {
-		fmt.Println("Can't deserialize", body)
+	cdc, ok := cdcResult.find(databaseId, keyspace, table)
+	if !ok {
+		return readError(ctx, resourceData, notFoundError(fmt.Sprintf("CDC configuration of table %s.%s", keyspace, table)))
+	}
+	if err := setCDCConfig(ctx, resourceData, streamingClientv3, cdc, pulsarCluster, pulsarToken); err != nil {
+		return diag.FromErr(err)
 	}
+	return cdcConnectorStatusDiagnostics(resourceData, table, cdc.ConnectorStatus)
+}
 
-	for i := 0; i < len(cdcResult); i++ {
-		if cdcResult[i].Keyspace == keyspace {
-			if cdcResult[i].DatabaseTable == table {
-				return nil
-			}
+// find returns the CDC configuration of a table among the CDC configurations of the tenant, which can hold the tables
+// of several keyspaces and databases
+func (r CDCResult) find(databaseID, keyspace, table string) (CDCConfig, bool) {
+	for _, cdc := range r {
+		if strings.EqualFold(cdc.DatabaseID, databaseID) && cdc.Keyspace == keyspace && cdc.DatabaseTable == table {
+			return cdc, true
 		}
 	}
+	return CDCConfig{}, false
+}
 
-	if err := resourceData.Set("connector_status", cdcResult[0].ConnectorStatus); err != nil {
-		return diag.FromErr(err)
+// setCDCConfig sets the attributes read from the CDC configuration of the table
+func setCDCConfig(ctx context.Context, d *schema.ResourceData, streamingClientv3 *astrastreaming.ClientWithResponses, cdc CDCConfig, pulsarCluster, pulsarToken string) error {
+	if err := d.Set("connector_status", cdc.ConnectorStatus); err != nil {
+		return err
 	}
-	if err := resourceData.Set("data_topic", cdcResult[0].DataTopic); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("connector_name", cdc.ConnectorName); err != nil {
+		return err
 	}
-
-	// Not found. Remove from state.
-	resourceData.SetId("")
-
-	return nil
+	if err := setCDCConnectorLocation(d, cdc.ClusterName, pulsarCluster, cdc.Instances); err != nil {
+		return err
+	}
+	if err := d.Set("data_topic", cdc.DataTopic); err != nil {
+		return err
+	}
+	return setCDCDataTopicSchema(ctx, d, streamingClientv3, cdc.DataTopic, pulsarCluster, pulsarToken)
 }
 
 type ServerlessStreamingAvailableRegionsResult []struct {
@@ -251,21 +454,21 @@ type ServerlessStreamingAvailableRegionsResult []struct {
 	RegionDisplay   string `json:"regionDisplay"`
 	RegionContinent string `json:"regionContinent"`
 	Cost            struct {
-		CostPerMinCents         int `json:"costPerMinCents"`
-		CostPerHourCents        int `json:"costPerHourCents"`
-		CostPerDayCents         int `json:"costPerDayCents"`
-		CostPerMonthCents       int `json:"costPerMonthCents"`
-		CostPerMinMRCents       int `json:"costPerMinMRCents"`
-		CostPerHourMRCents      int `json:"costPerHourMRCents"`
-		CostPerDayMRCents       int `json:"costPerDayMRCents"`
-		CostPerMonthMRCents     int `json:"costPerMonthMRCents"`
-		CostPerMinParkedCents   int `json:"costPerMinParkedCents"`
-		CostPerHourParkedCents  int `json:"costPerHourParkedCents"`
-		CostPerDayParkedCents   int `json:"costPerDayParkedCents"`
-		CostPerMonthParkedCents int `json:"costPerMonthParkedCents"`
-		CostPerNetworkGbCents   int `json:"costPerNetworkGbCents"`
-		CostPerWrittenGbCents   int `json:"costPerWrittenGbCents"`
-		CostPerReadGbCents      int `json:"costPerReadGbCents"`
+		CostPerMinCents         float64 `json:"costPerMinCents"`
+		CostPerHourCents        float64 `json:"costPerHourCents"`
+		CostPerDayCents         float64 `json:"costPerDayCents"`
+		CostPerMonthCents       float64 `json:"costPerMonthCents"`
+		CostPerMinMRCents       float64 `json:"costPerMinMRCents"`
+		CostPerHourMRCents      float64 `json:"costPerHourMRCents"`
+		CostPerDayMRCents       float64 `json:"costPerDayMRCents"`
+		CostPerMonthMRCents     float64 `json:"costPerMonthMRCents"`
+		CostPerMinParkedCents   float64 `json:"costPerMinParkedCents"`
+		CostPerHourParkedCents  float64 `json:"costPerHourParkedCents"`
+		CostPerDayParkedCents   float64 `json:"costPerDayParkedCents"`
+		CostPerMonthParkedCents float64 `json:"costPerMonthParkedCents"`
+		CostPerNetworkGbCents   float64 `json:"costPerNetworkGbCents"`
+		CostPerWrittenGbCents   float64 `json:"costPerWrittenGbCents"`
+		CostPerReadGbCents      float64 `json:"costPerReadGbCents"`
 	} `json:"cost"`
 	DatabaseCountUsed               int `json:"databaseCountUsed"`
 	DatabaseCountLimit              int `json:"databaseCountLimit"`
@@ -283,11 +486,8 @@ type StreamingTokens []struct {
 
 func resourceCDCCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
-	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
 	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
 
-	token := meta.(astraClients).token
-
 	table := resourceData.Get("table").(string)
 	keyspace := resourceData.Get("keyspace").(string)
 	databaseId := resourceData.Get("database_id").(string)
@@ -295,17 +495,29 @@ func resourceCDCCreate(ctx context.Context, resourceData *schema.ResourceData, m
 	topicPartitions := resourceData.Get("topic_partitions").(int)
 	tenantName := resourceData.Get("tenant_name").(string)
 
-	orgBody, _ := client.GetCurrentOrganization(ctx)
+	// The streaming API only reports a missing table after several failed attempts, with a confusing error
+	if err := checkCDCTable(ctx, meta, resourceData.Timeout(schema.TimeoutCreate), databaseId, keyspace, table); err != nil {
+		return diag.FromErr(err)
+	}
+
+	cdcMutex.Lock(tenantName)
+	defer cdcMutex.Unlock(tenantName)
 
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
+	org, err := getCurrentOrg(ctx, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = json.Unmarshal(bodyBuffer, &org)
-	if err != nil {
-		fmt.Println("Can't deserialize", orgBody)
+	// A pre-created data topic must match the topic partitions, the control plane does not change existing topics
+	dataTopic := resourceData.Get("data_topic").(string)
+	if dataTopic != "" {
+		pulsarCluster, pulsarToken, err := prepCDC(ctx, meta, databaseId, resourceData.Get("cluster_name").(string), org, tenantName, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := checkPulsarTopicPartitions(ctx, streamingClientv3, pulsarCluster, pulsarToken, dataTopic, topicPartitions); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	cdcRequestJSON := astrastreaming.EnableCDCJSONRequestBody{
@@ -317,114 +529,402 @@ func resourceCDCCreate(ctx context.Context, resourceData *schema.ResourceData, m
 		TopicPartitions: topicPartitions,
 	}
 
-	pulsarCluster, pulsarToken, err := prepCDC(ctx, client, databaseId, token, org, err, streamingClient, tenantName)
+	pulsarCluster, pulsarToken, err := enableTableCDC(ctx, meta, resourceData.Timeout(schema.TimeoutCreate), org, resourceData.Get("cluster_name").(string), tenantName, cdcRequestJSON)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	enableCDCParams := astrastreaming.EnableCDCParams{
+	getCDCParams := astrastreaming.GetCDCParams{
 		XDataStaxPulsarCluster: pulsarCluster,
 		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
 	}
 
-	var enableClientResult *http.Response
-	retryCount := 0
-	for enableClientResult == nil || strings.HasPrefix(enableClientResult.Status, "401") {
+	// Wait for the CDC configuration of the table to be visible, the tenant can have other CDC configurations
+	var cdc CDCConfig
+	if err := retry.RetryContext(ctx, resourceData.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		result, resp, err := getCDC(ctx, streamingClientv3, tenantName, &getCDCParams)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return retry.RetryableError(fmt.Errorf("error fetching CDC configuration: %s", string(resp.Body)))
+		}
+		if !resp.success() {
+			return retry.NonRetryableError(fmt.Errorf("error fetching CDC configuration: %s", string(resp.Body)))
+		}
+		found, ok := result.find(databaseId, keyspace, table)
+		if !ok {
+			return retry.RetryableError(fmt.Errorf("CDC configuration for table %s is not available yet", table))
+		}
+		cdc = found
+		return nil
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setCDCConfig(ctx, resourceData, streamingClientv3, cdc, pulsarCluster, pulsarToken); err != nil {
+		return diag.FromErr(err)
+	}
+
+	setCDCData(resourceData, resourceid.CDC.Format(databaseId, keyspace, table, tenantName))
+	if err := setCDCIdentity(resourceData, databaseId, keyspace, table, tenantName); err != nil {
+		return diag.FromErr(err)
+	}
 
-		enableClientResult, err = streamingClientv3.EnableCDC(ctx, tenantName, &enableCDCParams, cdcRequestJSON)
+	// The ID is set first, so that a CDC configuration with another data topic is replaced on the next apply
+	if err := checkCDCDataTopic(cdc, dataTopic); err != nil {
+		return diag.FromErr(err)
+	}
 
-		if err != nil {
+	// Step 3: create sink https://pulsar.apache.org/sink-rest-api/?version=2.8.0&apiversion=v3#operation/registerSink
+	spec, ok, err := expandCDCSink(resourceData, cdc.DataTopic)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ok {
+		if spec.Namespace == "" {
+			return diag.Errorf("could not determine the namespace of data topic %s", cdc.DataTopic)
+		}
+		if err := createStreamingSink(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenantName, spec); err != nil {
 			return diag.FromErr(err)
 		}
+	}
 
-		if strings.HasPrefix(enableClientResult.Status, "2") {
-			bodyBuffer, err = ioutil.ReadAll(enableClientResult.Body)
-			break
+	return nil
+}
+
+// setCDCConnectorLocation sets the Pulsar cluster running the CDC connector, the one reported by the streaming API or
+// else the one of the requests, and its number of instances
+func setCDCConnectorLocation(d *schema.ResourceData, clusterName, pulsarCluster string, instances int) error {
+	if clusterName == "" {
+		clusterName = pulsarCluster
+	}
+	if err := d.Set("cluster_name", strings.ToLower(clusterName)); err != nil {
+		return err
+	}
+	return d.Set("connector_instances", instances)
+}
+
+// checkPulsarTopicPartitions returns an error unless the partitioned topic exists with the given number of partitions
+func checkPulsarTopicPartitions(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, pulsarCluster, pulsarToken, topicName string, partitions int) error {
+	actual, err := getPulsarTopicPartitions(ctx, streamingClientv3, pulsarCluster, pulsarToken, topicName)
+	if err != nil {
+		return err
+	}
+	return checkTopicPartitionCount(topicName, actual, partitions)
+}
+
+// getPulsarTopicPartitions returns the number of partitions of the topic, 0 for a missing or non-partitioned topic
+func getPulsarTopicPartitions(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, pulsarCluster, pulsarToken, topicName string) (int, error) {
+	tenant, namespace, topic, err := parsePulsarTopicName(topicName)
+	if err != nil {
+		return 0, err
+	}
+	path := fmt.Sprintf("admin/v2/persistent/%s/%s/%s/partitions", tenant, namespace, topic)
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, path, pulsarCluster, pulsarToken)
+	if err != nil {
+		return 0, err
+	}
+	if statusCode == http.StatusNotFound {
+		return 0, nil
+	}
+	if statusCode != http.StatusOK {
+		return 0, fmt.Errorf("error fetching partitions of topic %s: %s", topicName, string(body))
+	}
+	var metadata struct {
+		Partitions int `json:"partitions"`
+	}
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return 0, fmt.Errorf("failed to decode partitions of topic %s: %w", topicName, err)
+	}
+	return metadata.Partitions, nil
+}
+
+// checkTopicPartitionCount compares the partitions of a topic, 0 for a missing or non-partitioned topic, to the
+// expected number of partitions
+func checkTopicPartitionCount(topicName string, partitions, expected int) error {
+	switch {
+	case partitions == 0:
+		return fmt.Errorf("data topic %s does not exist or is not partitioned, it must have %d partitions", topicName, expected)
+	case partitions != expected:
+		return fmt.Errorf("data topic %s has %d partitions, topic_partitions is %d", topicName, partitions, expected)
+	}
+	return nil
+}
+
+// checkCDCDataTopic returns an error when the CDC configuration of the table does not use the configured data topic
+func checkCDCDataTopic(cdc CDCConfig, dataTopic string) error {
+	if dataTopic != "" && !pulsarTopicsEqual(dataTopic, cdc.DataTopic) {
+		return fmt.Errorf("CDC for table %s uses data topic %s instead of %s", cdc.DatabaseTable, cdc.DataTopic, dataTopic)
+	}
+	return nil
+}
+
+// pulsarTopicsEqual returns whether the topic names are the same persistent topic, with or without the persistent://
+// prefix
+func pulsarTopicsEqual(a, b string) bool {
+	return strings.TrimPrefix(a, "persistent://") == strings.TrimPrefix(b, "persistent://")
+}
+
+// ignorePulsarTopicPrefix ignores the persistent:// prefix of topic name attributes
+func ignorePulsarTopicPrefix(_, old, new string, _ *schema.ResourceData) bool {
+	return pulsarTopicsEqual(old, new)
+}
+
+// cdcConnectorStatusDiagnostics returns a warning when the connector status is not the expected_status of the resource,
+// so that a failed or stopped connector is noticed on refresh without failing the plan
+func cdcConnectorStatusDiagnostics(d *schema.ResourceData, table, status string) diag.Diagnostics {
+	expectedStatus := d.Get("expected_status").(string)
+	if expectedStatus == "" || strings.EqualFold(status, expectedStatus) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("CDC connector of table %s is not %s", table, expectedStatus),
+		Detail:   fmt.Sprintf("The status of the CDC connector is %q, expected %q. Changes of the table may not be published to the data topic.", status, expectedStatus),
+	}}
+}
+
+// enableTableCDC enables CDC for a table in the Pulsar cluster, the one of the primary region of the database when it is
+// empty, and returns the Pulsar cluster and the tenant token which was accepted. Enabling CDC fails with a 401 until the tenant token can be used with the database, so it is retried
+// with a fresh token.
+func enableTableCDC(ctx context.Context, meta interface{}, timeout time.Duration, org OrgId, pulsarCluster, tenantName string, body astrastreaming.EnableCDCJSONRequestBody) (string, string, error) {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	pulsarCluster, pulsarToken, err := prepCDC(ctx, meta, body.DatabaseId, pulsarCluster, org, tenantName, false)
+	if err != nil {
+		return "", "", err
+	}
+
+	enableCDCParams := astrastreaming.EnableCDCParams{
+		XDataStaxPulsarCluster: pulsarCluster,
+		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
+	}
+	err = retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		resp, err := enableCDC(ctx, streamingClientv3, tenantName, &enableCDCParams, body)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if resp.success() {
+			return nil
 		}
-		if retryCount > 0 {
-			fmt.Printf("failed to set up cdc with token %s for table %s", pulsarToken, table)
-			time.Sleep(20 * time.Second)
+		// A 401 is also returned until the tenant token can be used with the database, so only 403 is reported
+		if resp.StatusCode == http.StatusForbidden {
+			return retry.NonRetryableError(permissionError(opEnableCDC, resp.StatusCode, resp.Body))
 		}
-		if retryCount > 6 {
-			return diag.Errorf("Could not enable CDC with token: %s", bodyBuffer)
+		if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode < http.StatusInternalServerError {
+			return retry.NonRetryableError(fmt.Errorf("error enabling CDC for table %s: %s", body.TableName, string(resp.Body)))
 		}
-		retryCount = retryCount + 1
 
-		pulsarCluster, pulsarToken, err = prepCDC(ctx, client, databaseId, token, org, err, streamingClient, tenantName)
+		pulsarCluster, pulsarToken, err = prepCDC(ctx, meta, body.DatabaseId, pulsarCluster, org, tenantName, true)
 		if err != nil {
-			return diag.FromErr(err)
+			return retry.NonRetryableError(err)
 		}
-
 		enableCDCParams = astrastreaming.EnableCDCParams{
 			XDataStaxPulsarCluster: pulsarCluster,
 			Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
 		}
+		return retry.RetryableError(fmt.Errorf("could not enable CDC for table %s: %s", body.TableName, string(resp.Body)))
+	})
+	return pulsarCluster, pulsarToken, err
+}
 
-	}
-
-	getCDCParams := astrastreaming.GetCDCParams{
+// deleteTableCDC deletes the CDC configuration of a table, retrying on server errors
+func deleteTableCDC(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, timeout time.Duration, pulsarCluster, pulsarToken, tenantName string, body astrastreaming.DeleteCDCJSONRequestBody) error {
+	deleteCDCParams := astrastreaming.DeleteCDCParams{
 		XDataStaxPulsarCluster: pulsarCluster,
-		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
+		Authorization:          pulsarToken,
 	}
-
-	var cdcResult CDCResult
-	retryCount = 0
-	for cdcResult == nil || len(cdcResult) <= 0 {
-		getCDCResponse, err := streamingClientv3.GetCDC(ctx, tenantName, &getCDCParams)
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		resp, err := deleteCDC(ctx, streamingClientv3, tenantName, &deleteCDCParams, body)
 		if err != nil {
-			return diag.FromErr(err)
+			return retry.NonRetryableError(err)
 		}
-
-		if !strings.HasPrefix(getCDCResponse.Status, "2") {
-			bodyBuffer, err = ioutil.ReadAll(getCDCResponse.Body)
-			return diag.Errorf("Error enabling client %s", string(bodyBuffer))
+		if resp.success() {
+			return nil
 		}
-		bodyBuffer, err = ioutil.ReadAll(getCDCResponse.Body)
-		json.Unmarshal(bodyBuffer, &cdcResult)
-
-		if retryCount > 0 {
-			fmt.Printf("failed to set up cdc with token %s for table %s", pulsarToken, table)
-			time.Sleep(20 * time.Second)
+		if err := permissionError(opDeleteCDC, resp.StatusCode, resp.Body); err != nil {
+			return retry.NonRetryableError(err)
 		}
-		if retryCount > 6 {
-			return diag.Errorf("Could not enable CDC with token: %s", bodyBuffer)
+		// Status code >=5xx are assumed to be transient
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return retry.RetryableError(fmt.Errorf("error deleting cdc %s", resp.Body))
 		}
+		return retry.NonRetryableError(fmt.Errorf("Error deleting cdc %s", resp.Body))
+	})
+}
+
+// checkCDCTable returns an error when the keyspace or the table to enable CDC for does not exist. Unexpected responses
+// of the schema API are only logged, since the streaming API reports its own errors when CDC is enabled.
+func checkCDCTable(ctx context.Context, meta interface{}, timeout time.Duration, databaseID, keyspace, table string) error {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	found, err := getKeyspace(ctx, client, timeout, databaseID, keyspace)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("keyspace %s does not exist in database %s", keyspace, databaseID)
 	}
 
-	if err := resourceData.Set("connector_status", cdcResult[0].ConnectorStatus); err != nil {
-		return diag.FromErr(err)
+	dbResp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return err
+	} else if dbResp.JSON200 == nil {
+		return fmt.Errorf("error fetching database %s: %s", databaseID, string(dbResp.Body))
 	}
-	if err := resourceData.Set("data_topic", cdcResult[0].DataTopic); err != nil {
-		return diag.FromErr(err)
+	restClient, err := getRestClient(meta, databaseID, astra.StringValue(dbResp.JSON200.Info.Region))
+	if err != nil {
+		return err
 	}
 
-	setCDCData(resourceData, fmt.Sprintf("%s/%s/%s/%s", databaseId, keyspace, table, tenantName))
+	raw := true
+	resp, err := restClient.GetTableWithResponse(ctx, keyspace, table, &astrarestapi.GetTableParams{
+		Raw:             &raw,
+		XCassandraToken: meta.(astraClients).token,
+	})
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("could not check that table %s.%s exists: %v", keyspace, table, err))
+		return nil
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("table %s does not exist in keyspace %s of database %s", table, keyspace, databaseID)
+	default:
+		tflog.Warn(ctx, fmt.Sprintf("could not check that table %s.%s exists: %s", keyspace, table, string(resp.Body)))
+		return nil
+	}
+}
 
-	// Step 3: create sink https://pulsar.apache.org/sink-rest-api/?version=2.8.0&apiversion=v3#operation/registerSink
+// expandCDCSink returns the sink to register on the data topic, if one is configured
+func expandCDCSink(d *schema.ResourceData, dataTopic string) (streamingSinkSpec, bool, error) {
+	sinks := d.Get("sink").([]interface{})
+	if len(sinks) == 0 || sinks[0] == nil {
+		return streamingSinkSpec{}, false, nil
+	}
+	sink := sinks[0].(map[string]interface{})
 
-	return nil
+	var configs map[string]interface{}
+	if err := json.Unmarshal([]byte(sink["sink_configs"].(string)), &configs); err != nil {
+		return streamingSinkSpec{}, false, fmt.Errorf("sink_configs must be a JSON object: %w", err)
+	}
+
+	// The data topic is fully qualified, the sink runs in its namespace
+	_, namespace, _, _ := parsePulsarTopicName(dataTopic)
+
+	return streamingSinkSpec{
+		Namespace:            namespace,
+		SinkName:             sink["sink_name"].(string),
+		Topic:                dataTopic,
+		Configs:              configs,
+		Parallelism:          int32(sink["parallelism"].(int)),
+		ProcessingGuarantees: sink["processing_guarantees"].(string),
+		RetainOrdering:       sink["retain_ordering"].(bool),
+		AutoAck:              sink["auto_ack"].(bool),
+	}, true, nil
 }
 
-func prepCDC(ctx context.Context, client *astra.ClientWithResponses, databaseId string, token string, org OrgId, err error, streamingClient *astrastreaming.ClientWithResponses, tenantName string) (string, string, error) {
+// prepCDC returns the Pulsar cluster of the database and a Pulsar token of the tenant. The Pulsar cluster is the one of
+// the primary region of the database unless pulsarCluster is set. The token is shared by all CDC resources of the
+// tenant, refresh replaces it after it was rejected.
+func prepCDC(ctx context.Context, meta interface{}, databaseId, pulsarCluster string, org OrgId, tenantName string, refresh bool) (string, string, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+	token := meta.(astraClients).token
+
 	databaseResourceData := schema.ResourceData{}
 	db, err := getDatabase(ctx, &databaseResourceData, client, databaseId)
 	if err != nil {
 		return "", "", err
 	}
 
+	pulsarCluster, _, err = cdcPulsarCluster(db, pulsarCluster)
+	if err != nil {
+		return "", "", err
+	}
+	pulsarToken, err := meta.(astraClients).pulsarTokens.get(pulsarCluster, tenantName, refresh, func() (string, error) {
+		return getPulsarToken(ctx, pulsarCluster, token, org, nil, streamingClient, tenantName)
+	})
+	return pulsarCluster, pulsarToken, err
+}
+
+// cdcPulsarCluster returns the Pulsar cluster running the CDC connector of the database and the region of the database
+// it is in. The cluster defaults to the one of the primary region, a pinned cluster must be in a region of the database.
+func cdcPulsarCluster(db *astra.Database, pulsarCluster string) (string, string, error) {
 	// In most astra APIs there are dashes in region names depending on the cloud provider, this seems not to be the case for streaming
 	cloudProvider := string(*db.Info.CloudProvider)
-	fmt.Printf("%s", cloudProvider)
+	if pulsarCluster == "" {
+		return GetPulsarCluster(cloudProvider, *db.Info.Region), *db.Info.Region, nil
+	}
 
-	pulsarCluster := GetPulsarCluster(cloudProvider, *db.Info.Region)
-	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenantName)
-	return pulsarCluster, pulsarToken, err
+	regions := []string{*db.Info.Region}
+	if db.Info.Datacenters != nil {
+		for _, dc := range *db.Info.Datacenters {
+			regions = append(regions, dc.Region)
+		}
+	}
+	available := make([]string, 0, len(regions))
+	seen := map[string]bool{}
+	for _, region := range regions {
+		cluster := GetPulsarCluster(cloudProvider, region)
+		if strings.EqualFold(cluster, pulsarCluster) {
+			return cluster, region, nil
+		}
+		if !seen[cluster] {
+			seen[cluster] = true
+			available = append(available, cluster)
+		}
+	}
+	return "", "", fmt.Errorf("Pulsar cluster %s is not in a region of database %s, available clusters: %s", pulsarCluster, db.Id, strings.Join(available, ", "))
+}
+
+// pulsarTokenCache caches the Pulsar tokens of streaming tenants, keyed by Pulsar cluster and tenant, so resources of
+// the same tenant share one token per provider instance
+type pulsarTokenCache struct {
+	lock   sync.Mutex
+	tokens map[string]string
+	// fetches serializes the fetches of the token of each tenant, without blocking the other tenants
+	fetches *mutexKV
+}
+
+func newPulsarTokenCache() *pulsarTokenCache {
+	return &pulsarTokenCache{
+		tokens:  make(map[string]string),
+		fetches: newMutexKV(),
+	}
+}
+
+// get returns the cached token of the tenant, or fetches it when it is not cached or refresh is set
+func (c *pulsarTokenCache) get(pulsarCluster, tenantName string, refresh bool, fetch func() (string, error)) (string, error) {
+	key := pulsarCluster + "/" + tenantName
+	c.fetches.Lock(key)
+	defer c.fetches.Unlock(key)
+
+	if pulsarToken, ok := c.cached(key); ok && !refresh {
+		return pulsarToken, nil
+	}
+	pulsarToken, err := fetch()
+	if err != nil {
+		return "", err
+	}
+	c.lock.Lock()
+	c.tokens[key] = pulsarToken
+	c.lock.Unlock()
+	return pulsarToken, nil
+}
+
+func (c *pulsarTokenCache) cached(key string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pulsarToken, ok := c.tokens[key]
+	return pulsarToken, ok
 }
 
 func GetPulsarCluster(cloudProvider string, rawRegion string) string {
 	// In most astra APIs there are dashes in region names depending on the cloud provider, this seems not to be the case for streaming
-	region := strings.ReplaceAll(rawRegion, "-", "")
-	return strings.ToLower(fmt.Sprintf("pulsar-%s-%s", cloudProvider, region))
+	return strings.ToLower(fmt.Sprintf("pulsar-%s-%s", cloudProvider, normalizeRegion(rawRegion)))
 }
 
 func getPulsarToken(ctx context.Context, pulsarCluster string, token string, org OrgId, err error, streamingClient *astrastreaming.ClientWithResponses, tenantName string) (string, error) {
@@ -467,16 +967,158 @@ func getPulsarToken(ctx context.Context, pulsarCluster string, token string, org
 	return pulsarToken, err
 }
 
+// getStreamingTenantPulsarToken returns the Pulsar cluster of the tenant and a Pulsar token of the tenant
+func getStreamingTenantPulsarToken(ctx context.Context, meta interface{}, cloudProvider, region, tenantName string) (string, string, error) {
+	pulsarCluster := GetPulsarCluster(cloudProvider, region)
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenantName)
+	if err != nil {
+		return "", "", err
+	}
+	return pulsarCluster, pulsarToken, nil
+}
+
+// getClusterPulsarToken returns a Pulsar token of the tenant in the Pulsar cluster and the current organization
+func getClusterPulsarToken(ctx context.Context, meta interface{}, pulsarCluster, tenantName string) (string, OrgId, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return "", OrgId{}, err
+	}
+
+	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, meta.(astraClients).token, org, err, streamingClient, tenantName)
+	if err != nil {
+		return "", org, err
+	}
+	return pulsarToken, org, nil
+}
+
 func setCDCData(d *schema.ResourceData, id string) error {
 	d.SetId(id)
 
 	return nil
 }
 
+func setCDCIdentity(d *schema.ResourceData, databaseID, keyspace, table, tenantName string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	if err := identity.Set("database_id", databaseID); err != nil {
+		return err
+	}
+	if err := identity.Set("keyspace", keyspace); err != nil {
+		return err
+	}
+	if err := identity.Set("table", table); err != nil {
+		return err
+	}
+	return identity.Set("tenant_name", tenantName)
+}
+
+// resourceCDCImport imports a CDC configuration by its ID, databaseId/keyspace/table/tenantName, or by its identity
+func resourceCDCImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != "" {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]string, 0, 4)
+	for _, k := range resourceid.CDC.Fields() {
+		v := identity.Get(k).(string)
+		if v == "" {
+			return nil, fmt.Errorf("expected identity to contain %s", k)
+		}
+		parts = append(parts, v)
+	}
+	d.SetId(resourceid.CDC.Format(parts...))
+	return []*schema.ResourceData{d}, nil
+}
+
 func parseCDCID(id string) (string, string, string, string, error) {
-	idParts := strings.Split(strings.ToLower(id), "/")
-	if len(idParts) != 4 {
-		return "", "", "", "", errors.New("invalid role id format: expected databaseId/keyspace/table/tenantName")
+	parts, err := resourceid.CDC.Parse(id)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
+
+// pulsarSchemaInfo is a schema of the Pulsar schema registry
+type pulsarSchemaInfo struct {
+	Version    int               `json:"version"`
+	Type       string            `json:"type"`
+	Data       string            `json:"data"`
+	Properties map[string]string `json:"properties"`
+}
+
+// getPulsarSchema returns the version of the schema registered on the topic, the latest one when the version is nil,
+// and whether it exists
+func getPulsarSchema(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, topicName, pulsarCluster, pulsarToken string, version *int) (pulsarSchemaInfo, bool, error) {
+	var schemaInfo pulsarSchemaInfo
+	tenant, namespace, topic, err := parsePulsarTopicName(topicName)
+	if err != nil {
+		return schemaInfo, false, err
+	}
+	path := fmt.Sprintf("admin/v2/schemas/%s/%s/%s/schema", tenant, namespace, topic)
+	if version != nil {
+		path = fmt.Sprintf("%s/%d", path, *version)
+	}
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, path, pulsarCluster, pulsarToken)
+	if err != nil {
+		return schemaInfo, false, err
+	}
+	switch {
+	case statusCode == http.StatusNotFound:
+		return schemaInfo, false, nil
+	case statusCode != http.StatusOK:
+		return schemaInfo, false, fmt.Errorf("error fetching schema of topic %s: %s", topicName, string(body))
+	}
+	if err := json.Unmarshal(body, &schemaInfo); err != nil {
+		return schemaInfo, false, fmt.Errorf("failed to decode schema of topic %s: %w", topicName, err)
+	}
+	return schemaInfo, true, nil
+}
+
+// setCDCDataTopicSchema sets the schema registered on the data topic. The schema is only registered once the first
+// change is published, so the attributes are empty until then.
+func setCDCDataTopicSchema(ctx context.Context, d *schema.ResourceData, streamingClientv3 *astrastreaming.ClientWithResponses, dataTopic, pulsarCluster, pulsarToken string) error {
+	schemaInfo, _, err := getPulsarSchema(ctx, streamingClientv3, dataTopic, pulsarCluster, pulsarToken, nil)
+	if err != nil {
+		return err
+	}
+
+	keySchema, valueSchema, err := splitPulsarSchema(schemaInfo)
+	if err != nil {
+		return fmt.Errorf("failed to decode schema of data topic %s: %w", dataTopic, err)
+	}
+	if err := d.Set("data_topic_schema_type", schemaInfo.Type); err != nil {
+		return err
+	}
+	if err := d.Set("data_topic_schema_version", schemaInfo.Version); err != nil {
+		return err
+	}
+	if err := d.Set("data_topic_key_schema", keySchema); err != nil {
+		return err
+	}
+	return d.Set("data_topic_value_schema", valueSchema)
+}
+
+// splitPulsarSchema returns the key and value schemas of a KEY_VALUE schema, whose data holds both, or the schema as
+// value schema for other types
+func splitPulsarSchema(schemaInfo pulsarSchemaInfo) (string, string, error) {
+	if schemaInfo.Type != "KEY_VALUE" {
+		return "", schemaInfo.Data, nil
+	}
+	var keyValue struct {
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(schemaInfo.Data), &keyValue); err != nil {
+		return "", "", err
 	}
-	return idParts[0], idParts[1], idParts[2], idParts[3], nil
+	return string(keyValue.Key), string(keyValue.Value), nil
 }
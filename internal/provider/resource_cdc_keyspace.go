@@ -0,0 +1,597 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCDCKeyspace() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_cdc_keyspace` enables CDC for every table of a keyspace of an Astra Serverless database. The tables of the keyspace are listed on each refresh, and CDC is enabled for the tables added since the last apply. Tables which already have CDC enabled, for example with `astra_cdc`, are left untouched.",
+		CreateContext: resourceCDCKeyspaceCreate,
+		ReadContext:   resourceCDCKeyspaceRead,
+		UpdateContext: resourceCDCKeyspaceUpdate,
+		DeleteContext: resourceCDCKeyspaceDelete,
+		CustomizeDiff: resourceCDCKeyspaceCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCDCKeyspaceImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: &cdcCreateTimeout,
+			Update: &cdcCreateTimeout,
+			Delete: &cdcDeleteTimeout,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"keyspace": {
+				Description:      "Keyspace of the tables to enable CDC for. The keyspace must exist before CDC is enabled.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			"database_id": {
+				Description:  "Astra database of the keyspace.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"database_name": {
+				Description:      "Astra database name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDatabaseName,
+			},
+			"topic_partitions": {
+				Description: "Number of partitions in the cdc topic of each table.",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"tenant_name": {
+				Description:      "Streaming tenant name",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			// Optional
+			"exclude_tables": {
+				Description: "Tables of the keyspace to leave out. CDC is disabled for the tables enabled by this resource which are added to this list.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateCQLIdentifier,
+				},
+			},
+			"deletion_policy": deletionPolicySchema("CDC configuration of each table"),
+			// Computed
+			"tables": {
+				Description: "Tables of the keyspace with CDC enabled by this resource.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"available_tables": {
+				Description: "Tables of the keyspace listed by the last refresh which this resource can enable CDC for, the ones without CDC and the ones it enabled CDC for.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connector_names": {
+				Description: "Name of the CDC connector of each table with CDC enabled, by table name.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"data_topics": {
+				Description: "Data topic of each table with CDC enabled, by table name.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceCDCKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	keyspace := d.Get("keyspace").(string)
+	tenantName := d.Get("tenant_name").(string)
+
+	found, err := getKeyspace(ctx, client, d.Timeout(schema.TimeoutCreate), databaseID, keyspace)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if !found {
+		return diag.Errorf("keyspace %s does not exist in database %s", keyspace, databaseID)
+	}
+	tables, err := listKeyspaceTables(ctx, meta, databaseID, keyspace)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cdcMutex.Lock(tenantName)
+	defer cdcMutex.Unlock(tenantName)
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The tables which already have CDC, for example with astra_cdc, are left to their resource
+	configs, _, _, err := getKeyspaceCDC(ctx, meta, org, databaseID, keyspace, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	available := availableCDCKeyspaceTables(tables, configs, nil)
+
+	// The ID is set before CDC is enabled, so that the tables enabled before a failure are tracked in the state
+	d.SetId(resourceid.CDCKeyspace.Format(databaseID, keyspace, tenantName))
+	if err := d.Set("available_tables", available); err != nil {
+		return diag.FromErr(err)
+	}
+	enabled := []string{}
+	for _, table := range cdcKeyspaceTables(available, expandStrings(d.Get("exclude_tables").(*schema.Set).List())) {
+		if _, _, err := enableTableCDC(ctx, meta, d.Timeout(schema.TimeoutCreate), org, "", tenantName, astrastreaming.EnableCDCJSONRequestBody{
+			DatabaseId:      databaseID,
+			DatabaseName:    d.Get("database_name").(string),
+			Keyspace:        keyspace,
+			OrgId:           org.ID,
+			TableName:       table,
+			TopicPartitions: d.Get("topic_partitions").(int),
+		}); err != nil {
+			d.Set("tables", enabled)
+			return diag.FromErr(err)
+		}
+		enabled = append(enabled, table)
+	}
+
+	if err := d.Set("tables", enabled); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceCDCKeyspaceReadLocked(ctx, d, meta, org)
+}
+
+func resourceCDCKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceCDCKeyspaceReadLocked(ctx, d, meta, org)
+}
+
+// resourceCDCKeyspaceReadLocked keeps the tables enabled by the resource which still have a CDC configuration, and lists
+// the tables of the keyspace so that the next plan enables CDC for the tables added since the last apply
+func resourceCDCKeyspaceReadLocked(ctx context.Context, d *schema.ResourceData, meta interface{}, org OrgId) diag.Diagnostics {
+	databaseID, keyspace, tenantName, err := parseCDCKeyspaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configs, _, _, err := getKeyspaceCDC(ctx, meta, org, databaseID, keyspace, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tables := []string{}
+	dataTopics := map[string]string{}
+	connectorNames := map[string]string{}
+	for _, table := range expandStrings(d.Get("tables").(*schema.Set).List()) {
+		cdc, ok := configs[table]
+		if !ok {
+			continue
+		}
+		tables = append(tables, table)
+		dataTopics[table] = cdc.DataTopic
+		connectorNames[table] = cdc.ConnectorName
+	}
+
+	if err := d.Set("tables", tables); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("data_topics", dataTopics); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("connector_names", connectorNames); err != nil {
+		return diag.FromErr(err)
+	}
+
+	keyspaceTables, err := listKeyspaceTables(ctx, meta, databaseID, keyspace)
+	if err != nil {
+		// Listed again by the next refresh, an unreachable database should not block unrelated changes
+		tflog.Warn(ctx, fmt.Sprintf("could not list the tables of keyspace %s: %v", keyspace, err))
+		if d.Get("available_tables").(*schema.Set).Len() == 0 {
+			// Without a listing, the plan must not disable CDC for the tables of the resource
+			return diag.FromErr(d.Set("available_tables", tables))
+		}
+		return nil
+	}
+	return diag.FromErr(d.Set("available_tables", availableCDCKeyspaceTables(keyspaceTables, configs, tables)))
+}
+
+func resourceCDCKeyspaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	databaseID, keyspace, tenantName, err := parseCDCKeyspaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !d.HasChanges("exclude_tables", "tables") {
+		// Only deletion_policy changed
+		return nil
+	}
+
+	oldTables, newTables := d.GetChange("tables")
+	add, remove := diffCDCKeyspaceTables(expandStrings(oldTables.(*schema.Set).List()), expandStrings(newTables.(*schema.Set).List()))
+
+	cdcMutex.Lock(tenantName)
+	defer cdcMutex.Unlock(tenantName)
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configs, pulsarCluster, pulsarToken, err := getKeyspaceCDC(ctx, meta, org, databaseID, keyspace, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The tables of the state are only changed for the tables which CDC was enabled or disabled for
+	tables := map[string]bool{}
+	for _, table := range expandStrings(oldTables.(*schema.Set).List()) {
+		tables[table] = true
+	}
+	setTables := func() {
+		names := []string{}
+		for table := range tables {
+			names = append(names, table)
+		}
+		sort.Strings(names)
+		d.Set("tables", names)
+	}
+	for _, table := range add {
+		if _, ok := configs[table]; ok {
+			// CDC was enabled by another resource since the plan
+			continue
+		}
+		if _, _, err := enableTableCDC(ctx, meta, d.Timeout(schema.TimeoutUpdate), org, "", tenantName, astrastreaming.EnableCDCJSONRequestBody{
+			DatabaseId:      databaseID,
+			DatabaseName:    d.Get("database_name").(string),
+			Keyspace:        keyspace,
+			OrgId:           org.ID,
+			TableName:       table,
+			TopicPartitions: d.Get("topic_partitions").(int),
+		}); err != nil {
+			setTables()
+			return diag.FromErr(err)
+		}
+		tables[table] = true
+	}
+	for _, table := range remove {
+		if _, ok := configs[table]; ok {
+			if err := deleteTableCDC(ctx, streamingClientv3, d.Timeout(schema.TimeoutUpdate), pulsarCluster, pulsarToken, tenantName, astrastreaming.DeleteCDCJSONRequestBody{
+				DatabaseId:      databaseID,
+				DatabaseName:    d.Get("database_name").(string),
+				Keyspace:        keyspace,
+				OrgId:           org.ID,
+				TableName:       table,
+				TopicPartitions: d.Get("topic_partitions").(int),
+			}); err != nil {
+				setTables()
+				return diag.FromErr(err)
+			}
+		}
+		delete(tables, table)
+	}
+
+	setTables()
+	return resourceCDCKeyspaceReadLocked(ctx, d, meta, org)
+}
+
+func resourceCDCKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonedOnDelete(d) {
+		// The CDC configurations are kept, only remove the resource from the state
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	databaseID, keyspace, tenantName, err := parseCDCKeyspaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cdcMutex.Lock(tenantName)
+	defer cdcMutex.Unlock(tenantName)
+
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pulsarCluster, pulsarToken, err := prepCDC(ctx, meta, databaseID, "", org, tenantName, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the tables enabled by the resource are tracked in the state
+	for _, table := range expandStrings(d.Get("tables").(*schema.Set).List()) {
+		if err := deleteTableCDC(ctx, streamingClientv3, d.Timeout(schema.TimeoutDelete), pulsarCluster, pulsarToken, tenantName, astrastreaming.DeleteCDCJSONRequestBody{
+			DatabaseId:      databaseID,
+			DatabaseName:    d.Get("database_name").(string),
+			Keyspace:        keyspace,
+			OrgId:           org.ID,
+			TableName:       table,
+			TopicPartitions: d.Get("topic_partitions").(int),
+		}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceCDCKeyspaceImport adopts the CDC configurations of the tables listed in the import ID, or of every table of
+// the keyspace when the ID lists no table. The database name and the topic partitions are read from the CDC
+// configurations, so that the import does not plan a replacement.
+func resourceCDCKeyspaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	databaseID, keyspace, tenantName, listed, err := parseCDCKeyspaceImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	org, err := getCurrentOrg(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	configs, pulsarCluster, pulsarToken, err := getKeyspaceCDC(ctx, meta, org, databaseID, keyspace, tenantName)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := importedCDCKeyspaceTables(configs, listed, keyspace, tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource has a single topic_partitions, so all the tables must have the same number of partitions
+	partitions := 0
+	for i, table := range tables {
+		p, err := getPulsarTopicPartitions(ctx, streamingClientv3, pulsarCluster, pulsarToken, configs[table].DataTopic)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && p != partitions {
+			return nil, fmt.Errorf("table %s has %d topic partitions and table %s has %d, only import tables with the same topic partitions", tables[0], partitions, table, p)
+		}
+		partitions = p
+	}
+
+	d.SetId(resourceid.CDCKeyspace.Format(databaseID, keyspace, tenantName))
+	values := map[string]interface{}{
+		"database_id":      databaseID,
+		"keyspace":         keyspace,
+		"tenant_name":      tenantName,
+		"database_name":    configs[tables[0]].DatabaseName,
+		"topic_partitions": partitions,
+		"tables":           tables,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importedCDCKeyspaceTables returns the tables adopted by the import, sorted: the listed tables, which must have a CDC
+// configuration, or all the tables with a CDC configuration when none are listed
+func importedCDCKeyspaceTables(configs map[string]CDCConfig, listed []string, keyspace, tenantName string) ([]string, error) {
+	tables := []string{}
+	if len(listed) == 0 {
+		for table := range configs {
+			tables = append(tables, table)
+		}
+		if len(tables) == 0 {
+			return nil, fmt.Errorf("no table of keyspace %s has CDC enabled in tenant %s", keyspace, tenantName)
+		}
+	}
+	for _, table := range listed {
+		if _, ok := configs[table]; !ok {
+			return nil, fmt.Errorf("table %s of keyspace %s does not have CDC enabled in tenant %s", table, keyspace, tenantName)
+		}
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+// resourceCDCKeyspaceCustomizeDiff compares the tables of the keyspace listed by the last refresh with the tables of the
+// resource, so that tables added or dropped since the last apply show up as a change of the tables attribute
+func resourceCDCKeyspaceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.NewValueKnown("exclude_tables") {
+		return nil
+	}
+
+	current := expandStrings(diff.Get("tables").(*schema.Set).List())
+	desired := cdcKeyspaceTables(expandStrings(diff.Get("available_tables").(*schema.Set).List()), expandStrings(diff.Get("exclude_tables").(*schema.Set).List()))
+	if add, remove := diffCDCKeyspaceTables(current, desired); len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	if err := diff.SetNew("tables", desired); err != nil {
+		return err
+	}
+	if err := diff.SetNewComputed("data_topics"); err != nil {
+		return err
+	}
+	return diff.SetNewComputed("connector_names")
+}
+
+// getKeyspaceCDC returns the CDC configurations of the tables of the keyspace in the tenant by table name, the Pulsar
+// cluster of the database and a Pulsar token of the tenant
+func getKeyspaceCDC(ctx context.Context, meta interface{}, org OrgId, databaseID, keyspace, tenantName string) (map[string]CDCConfig, string, string, error) {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	pulsarCluster, pulsarToken, err := prepCDC(ctx, meta, databaseID, "", org, tenantName, false)
+	if err != nil {
+		return nil, "", "", err
+	}
+	cdcResult, resp, err := getCDC(ctx, streamingClientv3, tenantName, &astrastreaming.GetCDCParams{
+		XDataStaxPulsarCluster: pulsarCluster,
+		Authorization:          pulsarToken,
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+	if !resp.success() {
+		return nil, "", "", fmt.Errorf("Error getting cdc config %s", resp.Body)
+	}
+	return keyspaceCDCConfigs(cdcResult, databaseID, keyspace), pulsarCluster, pulsarToken, nil
+}
+
+// keyspaceCDCConfigs returns the CDC configurations of the tables of the keyspace by table name
+func keyspaceCDCConfigs(cdcResult CDCResult, databaseID, keyspace string) map[string]CDCConfig {
+	configs := map[string]CDCConfig{}
+	for _, cdc := range cdcResult {
+		if strings.EqualFold(cdc.DatabaseID, databaseID) && cdc.Keyspace == keyspace {
+			configs[cdc.DatabaseTable] = cdc
+		}
+	}
+	return configs
+}
+
+// availableCDCKeyspaceTables returns the tables of the keyspace which the resource can enable CDC for: the tables
+// without a CDC configuration and the tables it enabled CDC for
+func availableCDCKeyspaceTables(tables []string, configs map[string]CDCConfig, enabled []string) []string {
+	owned := map[string]bool{}
+	for _, table := range enabled {
+		owned[table] = true
+	}
+	result := []string{}
+	for _, table := range tables {
+		if _, ok := configs[table]; !ok || owned[table] {
+			result = append(result, table)
+		}
+	}
+	return result
+}
+
+// listKeyspaceTables returns the names of the tables of the keyspace, sorted
+func listKeyspaceTables(ctx context.Context, meta interface{}, databaseID, keyspace string) ([]string, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	dbResp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return nil, err
+	} else if dbResp.JSON200 == nil {
+		return nil, fmt.Errorf("error fetching database %s: %s", databaseID, string(dbResp.Body))
+	}
+	restClient, err := getRestClient(meta, databaseID, astra.StringValue(dbResp.JSON200.Info.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := restClient.GetTablesWithResponse(ctx, keyspace, &astrarestapi.GetTablesParams{
+		XCassandraToken: meta.(astraClients).token,
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return nil, fmt.Errorf("error listing tables in keyspace %s: %s", keyspace, string(resp.Body))
+	}
+
+	tables := []string{}
+	if resp.JSON200.Data != nil {
+		for _, table := range *resp.JSON200.Data {
+			tables = append(tables, table.Name)
+		}
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+// cdcKeyspaceTables returns the tables to enable CDC for, all the tables of the keyspace except the excluded ones
+func cdcKeyspaceTables(tables, exclude []string) []string {
+	excluded := map[string]bool{}
+	for _, table := range exclude {
+		excluded[table] = true
+	}
+	result := []string{}
+	for _, table := range tables {
+		if !excluded[table] {
+			result = append(result, table)
+		}
+	}
+	return result
+}
+
+// diffCDCKeyspaceTables returns the tables to enable CDC for and the tables to disable CDC for, sorted
+func diffCDCKeyspaceTables(current, desired []string) ([]string, []string) {
+	currentTables := map[string]bool{}
+	for _, table := range current {
+		currentTables[table] = true
+	}
+	add := []string{}
+	for _, table := range desired {
+		if !currentTables[table] {
+			add = append(add, table)
+		}
+		delete(currentTables, table)
+	}
+	remove := []string{}
+	for table := range currentTables {
+		remove = append(remove, table)
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+// parseCDCKeyspaceImportID returns the fields of the ID of the resource and the tables listed after it, if any
+func parseCDCKeyspaceImportID(id string) (string, string, string, []string, error) {
+	if parts, err := resourceid.CDCKeyspaceImport.Parse(id); err == nil {
+		return parts[0], parts[1], parts[2], strings.Split(parts[3], ","), nil
+	}
+	databaseID, keyspace, tenantName, err := parseCDCKeyspaceID(id)
+	return databaseID, keyspace, tenantName, nil, err
+}
+
+func parseCDCKeyspaceID(id string) (string, string, string, error) {
+	parts, err := resourceid.CDCKeyspace.Parse(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], nil
+}
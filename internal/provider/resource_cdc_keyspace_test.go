@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCDCKeyspace(t *testing.T) {
+	// Disabled by default like TestCDC
+	checkRequiredTestVars(t, "ASTRA_TEST_CDC_TEST_ENABLED", "ASTRA_TEST_DATABASE_ID", "ASTRA_TEST_STREAMING_TENANT")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCDCKeyspaceConfiguration(databaseID, tenant),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("astra_cdc_keyspace.all", "tables.*", "test"),
+					resource.TestCheckResourceAttrSet("astra_cdc_keyspace.all", "data_topics.test"),
+				),
+			},
+			{
+				ResourceName:            "astra_cdc_keyspace.all",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_policy", "exclude_tables", "timeouts"},
+			},
+		},
+	})
+}
+
+func testAccCDCKeyspaceConfiguration(databaseID, tenant string) string {
+	return fmt.Sprintf(`
+resource "astra_cdc_keyspace" "all" {
+  database_id      = "%s"
+  database_name    = "sai_test"
+  keyspace         = "sai_test"
+  topic_partitions = 3
+  tenant_name      = "%s"
+}
+`, databaseID, tenant)
+}
+
+func TestDiffCDCKeyspaceTables(t *testing.T) {
+	desired := cdcKeyspaceTables([]string{"a", "b", "c", "d"}, []string{"b"})
+	if !reflect.DeepEqual(desired, []string{"a", "c", "d"}) {
+		t.Fatalf("unexpected tables: %v", desired)
+	}
+
+	add, remove := diffCDCKeyspaceTables([]string{"b", "a", "e"}, desired)
+	if !reflect.DeepEqual(add, []string{"c", "d"}) || !reflect.DeepEqual(remove, []string{"b", "e"}) {
+		t.Fatalf("unexpected changes: add %v, remove %v", add, remove)
+	}
+
+	add, remove = diffCDCKeyspaceTables(desired, desired)
+	if len(add) != 0 || len(remove) != 0 {
+		t.Fatalf("expected no changes, got add %v, remove %v", add, remove)
+	}
+}
+
+func TestAvailableCDCKeyspaceTables(t *testing.T) {
+	cdcResult := CDCResult{
+		{DatabaseID: "db1", Keyspace: "ks", DatabaseTable: "a"},
+		{DatabaseID: "db1", Keyspace: "ks", DatabaseTable: "b"},
+		{DatabaseID: "db1", Keyspace: "other", DatabaseTable: "c"},
+		{DatabaseID: "db2", Keyspace: "ks", DatabaseTable: "d"},
+	}
+	configs := keyspaceCDCConfigs(cdcResult, "DB1", "ks")
+	if len(configs) != 2 || configs["a"].DatabaseTable != "a" || configs["b"].DatabaseTable != "b" {
+		t.Fatalf("unexpected CDC configurations of the keyspace: %+v", configs)
+	}
+
+	// b has CDC enabled by another resource, a by this resource
+	available := availableCDCKeyspaceTables([]string{"a", "b", "c", "d"}, configs, []string{"a"})
+	if !reflect.DeepEqual(available, []string{"a", "c", "d"}) {
+		t.Fatalf("unexpected available tables: %v", available)
+	}
+	available = availableCDCKeyspaceTables([]string{"a", "b", "c", "d"}, configs, nil)
+	if !reflect.DeepEqual(available, []string{"c", "d"}) {
+		t.Fatalf("unexpected available tables before CDC is enabled: %v", available)
+	}
+}
+
+func TestImportedCDCKeyspaceTables(t *testing.T) {
+	// orders has CDC enabled by astra_cdc, users and events by astra_cdc_keyspace
+	configs := map[string]CDCConfig{
+		"events": {DatabaseTable: "events"},
+		"orders": {DatabaseTable: "orders"},
+		"users":  {DatabaseTable: "users"},
+	}
+	tables, err := importedCDCKeyspaceTables(configs, []string{"users", "events"}, "ks", "tenant1")
+	if err != nil || !reflect.DeepEqual(tables, []string{"events", "users"}) {
+		t.Fatalf("expected only the listed tables to be imported, got %v, %v", tables, err)
+	}
+	tables, err = importedCDCKeyspaceTables(configs, nil, "ks", "tenant1")
+	if err != nil || !reflect.DeepEqual(tables, []string{"events", "orders", "users"}) {
+		t.Fatalf("expected all the tables to be imported, got %v, %v", tables, err)
+	}
+	if _, err := importedCDCKeyspaceTables(configs, []string{"users", "missing"}, "ks", "tenant1"); err == nil || !strings.Contains(err.Error(), "table missing of keyspace ks does not have CDC enabled") {
+		t.Fatalf("expected a missing table error, got %v", err)
+	}
+	if _, err := importedCDCKeyspaceTables(map[string]CDCConfig{}, nil, "ks", "tenant1"); err == nil {
+		t.Fatal("expected an error when no table has CDC enabled")
+	}
+
+	for id, expected := range map[string][]string{
+		"db/ks/tenant1":              nil,
+		"db/ks/tenant1/users,events": {"users", "events"},
+	} {
+		databaseID, keyspace, tenantName, listed, err := parseCDCKeyspaceImportID(id)
+		if err != nil || databaseID != "db" || keyspace != "ks" || tenantName != "tenant1" || !reflect.DeepEqual(listed, expected) {
+			t.Fatalf("unexpected import ID fields of %s: %s %s %s %v %v", id, databaseID, keyspace, tenantName, listed, err)
+		}
+	}
+}
@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestCDC(t *testing.T) {
@@ -17,6 +26,7 @@ func TestCDC(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCDCConfiguration(),
+				Check:  resource.TestCheckResourceAttrSet("astra_cdc.cdc-1", "connector_name"),
 			},
 		},
 	})
@@ -26,7 +36,7 @@ func TestCDC(t *testing.T) {
 func testAccCDCConfiguration() string {
 	return fmt.Sprintf(`
 resource "astra_streaming_tenant" "streaming_tenant-1" {
-  tenant_name        = "terraformtest"
+  tenant_name        = "terraform-test-cdc"
   topic              = "terraformtest"
   region             = "useast-4"
   cloud_provider     = "gcp"
@@ -44,3 +54,285 @@ resource "astra_cdc" "cdc-1" {
 
 `)
 }
+
+func TestPulsarTokenCache(t *testing.T) {
+	cache := newPulsarTokenCache()
+	fetches := 0
+	fetch := func() (string, error) {
+		fetches++
+		return fmt.Sprintf("token%d", fetches), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if token, err := cache.get("pulsar-gcp-useast1", "tenant", false, fetch); err != nil || token != "token1" {
+			t.Fatalf("expected the cached token, got %q, %v", token, err)
+		}
+	}
+	if token, err := cache.get("pulsar-gcp-useast1", "other", false, fetch); err != nil || token != "token2" {
+		t.Fatalf("expected a token for the other tenant, got %q, %v", token, err)
+	}
+	if token, err := cache.get("pulsar-gcp-useast1", "tenant", true, fetch); err != nil || token != "token3" {
+		t.Fatalf("expected a refreshed token, got %q, %v", token, err)
+	}
+	if token, err := cache.get("pulsar-gcp-useast1", "tenant", false, fetch); err != nil || token != "token3" {
+		t.Fatalf("expected the refreshed token to be cached, got %q, %v", token, err)
+	}
+	if _, err := cache.get("pulsar-gcp-useast1", "failing", false, func() (string, error) { return "", fmt.Errorf("unauthorized") }); err == nil {
+		t.Fatal("expected the fetch error")
+	}
+}
+
+func TestPulsarTokenCacheConcurrentTenants(t *testing.T) {
+	cache := newPulsarTokenCache()
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, err := cache.get("pulsar-gcp-useast1", "slow", false, func() (string, error) {
+			close(fetching)
+			<-release
+			return "slow-token", nil
+		})
+		done <- err
+	}()
+	<-fetching
+
+	// The token of another tenant is fetched while the first fetch is still running
+	if token, err := cache.get("pulsar-gcp-useast1", "other", false, func() (string, error) { return "other-token", nil }); err != nil || token != "other-token" {
+		t.Fatalf("expected the token of the other tenant, got %q, %v", token, err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if token, err := cache.get("pulsar-gcp-useast1", "slow", false, func() (string, error) { return "", fmt.Errorf("unexpected fetch") }); err != nil || token != "slow-token" {
+		t.Fatalf("expected the cached token, got %q, %v", token, err)
+	}
+}
+
+func TestSplitPulsarSchema(t *testing.T) {
+	keyValue := pulsarSchemaInfo{
+		Type: "KEY_VALUE",
+		Data: `{"key":{"type":"record","name":"key","fields":[{"name":"id","type":"string"}]},"value":{"type":"record","name":"value","fields":[{"name":"v","type":["null","int"]}]}}`,
+	}
+	keySchema, valueSchema, err := splitPulsarSchema(keyValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keySchema != `{"type":"record","name":"key","fields":[{"name":"id","type":"string"}]}` {
+		t.Errorf("unexpected key schema %s", keySchema)
+	}
+	if valueSchema != `{"type":"record","name":"value","fields":[{"name":"v","type":["null","int"]}]}` {
+		t.Errorf("unexpected value schema %s", valueSchema)
+	}
+
+	keySchema, valueSchema, err = splitPulsarSchema(pulsarSchemaInfo{Type: "AVRO", Data: `{"type":"string"}`})
+	if err != nil || keySchema != "" || valueSchema != `{"type":"string"}` {
+		t.Errorf("unexpected schemas %q, %q (%v)", keySchema, valueSchema, err)
+	}
+
+	if _, _, err := splitPulsarSchema(pulsarSchemaInfo{Type: "KEY_VALUE", Data: "invalid"}); err == nil {
+		t.Error("expected an error for an invalid KEY_VALUE schema")
+	}
+}
+
+func TestGetCDC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/admin/v3/astra/tenants/missing/cdc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"unauthorized"}`))
+			return
+		}
+		w.Write([]byte(`[{"keyspace":"ks","databaseTable":"tbl","dataTopic":"persistent://tenant/astracdc/data-tbl"}]`))
+	}))
+	defer server.Close()
+
+	client, err := astrastreaming.NewClientWithResponses(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, resp, err := getCDC(context.Background(), client, "tenant", &astrastreaming.GetCDCParams{})
+	if err != nil || !resp.success() {
+		t.Fatalf("expected the CDC configurations, got %v, %v", resp, err)
+	}
+	if len(result) != 1 || result[0].DatabaseTable != "tbl" || result[0].DataTopic != "persistent://tenant/astracdc/data-tbl" {
+		t.Fatalf("unexpected CDC configurations: %+v", result)
+	}
+
+	result, resp, err = getCDC(context.Background(), client, "missing", &astrastreaming.GetCDCParams{})
+	if err != nil || resp.success() || resp.StatusCode != http.StatusUnauthorized || result != nil {
+		t.Fatalf("expected an unsuccessful response, got %v, %v, %v", result, resp, err)
+	}
+}
+
+func TestMockCDCMissingKeyspace(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	client := p.Meta().(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	resp, err := client.CreateDatabaseWithResponse(ctx, astra.CreateDatabaseJSONRequestBody{
+		Name:          "cdcdb",
+		Keyspace:      "ks1",
+		CloudProvider: "gcp",
+		Region:        "us-east1",
+		Tier:          astra.Serverless,
+		CapacityUnits: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 creating database, got %d: %s", resp.StatusCode(), resp.Body)
+	}
+	databaseID := resp.HTTPResponse.Header.Get("Location")
+
+	err = checkCDCTable(ctx, p.Meta(), time.Minute, databaseID, "ks2", "tbl")
+	if err == nil || !strings.Contains(err.Error(), "keyspace ks2 does not exist") {
+		t.Fatalf("expected an error for the missing keyspace, got %v", err)
+	}
+}
+
+func TestCDCConnectorStatusDiagnostics(t *testing.T) {
+	config := map[string]interface{}{
+		"table":            "tbl",
+		"keyspace":         "ks",
+		"database_id":      "5b70892f-e01a-4595-98e6-19ecc9985d50",
+		"database_name":    "db",
+		"topic_partitions": 3,
+		"tenant_name":      "tenant",
+	}
+	d := schema.TestResourceDataRaw(t, resourceCDC().Schema, config)
+	if diags := cdcConnectorStatusDiagnostics(d, "tbl", "running"); diags != nil {
+		t.Fatalf("expected no diagnostics for a running connector, got %v", diags)
+	}
+	diags := cdcConnectorStatusDiagnostics(d, "tbl", "Failed")
+	if len(diags) != 1 || diags.HasError() || !strings.Contains(diags[0].Detail, `"Failed"`) {
+		t.Fatalf("expected a warning for a failed connector, got %v", diags)
+	}
+
+	config["expected_status"] = "Stopped"
+	d = schema.TestResourceDataRaw(t, resourceCDC().Schema, config)
+	if diags := cdcConnectorStatusDiagnostics(d, "tbl", "Stopped"); diags != nil {
+		t.Fatalf("expected no diagnostics for the expected status, got %v", diags)
+	}
+}
+
+func TestCDCPulsarCluster(t *testing.T) {
+	cloudProvider := astra.CloudProvider("GCP")
+	region := "us-east1"
+	db := &astra.Database{
+		Id: "db1",
+		Info: astra.DatabaseInfo{
+			CloudProvider: &cloudProvider,
+			Region:        &region,
+			Datacenters:   &[]astra.Datacenter{{Region: "us-east1"}, {Region: "europe-west1"}},
+		},
+	}
+
+	if cluster, r, err := cdcPulsarCluster(db, ""); err != nil || cluster != "pulsar-gcp-useast1" || r != "us-east1" {
+		t.Fatalf("expected the cluster of the primary region, got %q, %q, %v", cluster, r, err)
+	}
+	if cluster, r, err := cdcPulsarCluster(db, "Pulsar-GCP-EuropeWest1"); err != nil || cluster != "pulsar-gcp-europewest1" || r != "europe-west1" {
+		t.Fatalf("expected the pinned cluster, got %q, %q, %v", cluster, r, err)
+	}
+	_, _, err := cdcPulsarCluster(db, "pulsar-aws-uswest2")
+	if err == nil || !strings.Contains(err.Error(), "available clusters: pulsar-gcp-useast1, pulsar-gcp-europewest1") {
+		t.Fatalf("expected an error for a cluster outside of the regions of the database, got %v", err)
+	}
+}
+
+func TestCheckTopicPartitionCount(t *testing.T) {
+	topic := "persistent://tenant1/cdc/data-tbl"
+	if err := checkTopicPartitionCount(topic, 3, 3); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := checkTopicPartitionCount(topic, 0, 3); err == nil || !strings.Contains(err.Error(), "not partitioned") {
+		t.Fatalf("expected an error for a missing topic, got %v", err)
+	}
+	if err := checkTopicPartitionCount(topic, 2, 3); err == nil || !strings.Contains(err.Error(), "has 2 partitions") {
+		t.Fatalf("expected an error for a different partition count, got %v", err)
+	}
+
+	if !pulsarTopicsEqual("tenant1/cdc/data-tbl", topic) || pulsarTopicsEqual("tenant1/astracdc/data-tbl", topic) {
+		t.Fatal("unexpected comparison of topic names")
+	}
+}
+
+func TestCDCResultFind(t *testing.T) {
+	cdcResult := CDCResult{
+		{DatabaseID: "db1", Keyspace: "ks", DatabaseTable: "other", DataTopic: "persistent://tenant/astracdc/data-db1-ks.other"},
+		{DatabaseID: "db2", Keyspace: "ks", DatabaseTable: "tbl", DataTopic: "persistent://tenant/astracdc/data-db2-ks.tbl"},
+		{DatabaseID: "DB1", Keyspace: "ks", DatabaseTable: "tbl", DataTopic: "persistent://tenant/astracdc/data-db1-ks.tbl"},
+	}
+	if cdc, ok := cdcResult.find("db1", "ks", "tbl"); !ok || cdc.DataTopic != "persistent://tenant/astracdc/data-db1-ks.tbl" {
+		t.Fatalf("expected the CDC configuration of the table, got %+v, %v", cdc, ok)
+	}
+	if _, ok := cdcResult.find("db1", "KS", "tbl"); ok {
+		t.Fatal("expected no CDC configuration for another keyspace")
+	}
+	if _, ok := (CDCResult{}).find("db1", "ks", "tbl"); ok {
+		t.Fatal("expected no CDC configuration in an empty tenant")
+	}
+}
+
+func TestCheckCDCDataTopic(t *testing.T) {
+	// The tenant already has CDC for another table, the check uses the configuration of the created table
+	cdcResult := CDCResult{
+		{DatabaseID: "db1", Keyspace: "ks", DatabaseTable: "first", DataTopic: "persistent://tenant/astracdc/data-first"},
+		{DatabaseID: "db1", Keyspace: "ks", DatabaseTable: "tbl", DataTopic: "persistent://tenant/cdc/data-tbl"},
+	}
+	cdc, ok := cdcResult.find("db1", "ks", "tbl")
+	if !ok {
+		t.Fatal("expected the CDC configuration of the table")
+	}
+	if err := checkCDCDataTopic(cdc, "tenant/cdc/data-tbl"); err != nil {
+		t.Fatalf("expected no error for the configured data topic, got %v", err)
+	}
+	if err := checkCDCDataTopic(cdc, ""); err != nil {
+		t.Fatalf("expected no error without a configured data topic, got %v", err)
+	}
+	if err := checkCDCDataTopic(cdc, "persistent://tenant/cdc/other"); err == nil || !strings.Contains(err.Error(), "uses data topic persistent://tenant/cdc/data-tbl") {
+		t.Fatalf("expected an error for another data topic, got %v", err)
+	}
+}
+
+func TestExpandCDCSink(t *testing.T) {
+	config := map[string]interface{}{
+		"table":            "tbl",
+		"keyspace":         "ks",
+		"database_id":      "5b70892f-e01a-4595-98e6-19ecc9985d50",
+		"database_name":    "db",
+		"topic_partitions": 3,
+		"tenant_name":      "tenant",
+	}
+	d := schema.TestResourceDataRaw(t, resourceCDC().Schema, config)
+	if _, ok, err := expandCDCSink(d, "persistent://tenant/astracdc/data-tbl"); ok || err != nil {
+		t.Fatalf("expected no sink, got %v, %v", ok, err)
+	}
+
+	config["sink"] = []interface{}{map[string]interface{}{
+		"sink_name":    "jdbc-clickhouse",
+		"sink_configs": `{"jdbcUrl":"jdbc:clickhouse://localhost:8123/db"}`,
+	}}
+	d = schema.TestResourceDataRaw(t, resourceCDC().Schema, config)
+	spec, ok, err := expandCDCSink(d, "persistent://tenant/astracdc/data-tbl")
+	if !ok || err != nil {
+		t.Fatalf("expected a sink, got %v, %v", ok, err)
+	}
+	if spec.Namespace != "astracdc" || spec.Topic != "persistent://tenant/astracdc/data-tbl" || spec.Configs["jdbcUrl"] != "jdbc:clickhouse://localhost:8123/db" || spec.Parallelism != 1 {
+		t.Fatalf("unexpected sink %+v", spec)
+	}
+
+	config["sink"] = []interface{}{map[string]interface{}{
+		"sink_name":    "jdbc-clickhouse",
+		"sink_configs": `["jdbc:clickhouse://localhost:8123/db"]`,
+	}}
+	d = schema.TestResourceDataRaw(t, resourceCDC().Schema, config)
+	if _, _, err := expandCDCSink(d, "persistent://tenant/astracdc/data-tbl"); err == nil || !strings.Contains(err.Error(), "JSON object") {
+		t.Fatalf("expected an error for sink configs which are not an object, got %v", err)
+	}
+}
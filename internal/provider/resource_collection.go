@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var availableVectorMetrics = []string{
+	"cosine",
+	"euclidean",
+	"dot_product",
+}
+
+func resourceCollection() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_collection` provides a Data API collection resource. Collections can store vectors and, with a `vectorize` block, generate the embeddings on the server side using an embedding provider.",
+		CreateContext: resourceCollectionCreate,
+		ReadContext:   resourceCollectionRead,
+		DeleteContext: resourceCollectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCollectionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"database_id": {
+				Description:  "Astra database to create the collection in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description:      "The region of the database used to reach the Data API.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignoreRegionFormat,
+			},
+			"namespace": {
+				Description:      "The namespace (keyspace) to create the collection in.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			"name": {
+				Description:      "Collection name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateCQLIdentifier,
+			},
+			// Optional
+			"vector_dimension": {
+				Description:  "The dimension of the vectors stored in the collection. Can be omitted when `vectorize` is set and the model has a default dimension.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"vector_metric": {
+				Description:  "The similarity metric of the vectors, `cosine`, `euclidean` or `dot_product`. Defaults to `cosine` for vector collections.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(availableVectorMetrics, false),
+			},
+			"vectorize": {
+				Description: "The embedding service used to generate vectors on the server side.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider": {
+							Description: "The embedding provider, for example `openai`, `azureOpenAI` or `nvidia`.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"model_name": {
+							Description: "The embedding model of the provider.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"provider_key": {
+							Description: "The name of the embedding provider API key registered in the Astra Portal. Not needed for providers that do not require a key.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"parameters": {
+							Description: "Additional provider specific parameters, for example the `resourceName` and `deploymentId` of Azure OpenAI.",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCollectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	namespace := d.Get("namespace").(string)
+	name := d.Get("name").(string)
+
+	createCollection := map[string]interface{}{
+		"name": name,
+	}
+	if vector := expandCollectionVectorOptions(d); vector != nil {
+		createCollection["options"] = map[string]interface{}{
+			"vector": vector,
+		}
+	}
+	command := map[string]interface{}{
+		"createCollection": createCollection,
+	}
+	if err := dataAPIStatusCommand(ctx, meta, databaseID, region, namespace, command); err != nil {
+		return diag.Errorf("error creating collection %s: %s", name, err)
+	}
+
+	d.SetId(resourceid.Collection.Format(databaseID, namespace, name))
+	return resourceCollectionRead(ctx, d, meta)
+}
+
+func resourceCollectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+
+	databaseID, namespace, name, err := parseCollectionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	command := map[string]interface{}{
+		"findCollections": map[string]interface{}{
+			"options": map[string]interface{}{
+				"explain": true,
+			},
+		},
+	}
+	body, err := dataAPICommand(ctx, meta, databaseID, region, namespace, command)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var collectionsResp DataAPIFindCollectionsResponse
+	if err := json.Unmarshal(body, &collectionsResp); err != nil {
+		return diag.Errorf("failed to decode collections: %s", err)
+	}
+	if len(collectionsResp.Errors) > 0 {
+		return diag.Errorf("error listing collections in namespace %s: %s", namespace, collectionsResp.Errors[0].Message)
+	}
+
+	var collection *DataAPICollection
+	for i := range collectionsResp.Status.Collections {
+		if collectionsResp.Status.Collections[i].Name == name {
+			collection = &collectionsResp.Status.Collections[i]
+			break
+		}
+	}
+	if collection == nil {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("collection %s in namespace %s", name, namespace)))
+	}
+
+	if err := setCollectionData(d, databaseID, namespace, collection); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCollectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+
+	databaseID, namespace, name, err := parseCollectionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	command := map[string]interface{}{
+		"deleteCollection": map[string]interface{}{
+			"name": name,
+		},
+	}
+	if err := dataAPIStatusCommand(ctx, meta, databaseID, region, namespace, command); err != nil {
+		return diag.Errorf("error deleting collection %s: %s", name, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceCollectionImport imports a collection from database_id/namespace/name. The region is read from the database.
+func resourceCollectionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID, _, _, err := parseCollectionID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setDatabaseRegion(ctx, d, client, databaseID); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// setDatabaseRegion sets the region of a resource which is reached through the Data API to the region of the database
+func setDatabaseRegion(ctx context.Context, d *schema.ResourceData, client *astra.ClientWithResponses, databaseID string) error {
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return err
+	} else if resp.JSON200 == nil {
+		return fmt.Errorf("error fetching database: %s", string(resp.Body))
+	}
+	return d.Set("region", astra.StringValue(resp.JSON200.Info.Region))
+}
+
+// dataAPIStatusCommand sends a Data API command which only returns a status and fails on any returned error
+func dataAPIStatusCommand(ctx context.Context, meta interface{}, databaseID, region, namespace string, command interface{}) error {
+	body, err := dataAPICommand(ctx, meta, databaseID, region, namespace, command)
+	if err != nil {
+		return err
+	}
+
+	var statusResp DataAPIStatusResponse
+	if err := json.Unmarshal(body, &statusResp); err != nil {
+		return fmt.Errorf("failed to decode Data API response: %w", err)
+	}
+	if len(statusResp.Errors) > 0 {
+		return errors.New(statusResp.Errors[0].Message)
+	}
+	return nil
+}
+
+func expandCollectionVectorOptions(d *schema.ResourceData) map[string]interface{} {
+	vector := map[string]interface{}{}
+	if v, ok := d.GetOk("vector_dimension"); ok {
+		vector["dimension"] = v.(int)
+	}
+	if v, ok := d.GetOk("vector_metric"); ok {
+		vector["metric"] = v.(string)
+	}
+	if v, ok := d.GetOk("vectorize"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		vectorize := v.([]interface{})[0].(map[string]interface{})
+		service := map[string]interface{}{
+			"provider": vectorize["provider"].(string),
+		}
+		if modelName := vectorize["model_name"].(string); modelName != "" {
+			service["modelName"] = modelName
+		}
+		if providerKey := vectorize["provider_key"].(string); providerKey != "" {
+			service["authentication"] = map[string]interface{}{
+				"providerKey": providerKey,
+			}
+		}
+		if parameters := vectorize["parameters"].(map[string]interface{}); len(parameters) > 0 {
+			service["parameters"] = parameters
+		}
+		vector["service"] = service
+	}
+	if len(vector) == 0 {
+		return nil
+	}
+	return vector
+}
+
+func flattenCollectionVectorize(service *DataAPICollectionVectorService) []map[string]interface{} {
+	if service == nil {
+		return nil
+	}
+	parameters := make(map[string]interface{}, len(service.Parameters))
+	for k, v := range service.Parameters {
+		parameters[k] = fmt.Sprint(v)
+	}
+	providerKey := ""
+	if service.Authentication != nil {
+		providerKey = service.Authentication.ProviderKey
+	}
+	return []map[string]interface{}{
+		{
+			"provider":     service.Provider,
+			"model_name":   service.ModelName,
+			"provider_key": providerKey,
+			"parameters":   parameters,
+		},
+	}
+}
+
+func setCollectionData(d *schema.ResourceData, databaseID, namespace string, collection *DataAPICollection) error {
+	d.SetId(resourceid.Collection.Format(databaseID, namespace, collection.Name))
+
+	if err := d.Set("database_id", databaseID); err != nil {
+		return err
+	}
+	if err := d.Set("namespace", namespace); err != nil {
+		return err
+	}
+	if err := d.Set("name", collection.Name); err != nil {
+		return err
+	}
+
+	dimension, metric := 0, ""
+	var service *DataAPICollectionVectorService
+	if collection.Options.Vector != nil {
+		dimension = collection.Options.Vector.Dimension
+		metric = collection.Options.Vector.Metric
+		service = collection.Options.Vector.Service
+	}
+	if err := d.Set("vector_dimension", dimension); err != nil {
+		return err
+	}
+	if err := d.Set("vector_metric", metric); err != nil {
+		return err
+	}
+	return d.Set("vectorize", flattenCollectionVectorize(service))
+}
+
+func parseCollectionID(id string) (string, string, string, error) {
+	parts, err := resourceid.Collection.Parse(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+type DataAPIStatusResponse struct {
+	Status map[string]interface{} `json:"status,omitempty"`
+	Errors []DataAPIError         `json:"errors,omitempty"`
+}
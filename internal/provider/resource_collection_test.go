@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCollection(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID", "ASTRA_TEST_DATABASE_REGION")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	region := os.Getenv("ASTRA_TEST_DATABASE_REGION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionConfiguration(databaseID, region),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_collection.vectors", "name", "tf_vectors"),
+					resource.TestCheckResourceAttr("astra_collection.vectors", "vector_dimension", "1024"),
+					resource.TestCheckResourceAttr("astra_collection.vectors", "vectorize.0.provider", "nvidia"),
+				),
+			},
+			{
+				ResourceName:      "astra_collection.vectors",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccCollectionConfiguration(databaseID, region string) string {
+	return fmt.Sprintf(`
+resource "astra_collection" "vectors" {
+  database_id      = "%s"
+  region           = "%s"
+  namespace        = "default_keyspace"
+  name             = "tf_vectors"
+  vector_dimension = 1024
+  vector_metric    = "cosine"
+  vectorize {
+    provider   = "nvidia"
+    model_name = "NV-Embed-QA"
+  }
+}
+`, databaseID, region)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDataAPINamespace() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_data_api_namespace` provides a Data API namespace resource. The namespace is created with the Data API instead of the DevOps API, for applications which only use the Data API. Use `astra_keyspace` for CQL keyspaces.",
+		CreateContext: resourceDataAPINamespaceCreate,
+		ReadContext:   resourceDataAPINamespaceRead,
+		DeleteContext: resourceDataAPINamespaceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDataAPINamespaceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"database_id": {
+				Description:  "Astra database to create the namespace in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description:      "The region of the database used to reach the Data API.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignoreRegionFormat,
+			},
+			"name": {
+				Description:      "Namespace name can have up to 48 alpha-numeric characters and contain underscores; only letters and numbers are supported as the first character.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+		},
+	}
+}
+
+func resourceDataAPINamespaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	name := d.Get("name").(string)
+
+	command := map[string]interface{}{
+		"createNamespace": map[string]interface{}{
+			"name": name,
+		},
+	}
+	if err := dataAPIStatusCommand(ctx, meta, databaseID, region, "", command); err != nil {
+		return diag.Errorf("error creating namespace %s: %s", name, err)
+	}
+
+	d.SetId(resourceid.DataAPINamespace.Format(databaseID, name))
+	return resourceDataAPINamespaceRead(ctx, d, meta)
+}
+
+func resourceDataAPINamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+
+	databaseID, name, err := parseDataAPINamespaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	command := map[string]interface{}{
+		"findNamespaces": map[string]interface{}{},
+	}
+	body, err := dataAPICommand(ctx, meta, databaseID, region, "", command)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var namespacesResp DataAPIFindNamespacesResponse
+	if err := json.Unmarshal(body, &namespacesResp); err != nil {
+		return diag.Errorf("failed to decode namespaces: %s", err)
+	}
+	if len(namespacesResp.Errors) > 0 {
+		return diag.Errorf("error listing namespaces: %s", namespacesResp.Errors[0].Message)
+	}
+
+	found := false
+	for _, n := range namespacesResp.Status.Namespaces {
+		if n == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("namespace %s", name)))
+	}
+
+	if err := d.Set("database_id", databaseID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDataAPINamespaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	region := d.Get("region").(string)
+
+	databaseID, name, err := parseDataAPINamespaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	command := map[string]interface{}{
+		"dropNamespace": map[string]interface{}{
+			"name": name,
+		},
+	}
+	if err := dataAPIStatusCommand(ctx, meta, databaseID, region, "", command); err != nil {
+		return diag.Errorf("error dropping namespace %s: %s", name, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceDataAPINamespaceImport imports a namespace from database_id/namespace/name. The region is read from the database.
+func resourceDataAPINamespaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID, _, err := parseDataAPINamespaceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setDatabaseRegion(ctx, d, client, databaseID); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseDataAPINamespaceID(id string) (string, string, error) {
+	parts, err := resourceid.DataAPINamespace.Parse(id)
+	if err != nil {
+		return "", "", err
+	}
+	return parts[0], parts[1], nil
+}
+
+type DataAPIFindNamespacesResponse struct {
+	Status struct {
+		Namespaces []string `json:"namespaces"`
+	} `json:"status"`
+	Errors []DataAPIError `json:"errors,omitempty"`
+}
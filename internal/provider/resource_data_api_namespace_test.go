@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataAPINamespace(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID", "ASTRA_TEST_DATABASE_REGION")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	region := os.Getenv("ASTRA_TEST_DATABASE_REGION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataAPINamespaceConfiguration(databaseID, region),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_data_api_namespace.app", "name", "tf_documents"),
+				),
+			},
+			{
+				ResourceName:      "astra_data_api_namespace.app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccDataAPINamespaceConfiguration(databaseID, region string) string {
+	return fmt.Sprintf(`
+resource "astra_data_api_namespace" "app" {
+  database_id = "%s"
+  region      = "%s"
+  name        = "tf_documents"
+}
+`, databaseID, region)
+}
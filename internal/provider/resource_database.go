@@ -1,16 +1,21 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -22,6 +27,34 @@ var availableCloudProviders = []string{
 	"azure",
 }
 
+// availableDatabaseTiers are the tiers which can be created, serverless or one of the classic (dedicated) tiers
+var availableDatabaseTiers = []string{
+	string(astra.Serverless),
+	string(astra.A5),
+	string(astra.A10),
+	string(astra.A20),
+	string(astra.A40),
+	string(astra.C10),
+	string(astra.C20),
+	string(astra.C40),
+	string(astra.D10),
+	string(astra.D20),
+	string(astra.D40),
+}
+
+// legacyDatabaseTiers are the tiers of databases created before serverless, which can no longer be created
+var legacyDatabaseTiers = []string{
+	string(astra.Developer),
+	string(astra.Cloudnative),
+}
+
+// maxCapacityUnitsIncrease is the maximum number of capacity units which can be added to a database in one resize
+const maxCapacityUnitsIncrease = 3
+
+var availableDatabaseTypes = []string{
+	"vector",
+}
+
 var databaseCreateTimeout = time.Minute * 20
 var databaseReadTimeout = time.Minute * 5
 var databaseDeleteTimeout = time.Minute * 20
@@ -29,14 +62,35 @@ var databaseUpdateTimeout = time.Minute * 20
 
 func resourceDatabase() *schema.Resource {
 	return &schema.Resource{
-		Description:   "`astra_database` provides an Astra Serverless Database resource. You can create and delete databases. Note: Classic Tier databases are not supported by the Terraform provider. (see https://docs.datastax.com/en/astra/docs/index.html for more about Astra DB)",
+		Description:   "`astra_database` provides an Astra Database resource. You can create and delete serverless databases and classic (dedicated) tier databases. (see https://docs.datastax.com/en/astra/docs/index.html for more about Astra DB)",
 		CreateContext: resourceDatabaseCreate,
 		ReadContext:   resourceDatabaseRead,
 		DeleteContext: resourceDatabaseDelete,
 		UpdateContext: resourceDatabaseUpdate,
+		CustomizeDiff: customdiff.All(
+			resourceDatabaseCustomizeDiff,
+			resourceDatabaseRegionChangeDiff,
+			resourceDatabaseTypeChangeDiff,
+			resourceDatabaseTierDiff,
+			resourceDatabasePreferredRegionsDiff,
+			resourceDatabaseQuotaDiff,
+		),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schema.ImportStatePassthroughWithIdentity("id"),
+		},
+
+		Identity: &schema.ResourceIdentity{
+			Version: 0,
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"id": {
+						Description:       "The ID of the Astra database.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+				}
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -49,11 +103,11 @@ func resourceDatabase() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			// Required
 			"name": {
-				Description:  "Astra database name.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "Astra database name. Must be 2 to 50 characters, start and end with a letter or number, and only contain letters, numbers and the characters `& + - _ ( ) < > . , @`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDatabaseName,
 			},
 			"keyspace": {
 				Description:      "Initial keyspace name. For additional keyspaces, use the astra_keyspace resource.",
@@ -71,22 +125,127 @@ func resourceDatabase() *schema.Resource {
 				DiffSuppressFunc: ignoreCase,
 			},
 			"regions": {
-				Description: "Cloud regions to launch the database. (see https://docs.datastax.com/en/astra/docs/database-regions.html for supported regions)",
-				Type:        schema.TypeList,
+				Description: "Cloud regions to launch the database. (see https://docs.datastax.com/en/astra/docs/database-regions.html for supported regions) Regions other than the primary region are added to and removed from the database in place. Regions are compared ignoring case and dashes, so `us-east1` and `useast1` are the same region.",
+				Type:        schema.TypeSet,
 				Required:    true,
 				ForceNew:    false,
+				MinItems:    1,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 			// Optional
+			"primary_region": {
+				Description:      "The region the database is created in, which must be one of `regions`. Required when more than one region is set at creation. Changing the primary region destroys and recreates the database.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignoreRegionFormat,
+			},
+			"preferred_regions": {
+				Description: "The regions of the database in the order clients should prefer them, for example to pick the local datacenter of drivers and the datacenters to fail over to. Astra does not route requests between regions, so this only orders `preferred_datacenters` and can be changed in place. Each region must be one of `regions`. Regions which are not listed follow the listed ones, starting with the primary region.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deletion_policy": deletionPolicySchema("database"),
 			"deletion_protection": {
 				Description: "Whether or not to allow Terraform to destroy the instance. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
 			},
+			"db_type": {
+				Description:  "The type of the database. Set to `vector` to create a vector database. Vector capability can not be enabled on an existing database, so changing the type destroys and recreates the database.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(availableDatabaseTypes, false),
+			},
+			"tier": {
+				Description:      "The tier of the database. Defaults to `serverless`. The classic (dedicated) tiers, like `C10` or `D10`, provision a cluster with a fixed compute size in each region. See `astra_available_tiers` for the tiers available to the organization. Changing the tier destroys and recreates the database. Databases of the legacy `developer` and `cloudnative` tiers keep their tier when it is not set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(astra.Serverless),
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(availableDatabaseTiers, false),
+				DiffSuppressFunc: ignoreLegacyTierDefault,
+			},
+			"capacity_units": {
+				Description:  "The capacity units of a classic tier database, which scale its storage and throughput. Defaults to `1`. Capacity units can be increased in place but not reduced. Not supported for serverless databases.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"public_access_disabled": {
+				Description: "Whether or not to disable access to the database from the public internet. When true, the database is only reachable from the addresses of its access list and through private endpoints. This is the same setting as `enabled` of `astra_access_list`, so only one of them should be set for a database. Existing access list addresses are kept when this is changed. When the role of the token cannot read the access list, a refresh reports a warning and keeps the last known value.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"allow_region_migration": {
+				Description: "Whether or not to allow changes that remove existing regions or change the cloud provider or primary region of the database. Removing a region drops its datacenter and changing the cloud provider or primary region destroys and recreates the database, so any of these changes may lose data. Unless this field is set to true, a plan with such a change will fail. It allows every change allowed by `allow_region_removal`, whatever the value of `allow_region_removal`. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"allow_region_removal": {
+				Description: "Whether or not to allow removing regions from the database. Removing a region deletes its datacenter and the replicas it holds, so data only replicated to it is lost. Unless this field or `allow_region_migration` is set to true, a plan which removes regions will fail and the provider will not delete any datacenter. Removing regions is allowed when either field is true, but this field never allows changing the cloud provider or primary region, which requires `allow_region_migration`. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"restore_from": {
+				Description: "Create the database from a backup of another database, for example to refresh a staging database from a production backup. The database is created empty and the backup is restored into it once it is active, before any other region is added. Changing the source destroys and recreates the database.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database_id": {
+							Description:  "The ID of the database the backup was taken from.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"backup_id": {
+							Description: "The ID of the backup to restore, see `astra_backups`. Defaults to the latest completed backup of the source database.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"wait_for_data_api": {
+				Description: "Whether or not to wait, after the database becomes active, until the Data API of each region of the database answers. The status of a new database can be active before its endpoints are ready, so resources changing its schema right away can fail. The endpoints must be reachable from where Terraform runs. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"wait_for_cql": {
+				Description: "Whether or not to wait, after the database becomes active, until the CQL coordinators of each region of the database answer, which is checked with the REST API of the database. The endpoints must be reachable from where Terraform runs. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			// Computed
+			"preferred_datacenters": {
+				Description: "The IDs of the datacenters of the database in the order of `preferred_regions`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"owner_id": {
 				Description: "The owner id.",
 				Type:        schema.TypeString,
@@ -137,6 +296,16 @@ func resourceDatabase() *schema.Resource {
 				Type:        schema.TypeInt,
 				Computed:    true,
 			},
+			"used_storage": {
+				Description: "Storage used by the database in GB, as last reported by the Astra API. Refreshed on every read.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"used_storage_percent": {
+				Description: "Storage used by the database as a percentage of `total_storage`. Always 0 for serverless databases, which have no fixed storage capacity.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
 			"additional_keyspaces": {
 				Description: "Additional keyspaces",
 				Type:        schema.TypeList,
@@ -163,38 +332,66 @@ func resourceDatabaseCreate(ctx context.Context, resourceData *schema.ResourceDa
 	name := resourceData.Get("name").(string)
 	keyspace := resourceData.Get("keyspace").(string)
 	cloudProvider := resourceData.Get("cloud_provider").(string)
-	regions := resourceData.Get("regions").([]interface{})
+	regions := resourceData.Get("regions").(*schema.Set).List()
 
 	if len(regions) < 1 {
-		return diag.Errorf("\"region\" array must have at least 1 region specified")
+		return diag.Errorf("\"regions\" must have at least 1 region specified")
 	}
 
 	// Make sure all regions are valid
-	if err := ensureValidRegions(ctx, client, resourceData); err != nil {
+	if err := ensureValidRegions(ctx, meta, resourceData); err != nil {
 		return err
 	}
-	// get the first region in the list to use as the region in which to create the database
-	region := regions[0].(string)
+	// Make sure the backup can be restored before creating the database
+	sourceDatabaseID, backupID, err := getRestoreBackup(ctx, client, resourceData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// create the database in the primary region, the other regions are added once it is active
+	region, err := getPrimaryRegion(resourceData.Get("primary_region").(string), regions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	additionalRegions, _ := getRegionUpdates([]interface{}{region}, regions)
+	// The regions are sent as named by the Astra API, the configuration may omit the dashes
+	regionNames, err := serverlessRegionNames(ctx, meta, resourceData.Get("tier").(string), cloudProvider, append([]string{region}, additionalRegions...))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	region, additionalRegions = regionNames[0], regionNames[1:]
 
-	// make an array of additonal regions to add if more than one specified
-	additionalRegions := make([]string, len(regions)-1)
-	if len(additionalRegions) > 0 {
-		for i := 0; i < len(additionalRegions); i++ {
-			additionalRegions[i] = regions[i+1].(string)
-		}
+	capacityUnits := 1
+	if cu, ok := resourceData.GetOk("capacity_units"); ok {
+		capacityUnits = cu.(int)
+	}
+
+	// Databases created earlier in the same apply count towards the limits, so they are checked again
+	if err := checkDatabaseQuotas(ctx, meta, resourceData.Get("tier").(string), cloudProvider, region, append([]string{region}, additionalRegions...), capacityUnits); err != nil {
+		return diag.FromErr(err)
 	}
 
-	resp, err := client.CreateDatabaseWithResponse(ctx, astra.CreateDatabaseJSONRequestBody{
-		Name:          name,
-		Keyspace:      keyspace,
-		CloudProvider: astra.CloudProvider(cloudProvider),
-		CapacityUnits: 1,
-		Region:        region,
-		Tier:          astra.Tier("serverless"),
+	// The client does not know the database type, so the request body is extended with it
+	body, err := json.Marshal(createDatabaseRequest{
+		DatabaseInfoCreate: astra.DatabaseInfoCreate{
+			Name:          name,
+			Keyspace:      keyspace,
+			CloudProvider: astra.CloudProvider(cloudProvider),
+			CapacityUnits: capacityUnits,
+			Region:        region,
+			Tier:          astra.Tier(resourceData.Get("tier").(string)),
+		},
+		DbType: resourceData.Get("db_type").(string),
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	resp, err := client.CreateDatabaseWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := permissionError(opCreateDatabase, resp.StatusCode(), resp.Body); err != nil {
+		return diag.FromErr(err)
+	}
 	if resp.StatusCode() != http.StatusCreated {
 		return diag.Errorf("unexpected create database response: %s", string(resp.Body))
 	}
@@ -202,17 +399,36 @@ func resourceDatabaseCreate(ctx context.Context, resourceData *schema.ResourceDa
 	databaseID := resp.HTTPResponse.Header.Get("location")
 
 	// Wait for the database to be ACTIVE then set resource data
-	if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID); err != nil {
+	if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID, resourceData.Timeout(schema.TimeoutCreate)); err != nil {
 		return err
 	}
 
+	// Restore the backup while the database only has its primary region, the added regions replicate the restored data
+	if backupID != "" {
+		if err := restoreDatabaseBackup(ctx, resourceData, client, databaseID, sourceDatabaseID, backupID, resourceData.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	// Add any additional regions/datacenters
 	if len(additionalRegions) > 0 {
-		if err := addRegionsToDatabase(ctx, resourceData, client, additionalRegions, databaseID, cloudProvider); err != nil {
+		if err := addRegionsToDatabase(ctx, resourceData, client, additionalRegions, databaseID, cloudProvider, resourceData.Timeout(schema.TimeoutCreate)); err != nil {
 			return err
 		}
 	}
 
+	// The endpoints are checked before public access can be disabled
+	if err := waitForDatabaseEndpoints(ctx, resourceData, meta, databaseID, resourceData.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	// New databases are public, so the access list is only changed when public access is disabled
+	if resourceData.Get("public_access_disabled").(bool) {
+		if err := setDatabasePublicAccessDisabled(ctx, client, databaseID, true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
@@ -229,8 +445,7 @@ func resourceDatabaseRead(ctx context.Context, resourceData *schema.ResourceData
 
 		// Remove from state when database not found
 		if resp.JSON404 != nil || resp.StatusCode() == http.StatusNotFound {
-			resourceData.SetId("")
-			return nil
+			return retry.NonRetryableError(notFoundError(fmt.Sprintf("database %s", databaseID)))
 		}
 
 		// Retry on 5XX errors
@@ -246,17 +461,34 @@ func resourceDatabaseRead(ctx context.Context, resourceData *schema.ResourceData
 
 		// If the database is TERMINATING or TERMINATED then remove it from the state
 		if db.Status == astra.TERMINATING || db.Status == astra.TERMINATED {
-			resourceData.SetId("")
-			return nil
+			return retry.NonRetryableError(notFoundError(fmt.Sprintf("database %s (%s)", databaseID, db.Status)))
 		}
 
 		// Add the database to state
 		if err := setDatabaseResourceData(resourceData, db); err != nil {
 			return retry.NonRetryableError(err)
 		}
+		if err := setDatabaseResourceOnlyData(resourceData, db, resp.Body); err != nil {
+			return retry.NonRetryableError(err)
+		}
 
 		return nil
 	}); err != nil {
+		return readError(ctx, resourceData, err)
+	}
+
+	publicAccessDisabled, err := getDatabasePublicAccessDisabled(ctx, client, databaseID)
+	if errors.Is(err, errInsufficientPermissions) {
+		// Tokens which cannot read access lists can still manage the database, the last known value is kept
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Could not read public_access_disabled of database %s", databaseID),
+			Detail:   err.Error(),
+		}}
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := resourceData.Set("public_access_disabled", publicAccessDisabled); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -264,6 +496,11 @@ func resourceDatabaseRead(ctx context.Context, resourceData *schema.ResourceData
 }
 
 func resourceDatabaseDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonedOnDelete(resourceData) {
+		// The database is kept, only remove the resource from the state
+		resourceData.SetId("")
+		return nil
+	}
 	if protectedFromDelete(resourceData) {
 		return diag.Errorf("\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_database")
 	}
@@ -273,13 +510,13 @@ func resourceDatabaseDelete(ctx context.Context, resourceData *schema.ResourceDa
 	alreadyDeleted := false
 
 	// get the list of regions and delete any extra regions/datacenters first
-	regions := (resourceData.Get("regions")).([]interface{})
+	regions := resourceData.Get("regions").(*schema.Set).List()
 	if len(regions) > 1 {
-		primaryRegion := []interface{}{regions[0].(string)}
+		primaryRegion := []interface{}{resourceData.Get("primary_region").(string)}
 		_, regionsToDelete := getRegionUpdates(regions, primaryRegion)
 		tflog.Debug(ctx, fmt.Sprintf("Multiple regions found. Must delete all additional regions first: %v, regions to delete: %v", regions, regionsToDelete))
 		cloudProvider := resourceData.Get("cloud_provider").(string)
-		if err := deleteRegionsFromDatabase(ctx, resourceData, client, regionsToDelete, databaseID, cloudProvider); err != nil {
+		if err := deleteRegionsFromDatabase(ctx, resourceData, client, regionsToDelete, databaseID, cloudProvider, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
 			return err
 		}
 	} else {
@@ -303,8 +540,15 @@ func resourceDatabaseDelete(ctx context.Context, resourceData *schema.ResourceDa
 			return nil
 		}
 
-		// All other 4XX status codes are NOT retried
+		if err := permissionError(opTerminateDatabase, resp.StatusCode(), resp.Body); err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		// All other 4XX status codes are NOT retried, unless an earlier delete already started the termination
 		if resp.StatusCode() >= http.StatusBadRequest {
+			if terminating, err := isDatabaseTerminating(ctx, client, databaseID); err == nil && terminating {
+				return nil
+			}
 			return retry.NonRetryableError(fmt.Errorf("unexpected response attempting to terminate database. Status code: %d, message = %s", resp.StatusCode(), string(resp.Body)))
 		}
 
@@ -320,7 +564,17 @@ func resourceDatabaseDelete(ctx context.Context, resourceData *schema.ResourceDa
 	}
 
 	// Wait for the database to be TERMINATED or not found
-	if err := retry.RetryContext(ctx, resourceData.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+	if err := waitForDatabaseTermination(ctx, client, databaseID, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceData.SetId("")
+	return nil
+}
+
+// waitForDatabaseTermination waits until the asynchronous termination of the database has completed
+func waitForDatabaseTermination(ctx context.Context, client *astra.ClientWithResponses, databaseID string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
 		// Errors sending request should be retried and are assumed to be transient
 		if err != nil {
@@ -350,12 +604,22 @@ func resourceDatabaseDelete(ctx context.Context, resourceData *schema.ResourceDa
 
 		// Continue until one of the expected conditions above are met
 		return retry.RetryableError(fmt.Errorf("expected database to be terminated but is %s", db.Status))
-	}); err != nil {
-		return diag.FromErr(err)
-	}
+	})
+}
 
-	resourceData.SetId("")
-	return nil
+// isDatabaseTerminating returns true when the database is already being terminated or is gone
+func isDatabaseTerminating(ctx context.Context, client *astra.ClientWithResponses, databaseID string) (bool, error) {
+	res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return false, err
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return true, nil
+	}
+	if res.StatusCode() != http.StatusOK || res.JSON200 == nil {
+		return false, fmt.Errorf("unexpected response fetching database: %s", string(res.Body))
+	}
+	return res.JSON200.Status == astra.TERMINATING || res.JSON200.Status == astra.TERMINATED, nil
 }
 
 func resourceDatabaseUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -365,24 +629,158 @@ func resourceDatabaseUpdate(ctx context.Context, resourceData *schema.ResourceDa
 	cloudProvider := resourceData.Get("cloud_provider").(string)
 
 	if resourceData.HasChange("regions") {
+		// make sure the regions are valid
+		if err := ensureValidRegions(ctx, meta, resourceData); err != nil {
+			return err
+		}
 		// get regions to add and delete
-		regionsToAdd, regionsToDelete := getRegionUpdates(resourceData.GetChange("regions"))
+		oldRegions, newRegions := resourceData.GetChange("regions")
+		regionsToAdd, regionsToDelete := getRegionUpdates(oldRegions.(*schema.Set).List(), newRegions.(*schema.Set).List())
 		if len(regionsToAdd) > 0 {
+			regionsToAdd, err := serverlessRegionNames(ctx, meta, resourceData.Get("tier").(string), cloudProvider, regionsToAdd)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := checkDatabaseQuotas(ctx, meta, resourceData.Get("tier").(string), cloudProvider, "", regionsToAdd, resourceData.Get("capacity_units").(int)); err != nil {
+				return diag.FromErr(err)
+			}
 			// add any regions to add first
-			if err := addRegionsToDatabase(ctx, resourceData, client, regionsToAdd, databaseID, cloudProvider); err != nil {
+			if err := addRegionsToDatabase(ctx, resourceData, client, regionsToAdd, databaseID, cloudProvider, resourceData.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+			if err := waitForDatabaseEndpoints(ctx, resourceData, meta, databaseID, resourceData.Timeout(schema.TimeoutUpdate)); err != nil {
 				return err
 			}
 		}
 		if len(regionsToDelete) > 0 {
+			// the plan is checked too, but never delete datacenters without the explicit opt-in
+			if !resourceData.Get("allow_region_removal").(bool) && !resourceData.Get("allow_region_migration").(bool) {
+				return diag.FromErr(regionRemovalError(regionsToDelete))
+			}
 			// delete any regions that should be removed
-			if err := deleteRegionsFromDatabase(ctx, resourceData, client, regionsToDelete, databaseID, cloudProvider); err != nil {
+			if err := deleteRegionsFromDatabase(ctx, resourceData, client, regionsToDelete, databaseID, cloudProvider, resourceData.Timeout(schema.TimeoutUpdate)); err != nil {
 				return err
 			}
 		}
 	}
+	if resourceData.HasChange("capacity_units") {
+		oldCapacityUnits, newCapacityUnits := resourceData.GetChange("capacity_units")
+		if err := resizeDatabase(ctx, resourceData, client, databaseID, oldCapacityUnits.(int), newCapacityUnits.(int), resourceData.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+	if resourceData.HasChange("public_access_disabled") {
+		if err := setDatabasePublicAccessDisabled(ctx, client, databaseID, resourceData.Get("public_access_disabled").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := setPreferredDatacenters(resourceData); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
+// resizeDatabase increases the capacity units of a classic tier database. The Astra API only allows a few capacity
+// units to be added at a time, so larger increases are applied in steps.
+func resizeDatabase(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, databaseID string, oldCapacityUnits, newCapacityUnits int, timeout time.Duration) diag.Diagnostics {
+	for _, capacityUnits := range capacityUnitsSteps(oldCapacityUnits, newCapacityUnits) {
+		cu := capacityUnits
+		resp, err := client.ResizeDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID), astra.CapacityUnits{CapacityUnits: &cu})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := permissionError(opResizeDatabase, resp.StatusCode(), resp.Body); err != nil {
+			return diag.FromErr(err)
+		}
+		if resp.StatusCode() >= http.StatusBadRequest {
+			return diag.Errorf("error resizing database to %d capacity units: %s", cu, string(resp.Body))
+		}
+		// Wait for the database to be ACTIVE then set resource data
+		if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// capacityUnitsSteps returns the capacity units of each resize needed to increase the capacity units of a database
+func capacityUnitsSteps(oldCapacityUnits, newCapacityUnits int) []int {
+	var steps []int
+	for cu := oldCapacityUnits; cu < newCapacityUnits; {
+		cu += maxCapacityUnitsIncrease
+		if cu > newCapacityUnits {
+			cu = newCapacityUnits
+		}
+		steps = append(steps, cu)
+	}
+	return steps
+}
+
+// getDatabasePublicAccessDisabled returns whether the access list of the database restricts access from the public
+// internet
+func getDatabasePublicAccessDisabled(ctx context.Context, client *astra.ClientWithResponses, databaseID string) (bool, error) {
+	resp, err := client.GetAccessListForDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return false, fmt.Errorf("unable to fetch access list of database (%s): %w", databaseID, err)
+	}
+	// Databases without an access list are public
+	if resp.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	if err := permissionError(opReadAccessList, resp.StatusCode(), resp.Body); err != nil {
+		return false, err
+	}
+	if resp.JSON200 == nil {
+		return false, fmt.Errorf("unexpected response fetching access list of database (%s): %s", databaseID, string(resp.Body))
+	}
+	if resp.JSON200.Configurations == nil {
+		return false, nil
+	}
+	return resp.JSON200.Configurations.AccessListEnabled, nil
+}
+
+// setDatabasePublicAccessDisabled enables or disables the access list of the database, keeping its addresses
+func setDatabasePublicAccessDisabled(ctx context.Context, client *astra.ClientWithResponses, databaseID string, disabled bool) error {
+	accessList, err := listAccessList(ctx, client, databaseID)
+	if err != nil {
+		return fmt.Errorf("unable to fetch access list of database (%s): %w", databaseID, err)
+	}
+	addresses := accessListAddressRequests(accessList)
+	resp, err := client.UpdateAccessListForDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID), astra.AccessListRequest{
+		Addresses: &addresses,
+		Configurations: &astra.AccessListConfigurations{
+			AccessListEnabled: disabled,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := permissionError(opUpdateAccessList, resp.StatusCode(), resp.Body); err != nil {
+		return err
+	}
+	if resp.StatusCode() >= http.StatusBadRequest {
+		return fmt.Errorf("error updating public access of database (%s): %s", databaseID, string(resp.Body))
+	}
+	return nil
+}
+
+// accessListAddressRequests converts the addresses of an access list to the addresses of an access list update
+func accessListAddressRequests(accessList *astra.AccessListResponse) []astra.AddressRequest {
+	addresses := []astra.AddressRequest{}
+	if accessList == nil || accessList.Addresses == nil {
+		return addresses
+	}
+	for _, a := range *accessList.Addresses {
+		addresses = append(addresses, astra.AddressRequest{
+			Address:     astra.StringValue(a.Address),
+			Description: astra.StringValue(a.Description),
+			Enabled:     a.Enabled != nil && *a.Enabled,
+		})
+	}
+	return addresses
+}
+
+// getRegionUpdates returns the regions to add and delete, ignoring regions which are only written in another format
 func getRegionUpdates(oldRegions interface{}, newRegions interface{}) ([]string, []string) {
 	mOld := map[string]bool{}
 	mNew := map[string]bool{}
@@ -390,18 +788,18 @@ func getRegionUpdates(oldRegions interface{}, newRegions interface{}) ([]string,
 	var regionsToDelete []string
 	// find any regions to add
 	for _, v := range oldRegions.([]interface{}) {
-		mOld[v.(string)] = true
+		mOld[normalizeRegion(v.(string))] = true
 	}
 	for _, v := range newRegions.([]interface{}) {
-		mNew[v.(string)] = true
+		mNew[normalizeRegion(v.(string))] = true
 	}
 	for _, v := range oldRegions.([]interface{}) {
-		if !mNew[v.(string)] {
+		if !mNew[normalizeRegion(v.(string))] {
 			regionsToDelete = append(regionsToDelete, v.(string))
 		}
 	}
 	for _, v := range newRegions.([]interface{}) {
-		if !mOld[v.(string)] {
+		if !mOld[normalizeRegion(v.(string))] {
 			regionsToAdd = append(regionsToAdd, v.(string))
 		}
 	}
@@ -409,18 +807,52 @@ func getRegionUpdates(oldRegions interface{}, newRegions interface{}) ([]string,
 	return regionsToAdd, regionsToDelete
 }
 
-func addRegionsToDatabase(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, regions []string, databaseID string, cloudProvider string) diag.Diagnostics {
-	// make sure the regions are valid
-	if err := ensureValidRegions(ctx, client, resourceData); err != nil {
-		return err
+// preserveRegionFormat returns the regions, replacing the ones which are configured in another format by the configured
+// region
+func preserveRegionFormat(configured []interface{}, regions []string) []string {
+	formats := make(map[string]string, len(configured))
+	for _, r := range configured {
+		formats[normalizeRegion(r.(string))] = r.(string)
+	}
+	preserved := make([]string, len(regions))
+	for i, r := range regions {
+		if format, ok := formats[normalizeRegion(r)]; ok {
+			preserved[i] = format
+		} else {
+			preserved[i] = r
+		}
+	}
+	return preserved
+}
+
+// getPrimaryRegion returns the primary region, which defaults to the only region when a single region is set
+func getPrimaryRegion(primaryRegion string, regions []interface{}) (string, error) {
+	if primaryRegion == "" {
+		if len(regions) != 1 {
+			return "", fmt.Errorf("\"primary_region\" must be set when \"regions\" has more than one region")
+		}
+		return regions[0].(string), nil
 	}
+	for _, r := range regions {
+		if regionsEqual(r.(string), primaryRegion) {
+			return r.(string), nil
+		}
+	}
+	return "", fmt.Errorf("primary region %s must be one of \"regions\"", primaryRegion)
+}
+
+func addRegionsToDatabase(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, regions []string, databaseID string, cloudProvider string, timeout time.Duration) diag.Diagnostics {
 	// Currently, DevOps API only allows for adding 1 region at a time
 	for _, region := range regions {
 		datacenters := make([]astra.Datacenter, 1)
 		datacenters[0] = astra.Datacenter{
 			CloudProvider: astra.CloudProvider(cloudProvider),
 			Region:        region,
-			Tier:          "serverless",
+			Tier:          astra.Tier(resourceData.Get("tier").(string)),
+		}
+		if !isServerlessTier(resourceData.Get("tier").(string)) {
+			capacityUnits := resourceData.Get("capacity_units").(int)
+			datacenters[0].CapacityUnits = &capacityUnits
 		}
 		resp, err := client.AddDatacentersWithResponse(ctx, astra.DatabaseIdParam(databaseID), datacenters)
 		if err != nil {
@@ -430,14 +862,14 @@ func addRegionsToDatabase(ctx context.Context, resourceData *schema.ResourceData
 			return diag.FromErr(fmt.Errorf("Unexpected response addinng Regions: %s", string(resp.Body)))
 		}
 		// Wait for the database to be ACTIVE then set resource data
-		if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID); err != nil {
+		if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID, timeout); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func deleteRegionsFromDatabase(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, regions []string, databaseID string, cloudProvider string) diag.Diagnostics {
+func deleteRegionsFromDatabase(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, regions []string, databaseID string, cloudProvider string, timeout time.Duration) diag.Diagnostics {
 	// get all the datacenetrs for the Datbase ID
 	dcListResp, err := client.ListDatacentersWithResponse(ctx, astra.DatabaseIdParam(databaseID), &astra.ListDatacentersParams{})
 	if err != nil {
@@ -450,11 +882,11 @@ func deleteRegionsFromDatabase(ctx context.Context, resourceData *schema.Resourc
 	// map regions to DCs
 	regionDcMap := map[string]astra.Datacenter{}
 	for _, v := range dcs {
-		regionDcMap[v.Region] = v
+		regionDcMap[normalizeRegion(v.Region)] = v
 	}
 	// delete each region that exists
 	for _, v := range regions {
-		if dc := regionDcMap[v]; dc.Id != nil {
+		if dc := regionDcMap[normalizeRegion(v)]; dc.Id != nil {
 			termResp, err := client.TerminateDatacenterWithResponse(ctx, astra.DatabaseIdParam(databaseID), astra.DatacenterIdParam(*dc.Id))
 			if err != nil {
 				return diag.FromErr(err)
@@ -466,7 +898,7 @@ func deleteRegionsFromDatabase(ctx context.Context, resourceData *schema.Resourc
 				return diag.Errorf("Error terminating datacenter for region \"%s\": Response %d, mesage = %s", v, termResp.StatusCode(), string(termResp.Body))
 			}
 			// Wait for the database to be ACTIVE then set resource data
-			if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID); err != nil {
+			if err := waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID, timeout); err != nil {
 				return err
 			}
 		}
@@ -474,8 +906,8 @@ func deleteRegionsFromDatabase(ctx context.Context, resourceData *schema.Resourc
 	return nil
 }
 
-func waitForDatabaseAndUpdateResource(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, databaseID string) diag.Diagnostics {
-	if err := retry.RetryContext(ctx, resourceData.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+func waitForDatabaseAndUpdateResource(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, databaseID string, timeout time.Duration) diag.Diagnostics {
+	if err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
 		// Errors sending request should be retried and are assumed to be transient
 		if err != nil {
@@ -502,6 +934,9 @@ func waitForDatabaseAndUpdateResource(ctx context.Context, resourceData *schema.
 			if err := setDatabaseResourceData(resourceData, db); err != nil {
 				return retry.NonRetryableError(err)
 			}
+			if err := setDatabaseResourceOnlyData(resourceData, db, res.Body); err != nil {
+				return retry.NonRetryableError(err)
+			}
 			return nil
 		default:
 			return retry.RetryableError(fmt.Errorf("expected database to be active but is %s", db.Status))
@@ -512,9 +947,209 @@ func waitForDatabaseAndUpdateResource(ctx context.Context, resourceData *schema.
 	return nil
 }
 
+// restoreStartTimeout is how long to wait for a database to leave the ACTIVE status once a restore is requested
+var restoreStartTimeout = time.Minute * 2
+
+// getRestoreBackup returns the source database and the backup of restore_from, which defaults to the latest completed
+// backup of the source database. The backup ID is empty when restore_from is not set.
+func getRestoreBackup(ctx context.Context, client *astra.ClientWithResponses, resourceData *schema.ResourceData) (string, string, error) {
+	restoreFrom, ok := resourceData.Get("restore_from").([]interface{})
+	if !ok || len(restoreFrom) == 0 || restoreFrom[0] == nil {
+		return "", "", nil
+	}
+	source := restoreFrom[0].(map[string]interface{})
+	sourceDatabaseID := source["database_id"].(string)
+	backupID := source["backup_id"].(string)
+
+	backups, err := listBackups(ctx, client, sourceDatabaseID)
+	if err != nil {
+		return "", "", fmt.Errorf("error listing the backups of database %s: %w", sourceDatabaseID, err)
+	}
+	backupID, err = findRestoreBackup(backups, sourceDatabaseID, backupID)
+	if err != nil {
+		return "", "", err
+	}
+	if err := resourceData.Set("restore_from", []map[string]interface{}{{
+		"database_id": sourceDatabaseID,
+		"backup_id":   backupID,
+	}}); err != nil {
+		return "", "", err
+	}
+	return sourceDatabaseID, backupID, nil
+}
+
+// findRestoreBackup returns the backup if it is completed, or the latest completed backup when backupID is empty
+func findRestoreBackup(backups DatabaseBackups, sourceDatabaseID, backupID string) (string, error) {
+	latestID := ""
+	var latest time.Time
+	for _, b := range backups {
+		if backupID != "" && b.ID == backupID {
+			if !strings.EqualFold(b.Status, "COMPLETED") {
+				return "", fmt.Errorf("backup %s of database %s can not be restored, its status is %s", backupID, sourceDatabaseID, b.Status)
+			}
+			return backupID, nil
+		}
+		if !strings.EqualFold(b.Status, "COMPLETED") {
+			continue
+		}
+		completedAt, err := time.Parse(time.RFC3339, b.CompletedAt)
+		if err != nil {
+			completedAt, _ = time.Parse(time.RFC3339, b.CreatedAt)
+		}
+		if latestID == "" || completedAt.After(latest) {
+			latestID, latest = b.ID, completedAt
+		}
+	}
+	if backupID != "" {
+		return "", fmt.Errorf("backup %s not found in the backups of database %s, see astra_backups", backupID, sourceDatabaseID)
+	}
+	if latestID == "" {
+		return "", fmt.Errorf("database %s has no completed backup to restore", sourceDatabaseID)
+	}
+	return latestID, nil
+}
+
+// restoreDatabaseBackup restores a backup of the source database into the database and waits for the restore to
+// complete. The database is not ACTIVE while the backup is restored, so the status is first polled until it changes.
+func restoreDatabaseBackup(ctx context.Context, resourceData *schema.ResourceData, client *astra.ClientWithResponses, databaseID, sourceDatabaseID, backupID string, timeout time.Duration) diag.Diagnostics {
+	if err := restoreBackup(ctx, client, databaseID, sourceDatabaseID, backupID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A database which stays ACTIVE did not accept the restore, it must not be reported as restored
+	if err := retry.RetryContext(ctx, restoreStartTimeout, func() *retry.RetryError {
+		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+		if err != nil || res.JSON200 == nil {
+			return retry.RetryableError(fmt.Errorf("error fetching database %s", databaseID))
+		}
+		if res.JSON200.Status == astra.ACTIVE {
+			return retry.RetryableError(fmt.Errorf("restore of backup %s into database %s has not started", backupID, databaseID))
+		}
+		return nil
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return waitForDatabaseAndUpdateResource(ctx, resourceData, client, databaseID, timeout)
+}
+
+// databaseEndpointProbe is a request answered by an endpoint of a database once it is ready
+type databaseEndpointProbe struct {
+	name        string
+	method      string
+	path        string
+	body        string
+	tokenHeader string
+}
+
+var (
+	dataAPIProbe = databaseEndpointProbe{
+		name:        "Data API",
+		method:      http.MethodPost,
+		path:        "api/json/v1",
+		body:        `{"findNamespaces":{}}`,
+		tokenHeader: "Token",
+	}
+	// The REST API answers through the CQL coordinators of the database
+	cqlProbe = databaseEndpointProbe{
+		name:        "CQL",
+		method:      http.MethodGet,
+		path:        "api/rest/v2/schemas/keyspaces",
+		tokenHeader: "X-Cassandra-Token",
+	}
+)
+
+// waitForDatabaseEndpoints waits until the endpoints selected by wait_for_data_api and wait_for_cql answer in every
+// region of the database. A database can be ACTIVE before its endpoints are ready.
+func waitForDatabaseEndpoints(ctx context.Context, resourceData *schema.ResourceData, meta interface{}, databaseID string, timeout time.Duration) diag.Diagnostics {
+	var probes []databaseEndpointProbe
+	if resourceData.Get("wait_for_data_api").(bool) {
+		probes = append(probes, dataAPIProbe)
+	}
+	if resourceData.Get("wait_for_cql").(bool) {
+		probes = append(probes, cqlProbe)
+	}
+	if len(probes) == 0 {
+		return nil
+	}
+
+	// the keys of the datacenters are cloud_provider.region
+	var regions []string
+	for key := range resourceData.Get("datacenters").(map[string]interface{}) {
+		regions = append(regions, key[strings.Index(key, ".")+1:])
+	}
+	sort.Strings(regions)
+
+	httpClient := newHTTPClient(meta.(astraClients).httpTransport, meta.(astraClients).httpOptions)
+	for _, region := range regions {
+		baseURL := fmt.Sprintf("https://%s-%s.apps.astra.datastax.com/", databaseID, region)
+		for _, probe := range probes {
+			tflog.Debug(ctx, fmt.Sprintf("waiting for the %s of database %s in region %s", probe.name, databaseID, region))
+			if err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+				ready, err := probeDatabaseEndpoint(ctx, meta, httpClient, baseURL, probe)
+				if err != nil {
+					return retry.NonRetryableError(err)
+				}
+				if !ready {
+					return retry.RetryableError(fmt.Errorf("%s of database %s in region %s is not ready", probe.name, databaseID, region))
+				}
+				return nil
+			}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	return nil
+}
+
+// probeDatabaseEndpoint sends the request of the probe and returns whether the endpoint answered. Errors sending the
+// request and unexpected responses are assumed to be transient, except when the token is not allowed to use the endpoint.
+func probeDatabaseEndpoint(ctx context.Context, meta interface{}, httpClient *http.Client, baseURL string, probe databaseEndpointProbe) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, probe.method, baseURL+probe.path, strings.NewReader(probe.body))
+	if err != nil {
+		return false, err
+	}
+	if probe.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(probe.tokenHeader, meta.(astraClients).token)
+	req.Header.Set("User-Agent", meta.(astraClients).userAgent)
+	req.Header.Set("X-Astra-Provider-Version", meta.(astraClients).providerVersion)
+	req.Header.Set("X-Astra-Client-Version", fmt.Sprintf("go/%s", astra.Version))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("%s probe failed: %v", probe.name, err))
+		return false, nil
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return false, fmt.Errorf("the token is not allowed to use the %s of the database (status %d)", probe.name, resp.StatusCode)
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		return true, nil
+	default:
+		tflog.Debug(ctx, fmt.Sprintf("%s probe returned status %d", probe.name, resp.StatusCode))
+		return false, nil
+	}
+}
+
 func setDatabaseResourceData(resourceData *schema.ResourceData, db *astra.Database) error {
 	resourceData.SetId(db.Id)
+	identity, err := resourceData.Identity()
+	if err != nil {
+		return err
+	}
+	if err := identity.Set("id", db.Id); err != nil {
+		return err
+	}
 	flatDb := flattenDatabase(db)
+	// DiffSuppressFunc does not apply to the elements of sets, so the regions of the resource keep the format they are
+	// configured in. The regions of the data source are a computed list.
+	if configured, ok := resourceData.Get("regions").(*schema.Set); ok {
+		flatDb["regions"] = preserveRegionFormat(configured.List(), flatDb["regions"].([]string))
+	}
 	for k, v := range flatDb {
 		if k == "id" {
 			continue
@@ -526,6 +1161,100 @@ func setDatabaseResourceData(resourceData *schema.ResourceData, db *astra.Databa
 	return nil
 }
 
+// setDatabaseResourceOnlyData sets the attributes which are only part of the astra_database resource. The database type
+// is not known by the client, so it is decoded from the response body.
+func setDatabaseResourceOnlyData(resourceData *schema.ResourceData, db *astra.Database, body []byte) error {
+	if err := resourceData.Set("primary_region", astra.StringValue(db.Info.Region)); err != nil {
+		return err
+	}
+	tier := string(astra.Serverless)
+	if db.Info.Tier != nil {
+		tier = string(*db.Info.Tier)
+	}
+	if err := resourceData.Set("tier", tier); err != nil {
+		return err
+	}
+	capacityUnits := 1
+	if db.Info.CapacityUnits != nil {
+		capacityUnits = *db.Info.CapacityUnits
+	}
+	if err := resourceData.Set("capacity_units", capacityUnits); err != nil {
+		return err
+	}
+	usedStorage, usedStoragePercent := databaseStorageUsage(db.Storage)
+	if err := resourceData.Set("used_storage", usedStorage); err != nil {
+		return err
+	}
+	if err := resourceData.Set("used_storage_percent", usedStoragePercent); err != nil {
+		return err
+	}
+	var dbTypeResp databaseTypeResponse
+	if err := json.Unmarshal(body, &dbTypeResp); err != nil {
+		return fmt.Errorf("failed to decode database type: %w", err)
+	}
+	if err := resourceData.Set("db_type", dbTypeResp.Info.DbType); err != nil {
+		return err
+	}
+	return setPreferredDatacenters(resourceData)
+}
+
+// setPreferredDatacenters sets the IDs of the datacenters of the database in the order of preferred_regions
+func setPreferredDatacenters(resourceData *schema.ResourceData) error {
+	datacenters := resourceData.Get("datacenters").(map[string]interface{})
+	cloudProvider := resourceData.Get("cloud_provider").(string)
+	// The configured regions may be written in another format than the regions of the datacenters
+	datacenterIDs := make(map[string]string, len(datacenters))
+	regions := make([]string, 0, len(datacenters))
+	for key, id := range datacenters {
+		region := normalizeRegion(strings.TrimPrefix(key, cloudProvider+"."))
+		datacenterIDs[region] = id.(string)
+		regions = append(regions, region)
+	}
+	preferredRegions := []string{}
+	for _, r := range resourceData.Get("preferred_regions").([]interface{}) {
+		preferredRegions = append(preferredRegions, normalizeRegion(r.(string)))
+	}
+	preferredDatacenters := []string{}
+	for _, region := range preferredRegionOrder(preferredRegions, normalizeRegion(resourceData.Get("primary_region").(string)), regions) {
+		preferredDatacenters = append(preferredDatacenters, datacenterIDs[region])
+	}
+	return resourceData.Set("preferred_datacenters", preferredDatacenters)
+}
+
+// preferredRegionOrder orders the regions of a database by preference: the preferred regions first, then the primary
+// region, then the other regions alphabetically
+func preferredRegionOrder(preferredRegions []string, primaryRegion string, regions []string) []string {
+	remaining := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		remaining[r] = true
+	}
+	ordered := make([]string, 0, len(regions))
+	for _, r := range append(preferredRegions, primaryRegion) {
+		if remaining[r] {
+			ordered = append(ordered, r)
+			delete(remaining, r)
+		}
+	}
+	others := make([]string, 0, len(remaining))
+	for r := range remaining {
+		others = append(others, r)
+	}
+	sort.Strings(others)
+	return append(ordered, others...)
+}
+
+// databaseStorageUsage returns the used storage of a database in GB and as a percentage of its total storage
+func databaseStorageUsage(storage *astra.Storage) (int, float64) {
+	if storage == nil || storage.UsedStorage == nil {
+		return 0, 0
+	}
+	usedStorage := *storage.UsedStorage
+	if storage.TotalStorage <= 0 {
+		return usedStorage, 0
+	}
+	return usedStorage, float64(usedStorage) * 100 / float64(storage.TotalStorage)
+}
+
 func flattenDatabase(db *astra.Database) map[string]interface{} {
 	flatDB := map[string]interface{}{
 		"id":                   db.Id,
@@ -567,34 +1296,356 @@ func flattenDatabase(db *astra.Database) map[string]interface{} {
 	return flatDB
 }
 
-func ensureValidRegions(ctx context.Context, client *astra.ClientWithResponses, resourceData *schema.ResourceData) diag.Diagnostics {
+// resourceDatabaseCustomizeDiff validates the cloud provider and regions at plan time
+func resourceDatabaseCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("cloud_provider") && !diff.HasChange("regions") && !diff.HasChange("primary_region") {
+		return nil
+	}
+	// Values computed from other resources are checked once they are known
+	if !diff.NewValueKnown("cloud_provider") || !diff.NewValueKnown("regions") || !diff.NewValueKnown("primary_region") {
+		return nil
+	}
+
+	regions := diff.Get("regions").(*schema.Set).List()
+	// The primary region of databases created before it was tracked is only known after the next refresh
+	if primaryRegion := diff.Get("primary_region").(string); diff.Id() == "" || primaryRegion != "" {
+		if _, err := getPrimaryRegion(primaryRegion, regions); err != nil {
+			return err
+		}
+	}
+
+	// Classic tier regions are checked by the Astra API when the database is created
+	if !diff.NewValueKnown("tier") || !isServerlessTier(diff.Get("tier").(string)) {
+		return nil
+	}
+
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	availableRegions, err := meta.(astraClients).serverlessRegions.get(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	cloudProvider := diff.Get("cloud_provider").(string)
+	for _, r := range regions {
+		region, _ := r.(string)
+		if region == "" {
+			continue
+		}
+		if err := checkRegionAvailable(cloudProvider, region, availableRegions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceDatabaseRegionChangeDiff blocks plans which remove regions from, or change the cloud provider or primary region
+// of, an existing database unless allow_region_migration is set. Removing regions is also allowed by allow_region_removal.
+func resourceDatabaseRegionChangeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || diff.Get("allow_region_migration").(bool) {
+		return nil
+	}
+
+	if diff.HasChange("cloud_provider") {
+		o, n := diff.GetChange("cloud_provider")
+		if !strings.EqualFold(o.(string), n.(string)) {
+			return fmt.Errorf("changing the cloud provider from %s to %s destroys and recreates the database, and all of its data is lost. Set \"allow_region_migration\" to true to allow this change", o, n)
+		}
+	}
+	if diff.HasChange("primary_region") {
+		o, n := diff.GetChange("primary_region")
+		if o.(string) != "" && n.(string) != "" {
+			return fmt.Errorf("changing the primary region from %s to %s destroys and recreates the database, and all of its data is lost. Set \"allow_region_migration\" to true to allow this change", o, n)
+		}
+	}
+	if diff.HasChange("regions") && diff.NewValueKnown("regions") && !diff.Get("allow_region_removal").(bool) {
+		oldRegions, newRegions := diff.GetChange("regions")
+		_, regionsToDelete := getRegionUpdates(oldRegions.(*schema.Set).List(), newRegions.(*schema.Set).List())
+		if len(regionsToDelete) > 0 {
+			return regionRemovalError(regionsToDelete)
+		}
+	}
+	return nil
+}
+
+func regionRemovalError(regions []string) error {
+	return fmt.Errorf("removing regions %s deletes their datacenters, and data only replicated to them is lost. Set \"allow_region_removal\" or \"allow_region_migration\" to true to allow this change", strings.Join(regions, ", "))
+}
+
+// resourceDatabasePreferredRegionsDiff checks that the preferred regions are regions of the database, and recomputes the
+// preferred datacenters when the regions or their order change
+func resourceDatabasePreferredRegionsDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("preferred_regions") || !diff.NewValueKnown("regions") {
+		return nil
+	}
+	regions := map[string]bool{}
+	for _, r := range diff.Get("regions").(*schema.Set).List() {
+		regions[normalizeRegion(r.(string))] = true
+	}
+	seen := map[string]bool{}
+	for _, r := range diff.Get("preferred_regions").([]interface{}) {
+		region, _ := r.(string)
+		if !regions[normalizeRegion(region)] {
+			return fmt.Errorf("preferred region %s must be one of \"regions\"", region)
+		}
+		if seen[normalizeRegion(region)] {
+			return fmt.Errorf("preferred region %s is listed more than once", region)
+		}
+		seen[normalizeRegion(region)] = true
+	}
+	if diff.Id() != "" && (diff.HasChange("preferred_regions") || diff.HasChange("regions")) {
+		return diff.SetNewComputed("preferred_datacenters")
+	}
+	return nil
+}
+
+// resourceDatabaseTypeChangeDiff explains that changing the type of an existing database replaces it, and blocks the
+// change while deletion_protection is set
+func resourceDatabaseTypeChangeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("db_type") || !diff.NewValueKnown("db_type") {
+		return nil
+	}
+	o, n := diff.GetChange("db_type")
+	if diff.Get("deletion_protection").(bool) {
+		return fmt.Errorf("changing the database type from %q to %q requires a new database, because the Astra API can not enable vector capability on an existing database. The database is destroyed and recreated, and all of its data is lost. Migrate the data to a new astra_database resource, or set \"deletion_protection\" to false to allow the replacement", databaseTypeName(o.(string)), databaseTypeName(n.(string)))
+	}
+	return nil
+}
+
+// resourceDatabaseTierDiff validates the settings which depend on the tier of the database
+func resourceDatabaseTierDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tier") {
+		return nil
+	}
+	tier := diff.Get("tier").(string)
+	if isServerlessTier(tier) {
+		if rawConfig := diff.GetRawConfig(); !rawConfig.IsNull() && !rawConfig.GetAttr("capacity_units").IsNull() {
+			return fmt.Errorf("\"capacity_units\" is only supported for classic tier databases, serverless databases scale automatically")
+		}
+		return nil
+	}
+	if dbType, ok := diff.GetOk("db_type"); ok && diff.NewValueKnown("db_type") {
+		return fmt.Errorf("database type %q is only supported for serverless databases, not for tier %s", dbType, tier)
+	}
+	if diff.Id() != "" && diff.HasChange("capacity_units") && diff.NewValueKnown("capacity_units") {
+		o, n := diff.GetChange("capacity_units")
+		if n.(int) < o.(int) {
+			return fmt.Errorf("capacity units of database can not be reduced from %d to %d", o, n)
+		}
+	}
+	return nil
+}
+
+// resourceDatabaseQuotaDiff checks at plan time that the organization limits allow creating the database, or adding
+// regions to it
+func resourceDatabaseQuotaDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("cloud_provider") || !diff.NewValueKnown("regions") || !diff.NewValueKnown("primary_region") ||
+		!diff.NewValueKnown("tier") || !diff.NewValueKnown("capacity_units") {
+		return nil
+	}
+
+	var primaryRegion string
+	var regions []string
+	if diff.Id() == "" {
+		newRegions := diff.Get("regions").(*schema.Set).List()
+		region, err := getPrimaryRegion(diff.Get("primary_region").(string), newRegions)
+		if err != nil {
+			// Reported by resourceDatabaseCustomizeDiff
+			return nil
+		}
+		primaryRegion = region
+		regions, _ = getRegionUpdates([]interface{}{}, newRegions)
+	} else if diff.HasChange("regions") {
+		oldRegions, newRegions := diff.GetChange("regions")
+		regions, _ = getRegionUpdates(oldRegions.(*schema.Set).List(), newRegions.(*schema.Set).List())
+	}
+	if len(regions) == 0 {
+		return nil
+	}
+
+	capacityUnits := diff.Get("capacity_units").(int)
+	if capacityUnits < 1 {
+		capacityUnits = 1
+	}
+	return checkDatabaseQuotas(ctx, meta, diff.Get("tier").(string), diff.Get("cloud_provider").(string), primaryRegion, regions, capacityUnits)
+}
+
+// checkDatabaseQuotas returns an error when the organization reached its limits in one of the regions. The primary
+// region is only set for a new database, which counts towards the database limit of its region. The limits are also
+// enforced by the Astra API, so they are not checked when they can not be fetched.
+func checkDatabaseQuotas(ctx context.Context, meta interface{}, tier, cloudProvider, primaryRegion string, regions []string, capacityUnits int) error {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	resp, err := client.ListAvailableRegionsWithResponse(ctx)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("could not fetch the organization limits: %v", err))
+		return nil
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		tflog.Warn(ctx, fmt.Sprintf("could not fetch the organization limits: %s", string(resp.Body)))
+		return nil
+	}
+	return checkRegionQuotas(*resp.JSON200, tier, cloudProvider, primaryRegion, regions, capacityUnits)
+}
+
+// checkRegionQuotas checks the limits of the regions against their current usage. Limits which are not set are ignored.
+func checkRegionQuotas(limits []astra.AvailableRegionCombination, tier, cloudProvider, primaryRegion string, regions []string, capacityUnits int) error {
+	if tier == "" {
+		tier = string(astra.Serverless)
+	}
+	for _, region := range regions {
+		var limit *astra.AvailableRegionCombination
+		for i, l := range limits {
+			if strings.EqualFold(string(l.Tier), tier) && strings.EqualFold(string(l.CloudProvider), cloudProvider) && regionsEqual(l.Region, region) {
+				limit = &limits[i]
+				break
+			}
+		}
+		if limit == nil {
+			continue
+		}
+		if primaryRegion != "" && regionsEqual(region, primaryRegion) && limit.DatabaseCountLimit > 0 && limit.DatabaseCountUsed >= limit.DatabaseCountLimit {
+			return fmt.Errorf("organization limit reached in region %s: %d of %d %s databases are used", region, limit.DatabaseCountUsed, limit.DatabaseCountLimit, tier)
+		}
+		if !isServerlessTier(tier) && limit.CapacityUnitsLimit > 0 && limit.CapacityUnitsUsed+capacityUnits > limit.CapacityUnitsLimit {
+			return fmt.Errorf("organization limit reached in region %s: %d of %d %s capacity units are used, and the database requires %d", region, limit.CapacityUnitsUsed, limit.CapacityUnitsLimit, tier, capacityUnits)
+		}
+	}
+	return nil
+}
+
+// ignoreLegacyTierDefault keeps databases of a legacy tier, which can no longer be created, from being replaced by a
+// serverless database because the tier defaults to serverless
+func ignoreLegacyTierDefault(_, old, new string, _ *schema.ResourceData) bool {
+	if new != string(astra.Serverless) {
+		return false
+	}
+	for _, tier := range legacyDatabaseTiers {
+		if strings.EqualFold(old, tier) {
+			return true
+		}
+	}
+	return false
+}
+
+func isServerlessTier(tier string) bool {
+	return tier == "" || tier == string(astra.Serverless)
+}
+
+func databaseTypeName(dbType string) string {
+	if dbType == "" {
+		return "serverless"
+	}
+	return dbType
+}
+
+func ensureValidRegions(ctx context.Context, meta interface{}, resourceData *schema.ResourceData) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	// Classic tier regions are checked by the Astra API
+	if !isServerlessTier(resourceData.Get("tier").(string)) {
+		return nil
+	}
+
 	// get the list of serveless regions
-	regionsResp, err := client.ListServerlessRegionsWithResponse(ctx)
+	availableRegions, err := meta.(astraClients).serverlessRegions.get(ctx, client)
 	if err != nil {
 		return diag.FromErr(err)
-	} else if regionsResp.StatusCode() != http.StatusOK {
-		return diag.Errorf("unexpected list available regions response: %s", string(regionsResp.Body))
 	}
 	// make sure all of the regions are valid
 	cloudProvider := resourceData.Get("cloud_provider").(string)
-	regions := resourceData.Get("regions").([]interface{})
+	regions := resourceData.Get("regions").(*schema.Set).List()
 	for _, r := range regions {
-		region := r.(string)
-		dbRegion := findMatchingRegion(cloudProvider, region, "serverless", *regionsResp.JSON200)
-		if dbRegion == nil {
-			return diag.Errorf("cloud provider and region combination not available: %s/%s", cloudProvider, region)
+		if err := checkRegionAvailable(cloudProvider, r.(string), availableRegions); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 	return nil
 }
 
+// checkRegionAvailable returns an error listing the available regions of the cloud provider if the region is not one of them
+func checkRegionAvailable(cloudProvider, region string, availableRegions []astra.ServerlessRegion) error {
+	if findMatchingRegion(cloudProvider, region, "serverless", availableRegions) != nil {
+		return nil
+	}
+	var valid []string
+	for _, ar := range availableRegions {
+		if strings.EqualFold(string(ar.CloudProvider), cloudProvider) {
+			valid = append(valid, ar.Name)
+		}
+	}
+	if len(valid) == 0 {
+		return fmt.Errorf("cloud provider and region combination not available: %s/%s. No regions are available for cloud provider %s", cloudProvider, region, cloudProvider)
+	}
+	sort.Strings(valid)
+	return fmt.Errorf("cloud provider and region combination not available: %s/%s. Available regions for %s: %s", cloudProvider, region, cloudProvider, strings.Join(valid, ", "))
+}
+
+// serverlessRegionsCache caches the serverless regions of the organization, so they are fetched at most once per provider instance
+type serverlessRegionsCache struct {
+	lock    sync.Mutex
+	regions []astra.ServerlessRegion
+}
+
+func (c *serverlessRegionsCache) get(ctx context.Context, client *astra.ClientWithResponses) ([]astra.ServerlessRegion, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.regions != nil {
+		return c.regions, nil
+	}
+	regionsResp, err := client.ListServerlessRegionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if regionsResp.StatusCode() != http.StatusOK || regionsResp.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected list available regions response: %s", string(regionsResp.Body))
+	}
+	c.regions = *regionsResp.JSON200
+	return c.regions, nil
+}
+
+// findMatchingRegion returns the available region of the cloud provider, the region can be written with or without dashes
 func findMatchingRegion(provider, region, tier string, availableRegions []astra.ServerlessRegion) *astra.ServerlessRegion {
 	for _, ar := range availableRegions {
 		if strings.EqualFold(string(ar.CloudProvider), provider) &&
-			strings.EqualFold(ar.Name, region) {
+			regionsEqual(ar.Name, region) {
 			return &ar
 		}
 	}
 
 	return nil
 }
+
+// serverlessRegionNames returns the regions as named by the Astra API. Classic tier regions and regions which are not
+// available are returned as configured, the Astra API reports them.
+func serverlessRegionNames(ctx context.Context, meta interface{}, tier, cloudProvider string, regions []string) ([]string, error) {
+	if !isServerlessTier(tier) {
+		return regions, nil
+	}
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	availableRegions, err := meta.(astraClients).serverlessRegions.get(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return matchRegionNames(cloudProvider, regions, availableRegions), nil
+}
+
+// matchRegionNames replaces the regions by the names of the matching available regions
+func matchRegionNames(cloudProvider string, regions []string, availableRegions []astra.ServerlessRegion) []string {
+	names := make([]string, len(regions))
+	for i, region := range regions {
+		names[i] = region
+		if ar := findMatchingRegion(cloudProvider, region, "serverless", availableRegions); ar != nil {
+			names[i] = ar.Name
+		}
+	}
+	return names
+}
+
+type createDatabaseRequest struct {
+	astra.DatabaseInfoCreate
+	DbType string `json:"dbType,omitempty"`
+}
+
+type databaseTypeResponse struct {
+	Info struct {
+		DbType string `json:"dbType"`
+	} `json:"info"`
+}
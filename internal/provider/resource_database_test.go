@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestDatabase(t *testing.T) {
@@ -148,3 +158,435 @@ func TestGetRegionUpdatesAddsAndDeletes(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGetPrimaryRegion(t *testing.T) {
+	if region, err := getPrimaryRegion("", []interface{}{"region1"}); err != nil || region != "region1" {
+		t.Fatalf("expected the only region to be the primary region, got %q, %v", region, err)
+	}
+	if region, err := getPrimaryRegion("region2", []interface{}{"region1", "region2"}); err != nil || region != "region2" {
+		t.Fatalf("expected region2 to be the primary region, got %q, %v", region, err)
+	}
+	if _, err := getPrimaryRegion("", []interface{}{"region1", "region2"}); err == nil {
+		t.Fatal("expected an error when the primary region is not set for multiple regions")
+	}
+	if _, err := getPrimaryRegion("region3", []interface{}{"region1", "region2"}); err == nil {
+		t.Fatal("expected an error when the primary region is not one of the regions")
+	}
+}
+
+func TestRegionFormats(t *testing.T) {
+	if !regionsEqual("us-east-1", "useast1") || !regionsEqual("US-East1", "us-east1") {
+		t.Fatal("expected regions written in other formats to be equal")
+	}
+	if regionsEqual("us-east1", "us-east4") {
+		t.Fatal("expected different regions not to be equal")
+	}
+	if cluster := GetPulsarCluster("gcp", "us-East1"); cluster != "pulsar-gcp-useast1" {
+		t.Fatalf("expected the cluster name pulsar-gcp-useast1, got %s", cluster)
+	}
+
+	availableRegions := []astra.ServerlessRegion{{CloudProvider: "GCP", Name: "us-east1"}, {CloudProvider: "AWS", Name: "us-east-1"}}
+	if err := checkRegionAvailable("gcp", "useast1", availableRegions); err != nil {
+		t.Fatalf("expected the region without dashes to be available, got %v", err)
+	}
+	if err := checkRegionAvailable("gcp", "useast4", availableRegions); err == nil {
+		t.Fatal("expected an error for an unavailable region")
+	}
+	if names := matchRegionNames("aws", []string{"USEast1", "eu-west-1"}, availableRegions); !reflect.DeepEqual(names, []string{"us-east-1", "eu-west-1"}) {
+		t.Fatalf("expected the region names of the Astra API, got %v", names)
+	}
+
+	regionsToAdd, regionsToDelete := getRegionUpdates([]interface{}{"us-east1", "useast4"}, []interface{}{"useast1", "us-east4"})
+	if len(regionsToAdd) != 0 || len(regionsToDelete) != 0 {
+		t.Fatalf("expected no region updates, got %v to add and %v to delete", regionsToAdd, regionsToDelete)
+	}
+	if region, err := getPrimaryRegion("US-EAST1", []interface{}{"us-east1", "us-west1"}); err != nil || region != "us-east1" {
+		t.Fatalf("expected us-east1 to be the primary region, got %q, %v", region, err)
+	}
+
+	regions := preserveRegionFormat([]interface{}{"useast1"}, []string{"us-east1", "us-west1"})
+	if len(regions) != 2 || regions[0] != "useast1" || regions[1] != "us-west1" {
+		t.Fatalf("expected the configured format of the regions, got %v", regions)
+	}
+}
+
+func TestCheckRegionQuotas(t *testing.T) {
+	limits := []astra.AvailableRegionCombination{
+		{Tier: astra.Serverless, CloudProvider: "GCP", Region: "us-east1", DatabaseCountUsed: 5, DatabaseCountLimit: 5},
+		{Tier: astra.Serverless, CloudProvider: "GCP", Region: "us-west1", DatabaseCountUsed: 1, DatabaseCountLimit: 5},
+		{Tier: "C10", CloudProvider: "GCP", Region: "us-east1", CapacityUnitsUsed: 2, CapacityUnitsLimit: 3},
+	}
+
+	if err := checkRegionQuotas(limits, "serverless", "gcp", "useast1", []string{"us-east1"}, 1); err == nil || !strings.Contains(err.Error(), "organization limit reached in region us-east1") {
+		t.Fatalf("expected the database limit of us-east1 to be reached, got %v", err)
+	}
+	if err := checkRegionQuotas(limits, "", "gcp", "us-west1", []string{"us-west1", "us-east1"}, 1); err != nil {
+		t.Fatalf("expected a region added to a new database not to count as a database, got %v", err)
+	}
+	if err := checkRegionQuotas(limits, "C10", "gcp", "us-east1", []string{"us-east1"}, 2); err == nil || !strings.Contains(err.Error(), "capacity units") {
+		t.Fatalf("expected the capacity unit limit of us-east1 to be reached, got %v", err)
+	}
+	if err := checkRegionQuotas(limits, "C10", "gcp", "us-east1", []string{"us-east1"}, 1); err != nil {
+		t.Fatalf("expected the capacity units to be available, got %v", err)
+	}
+	if err := checkRegionQuotas(limits, "serverless", "aws", "us-east-1", []string{"us-east-1"}, 1); err != nil {
+		t.Fatalf("expected regions without limits to be ignored, got %v", err)
+	}
+}
+
+func TestCreateDatabaseRequestType(t *testing.T) {
+	body, err := json.Marshal(createDatabaseRequest{
+		DatabaseInfoCreate: astra.DatabaseInfoCreate{Name: "db", Region: "us-east1"},
+		DbType:             "vector",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatal(err)
+	}
+	if req["dbType"] != "vector" || req["name"] != "db" || req["region"] != "us-east1" {
+		t.Fatalf("unexpected create database request: %s", body)
+	}
+
+	body, err = json.Marshal(createDatabaseRequest{DatabaseInfoCreate: astra.DatabaseInfoCreate{Name: "db"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "dbType") {
+		t.Fatalf("expected no database type for serverless databases: %s", body)
+	}
+}
+
+func TestAccessListAddressRequests(t *testing.T) {
+	if addresses := accessListAddressRequests(nil); addresses == nil || len(addresses) != 0 {
+		t.Fatalf("expected no addresses for a missing access list, got %v", addresses)
+	}
+	enabled := true
+	addresses := accessListAddressRequests(&astra.AccessListResponse{
+		Addresses: &[]astra.AddressResponse{
+			{Address: astra.StringPtr("10.0.0.1/32"), Description: astra.StringPtr("office"), Enabled: &enabled},
+			{Address: astra.StringPtr("10.0.0.2/32")},
+		},
+	})
+	if len(addresses) != 2 {
+		t.Fatalf("expected 2 addresses, got %v", addresses)
+	}
+	if addresses[0] != (astra.AddressRequest{Address: "10.0.0.1/32", Description: "office", Enabled: true}) {
+		t.Fatalf("unexpected address: %v", addresses[0])
+	}
+	if addresses[1] != (astra.AddressRequest{Address: "10.0.0.2/32"}) {
+		t.Fatalf("unexpected address: %v", addresses[1])
+	}
+}
+
+func TestCapacityUnitsSteps(t *testing.T) {
+	if steps := capacityUnitsSteps(1, 8); !reflect.DeepEqual(steps, []int{4, 7, 8}) {
+		t.Fatalf("unexpected resize steps: %v", steps)
+	}
+	if steps := capacityUnitsSteps(2, 3); !reflect.DeepEqual(steps, []int{3}) {
+		t.Fatalf("unexpected resize steps: %v", steps)
+	}
+	if steps := capacityUnitsSteps(3, 3); len(steps) != 0 {
+		t.Fatalf("expected no resize steps, got %v", steps)
+	}
+}
+
+func TestDatabaseStorageUsage(t *testing.T) {
+	used := 5
+	if usedStorage, percent := databaseStorageUsage(&astra.Storage{TotalStorage: 20, UsedStorage: &used}); usedStorage != 5 || percent != 25 {
+		t.Fatalf("unexpected storage usage: %d, %f", usedStorage, percent)
+	}
+	if usedStorage, percent := databaseStorageUsage(&astra.Storage{UsedStorage: &used}); usedStorage != 5 || percent != 0 {
+		t.Fatalf("unexpected storage usage without total storage: %d, %f", usedStorage, percent)
+	}
+	if usedStorage, percent := databaseStorageUsage(nil); usedStorage != 0 || percent != 0 {
+		t.Fatalf("unexpected storage usage without storage: %d, %f", usedStorage, percent)
+	}
+}
+
+func TestPreferredRegionOrder(t *testing.T) {
+	regions := []string{"us-west1", "europe-west1", "us-east1", "asia-east1"}
+	for _, tc := range []struct {
+		preferredRegions []string
+		expected         []string
+	}{
+		{nil, []string{"us-east1", "asia-east1", "europe-west1", "us-west1"}},
+		{[]string{"us-west1"}, []string{"us-west1", "us-east1", "asia-east1", "europe-west1"}},
+		{[]string{"europe-west1", "us-east1", "us-west1"}, []string{"europe-west1", "us-east1", "us-west1", "asia-east1"}},
+		{[]string{"us-central1", "us-west1"}, []string{"us-west1", "us-east1", "asia-east1", "europe-west1"}},
+	} {
+		if order := preferredRegionOrder(tc.preferredRegions, "us-east1", regions); !reflect.DeepEqual(order, tc.expected) {
+			t.Errorf("expected %v for preferred regions %v, got %v", tc.expected, tc.preferredRegions, order)
+		}
+	}
+}
+
+func TestDatabaseRegionRemovalRequiresOptIn(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	database := p.ResourcesMap["astra_database"]
+	state := &terraform.InstanceState{
+		ID: "00000000-0000-0000-0000-000000000001",
+		Attributes: map[string]string{
+			"id":                     "00000000-0000-0000-0000-000000000001",
+			"name":                   "db",
+			"keyspace":               "ks",
+			"cloud_provider":         "gcp",
+			"primary_region":         "us-east1",
+			"regions.#":              "2",
+			"regions.0":              "us-east1",
+			"regions.1":              "us-central1",
+			"tier":                   "serverless",
+			"deletion_protection":    "true",
+			"allow_region_migration": "false",
+			"allow_region_removal":   "false",
+		},
+	}
+	config := map[string]interface{}{
+		"name":           "db",
+		"keyspace":       "ks",
+		"cloud_provider": "gcp",
+		"primary_region": "us-east1",
+		"regions":        []interface{}{"us-east1"},
+	}
+
+	_, err := database.Diff(ctx, state, terraform.NewResourceConfigRaw(config), p.Meta())
+	if err == nil || !strings.Contains(err.Error(), "allow_region_removal") {
+		t.Fatalf("expected removing a region to require allow_region_removal, got %v", err)
+	}
+
+	config["allow_region_removal"] = true
+	if _, err := database.Diff(ctx, state, terraform.NewResourceConfigRaw(config), p.Meta()); err != nil {
+		t.Fatalf("expected removing a region to be allowed, got %v", err)
+	}
+}
+
+func TestProbeDatabaseEndpoint(t *testing.T) {
+	status := http.StatusBadGateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/rest/v2/schemas/keyspaces" || r.Header.Get("X-Cassandra-Token") != "token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	meta := astraClients{token: "token"}
+	for _, test := range []struct {
+		status int
+		ready  bool
+		err    bool
+	}{
+		{status: http.StatusBadGateway, ready: false},
+		{status: http.StatusServiceUnavailable, ready: false},
+		{status: http.StatusUnauthorized, err: true},
+		{status: http.StatusOK, ready: true},
+	} {
+		status = test.status
+		ready, err := probeDatabaseEndpoint(context.Background(), meta, server.Client(), server.URL+"/", cqlProbe)
+		if ready != test.ready || (err != nil) != test.err {
+			t.Fatalf("unexpected probe result for status %d: ready=%t, err=%v", test.status, ready, err)
+		}
+	}
+}
+
+func TestFindRestoreBackup(t *testing.T) {
+	backups := DatabaseBackups{
+		{ID: "b1", Status: "COMPLETED", CreatedAt: "2024-01-01T00:00:00Z", CompletedAt: "2024-01-01T01:00:00Z"},
+		{ID: "b2", Status: "COMPLETED", CreatedAt: "2024-01-02T00:00:00Z", CompletedAt: "2024-01-02T01:00:00Z"},
+		{ID: "b3", Status: "IN_PROGRESS", CreatedAt: "2024-01-03T00:00:00Z"},
+	}
+	if backupID, err := findRestoreBackup(backups, "db", ""); err != nil || backupID != "b2" {
+		t.Fatalf("expected the latest completed backup, got %q, %v", backupID, err)
+	}
+	if backupID, err := findRestoreBackup(backups, "db", "b1"); err != nil || backupID != "b1" {
+		t.Fatalf("expected the chosen backup, got %q, %v", backupID, err)
+	}
+	if _, err := findRestoreBackup(backups, "db", "b3"); err == nil || !strings.Contains(err.Error(), "IN_PROGRESS") {
+		t.Fatalf("expected an error for a backup in progress, got %v", err)
+	}
+	if _, err := findRestoreBackup(backups, "db", "b4"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected an error for a missing backup, got %v", err)
+	}
+	if _, err := findRestoreBackup(nil, "db", ""); err == nil || !strings.Contains(err.Error(), "no completed backup") {
+		t.Fatalf("expected an error for a database without backups, got %v", err)
+	}
+}
+
+func TestGetDatabasePublicAccessDisabled(t *testing.T) {
+	status := http.StatusForbidden
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"configurations":{"accessListEnabled":true}}`))
+		} else {
+			w.Write([]byte(`{"errors":[{"message":"forbidden"}]}`))
+		}
+	}))
+	defer server.Close()
+	client, err := astra.NewClientWithResponses(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reported as a warning by the read of the database
+	if _, err := getDatabasePublicAccessDisabled(context.Background(), client, "db"); !errors.Is(err, errInsufficientPermissions) {
+		t.Fatalf("expected a permission error, got %v", err)
+	}
+	status = http.StatusOK
+	if disabled, err := getDatabasePublicAccessDisabled(context.Background(), client, "db"); err != nil || !disabled {
+		t.Fatalf("expected public access to be disabled, got %t, %v", disabled, err)
+	}
+	status = http.StatusBadRequest
+	if _, err := getDatabasePublicAccessDisabled(context.Background(), client, "db"); err == nil || errors.Is(err, errInsufficientPermissions) {
+		t.Fatalf("expected an error for a bad request, got %v", err)
+	}
+}
+
+func TestDatabaseLegacyTierNotReplaced(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	database := p.ResourcesMap["astra_database"]
+	state := &terraform.InstanceState{
+		ID: "00000000-0000-0000-0000-000000000001",
+		Attributes: map[string]string{
+			"id":                     "00000000-0000-0000-0000-000000000001",
+			"name":                   "db",
+			"keyspace":               "ks",
+			"cloud_provider":         "gcp",
+			"primary_region":         "us-east1",
+			"regions.#":              "1",
+			"regions.0":              "us-east1",
+			"tier":                   "developer",
+			"deletion_protection":    "true",
+			"allow_region_migration": "false",
+			"allow_region_removal":   "false",
+		},
+	}
+	config := map[string]interface{}{
+		"name":           "db",
+		"keyspace":       "ks",
+		"cloud_provider": "gcp",
+		"regions":        []interface{}{"us-east1"},
+	}
+
+	diff, err := database.Diff(ctx, state, terraform.NewResourceConfigRaw(config), p.Meta())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && (diff.RequiresNew() || diff.Attributes["tier"] != nil) {
+		t.Fatalf("expected no change of the legacy tier, got %v", diff)
+	}
+
+	config["tier"] = "C10"
+	diff, err = database.Diff(ctx, state, terraform.NewResourceConfigRaw(config), p.Meta())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected changing the legacy tier to another tier to replace the database, got %v", diff)
+	}
+}
+
+func TestRestoreDatabaseBackupNotStarted(t *testing.T) {
+	restoreStatus := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/databases/db/restore/b1":
+			w.WriteHeader(restoreStatus)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/databases/db":
+			// The database never leaves ACTIVE
+			w.Write([]byte(`{"id":"db","orgId":"org","ownerId":"owner","status":"ACTIVE","info":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := astra.NewClientWithResponses(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := restoreStartTimeout
+	restoreStartTimeout = time.Second
+	defer func() { restoreStartTimeout = timeout }()
+
+	diags := restoreDatabaseBackup(context.Background(), nil, client, "db", "source", "b1", time.Minute)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "has not started") {
+		t.Fatalf("expected an error for a restore which never started, got %v", diags)
+	}
+
+	restoreStatus = http.StatusBadRequest
+	diags = restoreDatabaseBackup(context.Background(), nil, client, "db", "source", "b1", time.Minute)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "error restoring backup b1") {
+		t.Fatalf("expected an error for a rejected restore, got %v", diags)
+	}
+}
+
+func TestDatabaseRegionChangeFlags(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	database := p.ResourcesMap["astra_database"]
+	state := &terraform.InstanceState{
+		ID: "00000000-0000-0000-0000-000000000001",
+		Attributes: map[string]string{
+			"id":                     "00000000-0000-0000-0000-000000000001",
+			"name":                   "db",
+			"keyspace":               "ks",
+			"cloud_provider":         "gcp",
+			"primary_region":         "us-east1",
+			"regions.#":              "2",
+			"regions.0":              "us-east1",
+			"regions.1":              "us-central1",
+			"tier":                   "serverless",
+			"deletion_protection":    "true",
+			"allow_region_migration": "false",
+			"allow_region_removal":   "false",
+		},
+	}
+
+	// Either flag allows removing a region, only allow_region_migration allows changing the primary region
+	for _, tc := range []struct {
+		primaryRegion string
+		removal       bool
+		migration     bool
+		allowed       bool
+	}{
+		{primaryRegion: "us-east1", removal: false, migration: false, allowed: false},
+		{primaryRegion: "us-east1", removal: true, migration: false, allowed: true},
+		{primaryRegion: "us-east1", removal: false, migration: true, allowed: true},
+		{primaryRegion: "us-east1", removal: true, migration: true, allowed: true},
+		{primaryRegion: "us-central1", removal: false, migration: false, allowed: false},
+		{primaryRegion: "us-central1", removal: true, migration: false, allowed: false},
+		{primaryRegion: "us-central1", removal: false, migration: true, allowed: true},
+		{primaryRegion: "us-central1", removal: true, migration: true, allowed: true},
+	} {
+		config := map[string]interface{}{
+			"name":                   "db",
+			"keyspace":               "ks",
+			"cloud_provider":         "gcp",
+			"primary_region":         tc.primaryRegion,
+			"regions":                []interface{}{tc.primaryRegion},
+			"allow_region_removal":   tc.removal,
+			"allow_region_migration": tc.migration,
+		}
+		_, err := database.Diff(ctx, state, terraform.NewResourceConfigRaw(config), p.Meta())
+		if (err == nil) != tc.allowed {
+			t.Errorf("primary region %s, allow_region_removal=%t, allow_region_migration=%t: expected allowed=%t, got %v", tc.primaryRegion, tc.removal, tc.migration, tc.allowed, err)
+		}
+	}
+}
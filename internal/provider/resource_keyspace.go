@@ -4,28 +4,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// Mutex for synchronizing Keyspace creation
-var keyspaceMutex sync.Mutex
+// Mutexes for synchronizing keyspace operations, keyed by database ID. Operations on different databases can run
+// in parallel while operations on the same database are serialized to avoid 409 errors from the DevOps API.
+var keyspaceMutex = newMutexKV()
+
+var keyspaceCreateTimeout = time.Minute * 20
+var keyspaceReadTimeout = time.Minute * 5
+var keyspaceDeleteTimeout = time.Minute * 20
 
 func resourceKeyspace() *schema.Resource {
 	return &schema.Resource{
 		Description:   "`astra_keyspace` provides a keyspace resource. Keyspaces are groupings of tables for Cassandra. `astra_keyspace` resources are associated with a database id. You can have multiple keyspaces per DB in addition to the default keyspace provided in the `astra_database` resource.",
 		CreateContext: resourceKeyspaceCreate,
 		ReadContext:   resourceKeyspaceRead,
+		UpdateContext: resourceKeyspaceUpdate,
 		DeleteContext: resourceKeyspaceDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKeyspaceImport,
+		},
+
+		Identity: &schema.ResourceIdentity{
+			Version: 0,
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"database_id": {
+						Description:       "The ID of the Astra database.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"name": {
+						Description:       "The keyspace name.",
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+				}
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: &keyspaceCreateTimeout,
+			Read:   &keyspaceReadTimeout,
+			Delete: &keyspaceDeleteTimeout,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,6 +68,7 @@ func resourceKeyspace() *schema.Resource {
 				Required:         true,
 				ForceNew:         true,
 				ValidateDiagFunc: validateKeyspace,
+				DiffSuppressFunc: keyspaceNameDiffSuppress,
 			},
 			"database_id": {
 				Description:  "Astra database to create the keyspace.",
@@ -44,6 +77,15 @@ func resourceKeyspace() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.IsUUID,
 			},
+			// Optional
+			"case_sensitive": {
+				Description: "Whether the keyspace name is case sensitive. Unquoted CQL identifiers are case insensitive, so by default names that only differ by case (for example `Analytics` and `analytics`) are considered equal. Set to `true` to treat such names as different keyspaces. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"deletion_policy": deletionPolicySchema("keyspace"),
+			"max_keyspaces":   maxKeyspacesSchema(),
 		},
 	}
 }
@@ -54,11 +96,143 @@ func resourceKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta in
 	databaseID := d.Get("database_id").(string)
 	keyspaceName := d.Get("name").(string)
 
+	if err := checkKeyspaceLimit(ctx, client, databaseID, []string{keyspaceName}, d.Get("max_keyspaces").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := addKeyspace(ctx, client, d.Timeout(schema.TimeoutCreate), databaseID, keyspaceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setKeyspaceResourceData(d, databaseID, keyspaceName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The keyspace is not always immediately visible after being added, wait for it so that dependent resources
+	// (tables, CDC) can use it in the same apply.
+	if err := waitForKeyspace(ctx, client, d.Timeout(schema.TimeoutCreate), databaseID, keyspaceName, d.Get("case_sensitive").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	id := d.Id()
+	databaseID, keyspaceName, err := parseKeyspaceID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found := false
+	for _, name := range keyspaceNames(keyspaceName, d.Get("case_sensitive").(bool)) {
+		found, err = getKeyspace(ctx, client, d.Timeout(schema.TimeoutRead), databaseID, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found {
+			keyspaceName = name
+			break
+		}
+	}
+	if !found {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("keyspace %s", keyspaceName)))
+	}
+
+	if err := setKeyspaceResourceData(d, databaseID, keyspaceName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getKeyspace fetches a single keyspace of a database and returns false if it does not exist
+func getKeyspace(ctx context.Context, client *astra.ClientWithResponses, timeout time.Duration, databaseID, keyspaceName string) (bool, error) {
+	found := false
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var retryErr *retry.RetryError
+		found, retryErr = lookupKeyspace(ctx, client, databaseID, keyspaceName)
+		return retryErr
+	})
+	return found, err
+}
+
+// lookupKeyspace fetches the keyspace once, the errors which are assumed to be transient are retryable
+func lookupKeyspace(ctx context.Context, client *astra.ClientWithResponses, databaseID, keyspaceName string) (bool, *retry.RetryError) {
+	statusCode, body, err := astraAPIGet(ctx, client, fmt.Sprintf("v2/databases/%s/keyspaces/%s", databaseID, keyspaceName))
+	// Errors sending request should be retried and are assumed to be transient
+	if err != nil {
+		return false, retry.RetryableError(err)
+	}
+
+	switch {
+	case statusCode == http.StatusOK:
+		return true, nil
+	case statusCode == http.StatusNotFound:
+		return false, nil
+	case statusCode >= 500:
+		// Status code >=5xx are assumed to be transient
+		return false, retry.RetryableError(fmt.Errorf("error while fetching keyspace: %s", string(body)))
+	default:
+		return false, retry.NonRetryableError(fmt.Errorf("unexpected response fetching keyspace: %s", string(body)))
+	}
+}
+
+// keyspaceNames returns the names the keyspace can be stored with: unquoted keyspace names are stored in lower case
+func keyspaceNames(keyspaceName string, caseSensitive bool) []string {
+	if caseSensitive || strings.ToLower(keyspaceName) == keyspaceName {
+		return []string{keyspaceName}
+	}
+	return []string{keyspaceName, strings.ToLower(keyspaceName)}
+}
+
+func waitForKeyspace(ctx context.Context, client *astra.ClientWithResponses, timeout time.Duration, databaseID, keyspaceName string, caseSensitive bool) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		for _, name := range keyspaceNames(keyspaceName, caseSensitive) {
+			found, retryErr := lookupKeyspace(ctx, client, databaseID, name)
+			if retryErr != nil {
+				return retryErr
+			}
+			if found {
+				return nil
+			}
+		}
+		return retry.RetryableError(fmt.Errorf("keyspace %s is not visible yet in database %s", keyspaceName, databaseID))
+	})
+}
+
+func resourceKeyspaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// In-place update not supported. This is only here to support case_sensitive, deletion_policy and max_keyspaces
+	return nil
+}
+
+func resourceKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonedOnDelete(d) {
+		// The keyspace is kept, only remove the resource from the state
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	keyspaceName := d.Get("name").(string)
+
+	if err := dropKeyspace(ctx, client, d.Timeout(schema.TimeoutDelete), databaseID, keyspaceName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// addKeyspace waits for the database to be active and adds the keyspace, retrying concurrent modification errors
+func addKeyspace(ctx context.Context, client *astra.ClientWithResponses, timeout time.Duration, databaseID, keyspaceName string) error {
 	//Wait for DB to be in Active status
-	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-		keyspaceMutex.Lock()
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		keyspaceMutex.Lock(databaseID)
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
-		keyspaceMutex.Unlock()
+		keyspaceMutex.Unlock(databaseID)
 		// Errors sending request should be retried and are assumed to be transient
 		if err != nil {
 			return retry.RetryableError(err)
@@ -81,76 +255,35 @@ func resourceKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta in
 			// If the database reached a terminal state it will never become active
 			return retry.NonRetryableError(fmt.Errorf("database failed to reach active status: status=%s", db.Status))
 		case astra.ACTIVE:
-			keyspaceMutex.Lock()
+			keyspaceMutex.Lock(databaseID)
 			resp, err := client.AddKeyspaceWithResponse(ctx, astra.DatabaseIdParam(databaseID), astra.KeyspaceNameParam(keyspaceName))
-			keyspaceMutex.Unlock()
+			keyspaceMutex.Unlock(databaseID)
 			if err != nil {
 				return retry.NonRetryableError(fmt.Errorf("error calling add keyspace (not retrying) %s", err))
 			} else if resp.StatusCode() == 409 {
 				// DevOps API returns 409 for concurrent modifications, these need to be retried.
 				return retry.RetryableError(fmt.Errorf("error adding keyspace to database (retrying): %s", string(resp.Body)))
-			} else if resp.StatusCode() == 401 {
+			} else if err := permissionError(opAddKeyspace, resp.StatusCode(), resp.Body); err != nil {
 				// DevOps API returns 401 Unauthorized for requests without the keyspace create permission
-				return retry.NonRetryableError(fmt.Errorf("error adding keyspace to database (insufficient permissions, role missing 'db-keyspace-create')"))
+				return retry.NonRetryableError(err)
 			} else if resp.StatusCode() >= 400 {
 				return retry.NonRetryableError(fmt.Errorf("error adding keyspace to database (not retrying): %s", string(resp.Body)))
 			}
 
-			if err := setKeyspaceResourceData(d, databaseID, keyspaceName); err != nil {
-				return retry.NonRetryableError(fmt.Errorf("error setting keyspace data (not retrying) %s", err))
-			}
-
 			return nil
 		default:
 			return retry.RetryableError(fmt.Errorf("expected database to be active but is %s", db.Status))
 		}
-	}); err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	})
 }
 
-func resourceKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
-
-	id := d.Id()
-	databaseID, keyspaceName, err := parseKeyspaceID(id)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	keyspaces, err := listKeyspaces(ctx, client, databaseID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	for _, k := range keyspaces {
-		if k == keyspaceName {
-			if err := setKeyspaceResourceData(d, databaseID, keyspaceName); err != nil {
-				return diag.FromErr(err)
-			}
-			return nil
-		}
-	}
-
-	// Keyspace not found. Remove from state.
-	d.SetId("")
-
-	return nil
-}
-
-func resourceKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
-
-	databaseID := d.Get("database_id").(string)
-	keyspaceName := d.Get("name").(string)
-
+// dropKeyspace waits for the database to be active and drops the keyspace, retrying concurrent modification errors
+func dropKeyspace(ctx context.Context, client *astra.ClientWithResponses, timeout time.Duration, databaseID, keyspaceName string) error {
 	//Wait for DB to be in Active status
-	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-		keyspaceMutex.Lock()
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		keyspaceMutex.Lock(databaseID)
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
-		keyspaceMutex.Unlock()
+		keyspaceMutex.Unlock(databaseID)
 		// Errors sending request should be retried and are assumed to be transient
 		if err != nil {
 			return retry.RetryableError(err)
@@ -173,35 +306,90 @@ func resourceKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta in
 			// If the database reached a terminal state it will never become active
 			return retry.NonRetryableError(fmt.Errorf("database failed to reach active status: status=%s", db.Status))
 		case astra.ACTIVE:
-			keyspaceMutex.Lock()
+			keyspaceMutex.Lock(databaseID)
 			resp, err := client.DropKeyspaceWithResponse(ctx, astra.DatabaseIdParam(databaseID), astra.KeyspaceNameParam(keyspaceName))
-			keyspaceMutex.Unlock()
+			keyspaceMutex.Unlock(databaseID)
 			if err != nil {
 				return retry.NonRetryableError(fmt.Errorf("error calling drop keyspace (not retrying) %s", err))
 			} else if resp.StatusCode() == 409 {
 				// DevOps API returns 409 for concurrent modifications, these need to be retried.
 				return retry.RetryableError(fmt.Errorf("error dropping keyspace from database (retrying): %s", string(resp.Body)))
-			} else if resp.StatusCode() == 401 {
+			} else if err := permissionError(opDropKeyspace, resp.StatusCode(), resp.Body); err != nil {
 				// DevOps API returns 401 Unauthorized for requests without the keyspace drop permission
-				return retry.NonRetryableError(fmt.Errorf("error adding keyspace to database (insufficient permissions, role missing 'db-keyspace-drop')"))
+				return retry.NonRetryableError(err)
 			} else if resp.StatusCode() >= 400 {
 				return retry.NonRetryableError(fmt.Errorf("error dropping keyspace from database (not retrying): %s", string(resp.Body)))
 			}
 
-			d.SetId("")
 			return nil
 		default:
 			return retry.RetryableError(fmt.Errorf("expected database to be active but is %s", db.Status))
 		}
-	}); err != nil {
-		return diag.FromErr(err)
+	})
+}
+
+func maxKeyspacesSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:  "The maximum number of keyspaces of the database, including its default keyspace. When set, keyspaces are only added if the database has fewer keyspaces, so reaching the keyspace limit of the database fails with a clear error instead of an error from the DevOps API. The check is skipped when not set.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
 	}
-	d.SetId("")
-	return nil
+}
+
+// checkKeyspaceLimit returns an error if adding the keyspaces to the database would exceed maxKeyspaces. The check is
+// skipped when maxKeyspaces is not set.
+func checkKeyspaceLimit(ctx context.Context, client *astra.ClientWithResponses, databaseID string, names []string, maxKeyspaces int) error {
+	if maxKeyspaces == 0 || len(names) == 0 {
+		return nil
+	}
+	keyspaces, err := listKeyspaces(ctx, client, databaseID)
+	if err != nil {
+		return err
+	}
+	return keyspaceLimitError(databaseID, keyspaces, names, maxKeyspaces)
+}
+
+// keyspaceLimitError returns an error if the existing keyspaces and the new ones exceed maxKeyspaces. Keyspaces which
+// already exist are only counted once.
+func keyspaceLimitError(databaseID string, existing, names []string, maxKeyspaces int) error {
+	keyspaces := make(map[string]bool, len(existing)+len(names))
+	for _, k := range existing {
+		keyspaces[strings.ToLower(k)] = true
+	}
+	count := len(keyspaces)
+	for _, n := range names {
+		keyspaces[strings.ToLower(n)] = true
+	}
+	if len(keyspaces) <= maxKeyspaces {
+		return nil
+	}
+	if count >= maxKeyspaces {
+		return fmt.Errorf("database %s already has %d keyspaces and can not have more than %d (max_keyspaces), drop a keyspace before adding %s", databaseID, count, maxKeyspaces, strings.Join(names, ", "))
+	}
+	return fmt.Errorf("adding %d keyspaces to database %s, which has %d keyspaces, exceeds the limit of %d keyspaces (max_keyspaces)", len(keyspaces)-count, databaseID, count, maxKeyspaces)
+}
+
+// keyspaceNameDiffSuppress suppresses keyspace name diffs that only differ by case, unless case_sensitive is set
+func keyspaceNameDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("case_sensitive").(bool) {
+		return false
+	}
+	return strings.EqualFold(old, new)
 }
 
 func setKeyspaceResourceData(d *schema.ResourceData, databaseID string, keyspaceName string) error {
-	d.SetId(fmt.Sprintf("%s/keyspace/%s", databaseID, keyspaceName))
+	d.SetId(resourceid.Keyspace.Format(databaseID, keyspaceName))
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	if err := identity.Set("database_id", databaseID); err != nil {
+		return err
+	}
+	if err := identity.Set("name", keyspaceName); err != nil {
+		return err
+	}
 	if err := d.Set("name", keyspaceName); err != nil {
 		return err
 	}
@@ -212,10 +400,29 @@ func setKeyspaceResourceData(d *schema.ResourceData, databaseID string, keyspace
 	return nil
 }
 
+// resourceKeyspaceImport imports a keyspace by its ID, database_id/keyspace/name, or by its identity
+func resourceKeyspaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != "" {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return nil, err
+	}
+	databaseID := identity.Get("database_id").(string)
+	keyspaceName := identity.Get("name").(string)
+	if databaseID == "" || keyspaceName == "" {
+		return nil, errors.New("expected identity to contain database_id and name")
+	}
+	d.SetId(resourceid.Keyspace.Format(databaseID, keyspaceName))
+	return []*schema.ResourceData{d}, nil
+}
+
 func parseKeyspaceID(id string) (string, string, error) {
-	idParts := strings.Split(id, "/keyspace/")
-	if len(idParts) != 2 {
-		return "", "", errors.New("invalid keyspace id format: expected database_id/keyspace")
+	parts, err := resourceid.Keyspace.Parse(id)
+	if err != nil {
+		return "", "", err
 	}
-	return idParts[0], idParts[1], nil
+	return parts[0], parts[1], nil
 }
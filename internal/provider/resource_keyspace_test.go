@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestKeyspace(t *testing.T) {
@@ -46,3 +52,51 @@ resource "astra_keyspace" "keyspace-3" {
 
 `, databaseID, databaseID, databaseID)
 }
+
+func TestKeyspaceLimitError(t *testing.T) {
+	existing := []string{"default_keyspace", "ks1"}
+	if err := keyspaceLimitError("db", existing, []string{"ks2"}, 3); err != nil {
+		t.Fatalf("expected the keyspace to fit in the limit, got %v", err)
+	}
+	if err := keyspaceLimitError("db", existing, []string{"KS1"}, 2); err != nil {
+		t.Fatalf("expected an existing keyspace not to be counted twice, got %v", err)
+	}
+	if err := keyspaceLimitError("db", existing, []string{"ks2"}, 2); err == nil || !strings.Contains(err.Error(), "already has 2 keyspaces") {
+		t.Fatalf("expected a full database error, got %v", err)
+	}
+	if err := keyspaceLimitError("db", existing, []string{"ks2", "ks3"}, 3); err == nil || !strings.Contains(err.Error(), "adding 2 keyspaces") {
+		t.Fatalf("expected an exceeded limit error, got %v", err)
+	}
+}
+
+func TestMockWaitForMixedCaseKeyspace(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	client := p.Meta().(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	// Unquoted keyspace names are stored in lower case
+	resp, err := client.CreateDatabaseWithResponse(ctx, astra.CreateDatabaseJSONRequestBody{
+		Name:          "ksdb",
+		Keyspace:      "analytics",
+		CloudProvider: "gcp",
+		Region:        "us-east1",
+		Tier:          astra.Serverless,
+		CapacityUnits: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 creating database, got %d: %s", resp.StatusCode(), resp.Body)
+	}
+	databaseID := resp.HTTPResponse.Header.Get("Location")
+
+	if err := waitForKeyspace(ctx, client, time.Minute, databaseID, "Analytics", false); err != nil {
+		t.Fatalf("expected the keyspace to be found in lower case, got %v", err)
+	}
+	if err := waitForKeyspace(ctx, client, time.Second, databaseID, "Analytics", true); err == nil {
+		t.Fatal("expected a case sensitive keyspace name not to be found")
+	}
+}
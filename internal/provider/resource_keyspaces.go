@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var keyspacesCreateTimeout = time.Minute * 60
+var keyspacesReadTimeout = time.Minute * 5
+var keyspacesUpdateTimeout = time.Minute * 60
+var keyspacesDeleteTimeout = time.Minute * 60
+
+func resourceKeyspaces() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_keyspaces` manages a set of keyspaces in one Astra database. Keyspaces are added and dropped one at a time, which avoids the concurrent modification errors seen when many `astra_keyspace` resources target the same database. The default keyspace of the database must not be included.",
+		CreateContext: resourceKeyspacesCreate,
+		ReadContext:   resourceKeyspacesRead,
+		UpdateContext: resourceKeyspacesUpdate,
+		DeleteContext: resourceKeyspacesDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeyspacesImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: &keyspacesCreateTimeout,
+			Read:   &keyspacesReadTimeout,
+			Update: &keyspacesUpdateTimeout,
+			Delete: &keyspacesDeleteTimeout,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"database_id": {
+				Description:  "Astra database to create the keyspaces in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"names": {
+				Description: "Names of the keyspaces. Keyspace names can have up to 48 alpha-numeric characters and contain underscores; only letters and numbers are supported as the first character.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateKeyspace,
+				},
+			},
+			// Optional
+			"max_keyspaces": maxKeyspacesSchema(),
+		},
+	}
+}
+
+func resourceKeyspacesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	names := expandKeyspaceNames(d.Get("names").(*schema.Set))
+
+	if err := checkKeyspaceLimit(ctx, client, databaseID, names, d.Get("max_keyspaces").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(resourceid.Keyspaces.Format(databaseID))
+	if err := addKeyspaces(ctx, client, d.Timeout(schema.TimeoutCreate), databaseID, names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeyspacesRead(ctx, d, meta)
+}
+
+func resourceKeyspacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID, err := parseKeyspacesID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keyspaces, err := listKeyspaces(ctx, client, databaseID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	existing := make(map[string]bool, len(keyspaces))
+	for _, k := range keyspaces {
+		existing[k] = true
+	}
+
+	// Only keep the managed keyspaces which still exist, with their configured names
+	names := make([]string, 0)
+	for _, n := range expandKeyspaceNames(d.Get("names").(*schema.Set)) {
+		if keyspaceExists(existing, n) {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		// None of the keyspaces exist anymore
+		return readError(ctx, d, notFoundError(fmt.Sprintf("keyspaces of database %s", databaseID)))
+	}
+
+	if err := d.Set("database_id", databaseID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeyspacesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+
+	if d.HasChange("names") {
+		o, n := d.GetChange("names")
+		oldNames := o.(*schema.Set)
+		newNames := n.(*schema.Set)
+
+		if err := dropKeyspaces(ctx, client, d.Timeout(schema.TimeoutUpdate), databaseID, expandKeyspaceNames(oldNames.Difference(newNames))); err != nil {
+			return diag.FromErr(err)
+		}
+		namesToAdd := expandKeyspaceNames(newNames.Difference(oldNames))
+		if err := checkKeyspaceLimit(ctx, client, databaseID, namesToAdd, d.Get("max_keyspaces").(int)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := addKeyspaces(ctx, client, d.Timeout(schema.TimeoutUpdate), databaseID, namesToAdd); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeyspacesRead(ctx, d, meta)
+}
+
+func resourceKeyspacesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	databaseID := d.Get("database_id").(string)
+	names := expandKeyspaceNames(d.Get("names").(*schema.Set))
+
+	if err := dropKeyspaces(ctx, client, d.Timeout(schema.TimeoutDelete), databaseID, names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKeyspacesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	// The keyspaces are listed in the import ID, since other keyspaces of the database can be managed by astra_keyspace
+	parts, err := resourceid.KeyspacesImport.Parse(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	databaseID := parts[0]
+	names := strings.Split(parts[1], ",")
+
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return nil, err
+	} else if resp.JSON200 == nil {
+		return nil, fmt.Errorf("error fetching database: %s", string(resp.Body))
+	}
+
+	// The default keyspace is managed by astra_database
+	existing := map[string]bool{}
+	for _, k := range astra.StringSlice(resp.JSON200.Info.AdditionalKeyspaces) {
+		existing[k] = true
+	}
+	for _, name := range names {
+		if !keyspaceExists(existing, name) {
+			return nil, fmt.Errorf("keyspace %s is not an additional keyspace of database %s", name, databaseID)
+		}
+	}
+
+	d.SetId(resourceid.Keyspaces.Format(databaseID))
+	if err := d.Set("database_id", databaseID); err != nil {
+		return nil, err
+	}
+	if err := d.Set("names", names); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// addKeyspaces adds the keyspaces one at a time and waits for each of them to be visible
+func addKeyspaces(ctx context.Context, client *astra.ClientWithResponses, timeout time.Duration, databaseID string, names []string) error {
+	for _, name := range names {
+		if err := addKeyspace(ctx, client, timeout, databaseID, name); err != nil {
+			return fmt.Errorf("error adding keyspace %s: %w", name, err)
+		}
+		if err := waitForKeyspace(ctx, client, timeout, databaseID, name, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropKeyspaces drops the keyspaces one at a time
+func dropKeyspaces(ctx context.Context, client *astra.ClientWithResponses, timeout time.Duration, databaseID string, names []string) error {
+	for _, name := range names {
+		if err := dropKeyspace(ctx, client, timeout, databaseID, name); err != nil {
+			return fmt.Errorf("error dropping keyspace %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// keyspaceExists returns whether the keyspace is one of the existing keyspaces, also in lower case since unquoted
+// keyspace names are stored in lower case
+func keyspaceExists(existing map[string]bool, name string) bool {
+	for _, n := range keyspaceNames(name, false) {
+		if existing[n] {
+			return true
+		}
+	}
+	return false
+}
+
+func expandKeyspaceNames(s *schema.Set) []string {
+	names := make([]string, 0, s.Len())
+	for _, n := range s.List() {
+		names = append(names, n.(string))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseKeyspacesID(id string) (string, error) {
+	parts, err := resourceid.Keyspaces.Parse(id)
+	if err != nil {
+		return "", err
+	}
+	return parts[0], nil
+}
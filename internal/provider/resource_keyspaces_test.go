@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKeyspaces(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyspacesConfiguration(databaseID, `"bulk1", "bulk2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_keyspaces.bulk", "names.#", "2"),
+				),
+			},
+			{
+				Config: testAccKeyspacesConfiguration(databaseID, `"bulk1", "bulk3", "bulk4"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_keyspaces.bulk", "names.#", "3"),
+					resource.TestCheckTypeSetElemAttr("astra_keyspaces.bulk", "names.*", "bulk3"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccKeyspacesConfiguration(databaseID, names string) string {
+	return fmt.Sprintf(`
+resource "astra_keyspaces" "bulk" {
+  database_id = "%s"
+  names       = [%s]
+}
+`, databaseID, names)
+}
+
+func TestMockMixedCaseKeyspaces(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"mock": true})); diags.HasError() {
+		t.Fatalf("failed to configure mock provider: %v", diags)
+	}
+	client := p.Meta().(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	resp, err := client.CreateDatabaseWithResponse(ctx, astra.CreateDatabaseJSONRequestBody{
+		Name:          "ksdb",
+		Keyspace:      "default_keyspace",
+		CloudProvider: "gcp",
+		Region:        "us-east1",
+		Tier:          astra.Serverless,
+		CapacityUnits: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected status 201 creating database, got %d: %s", resp.StatusCode(), resp.Body)
+	}
+	databaseID := resp.HTTPResponse.Header.Get("Location")
+
+	keyspaces := resourceKeyspaces()
+	d := schema.TestResourceDataRaw(t, keyspaces.Schema, map[string]interface{}{
+		"database_id": databaseID,
+		"names":       []interface{}{"Analytics", "reports"},
+	})
+	if diags := resourceKeyspacesCreate(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the keyspaces to stay in state")
+	}
+	if names := expandKeyspaceNames(d.Get("names").(*schema.Set)); len(names) != 2 || names[0] != "Analytics" || names[1] != "reports" {
+		t.Fatalf("expected the configured names to be kept, got %v", names)
+	}
+
+	d = schema.TestResourceDataRaw(t, keyspaces.Schema, map[string]interface{}{})
+	d.SetId(databaseID + "/keyspaces/Analytics")
+	if _, err := resourceKeyspacesImport(ctx, d, p.Meta()); err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if names := expandKeyspaceNames(d.Get("names").(*schema.Set)); len(names) != 1 || names[0] != "Analytics" {
+		t.Fatalf("expected only the listed keyspace to be imported, got %v", names)
+	}
+	for _, id := range []string{databaseID + "/keyspaces", databaseID + "/keyspaces/missing", databaseID + "/keyspaces/default_keyspace"} {
+		d.SetId(id)
+		if _, err := resourceKeyspacesImport(ctx, d, p.Meta()); err == nil {
+			t.Fatalf("expected import of %s to fail", id)
+		}
+	}
+}
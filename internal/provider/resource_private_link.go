@@ -2,16 +2,21 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"regexp"
+	"net/http"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var privateLinkCreateTimeout = time.Minute * 10
+var privateLinkDeleteTimeout = time.Minute * 10
+
 func resourcePrivateLink() *schema.Resource {
 	return &schema.Resource{
 		Description:   "`astra_private_link` provides a private link resource. Private Link is a private network endpoint that can be created to connect from your vpc to Astra without using a publicly routable IP address. `astra_private_link` resources are associated with a database id. Once the private_link resource is created in Astra it must be linked to an endpoint within your vpc, use `astra_private_link_endpoint` to do this.",
@@ -23,6 +28,11 @@ func resourcePrivateLink() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: &privateLinkCreateTimeout,
+			Delete: &privateLinkDeleteTimeout,
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"allowed_principals": {
@@ -69,18 +79,27 @@ func resourcePrivateLinkCreate(ctx context.Context, d *schema.ResourceData, meta
 		allowedPrincipals = append(allowedPrincipals, apString)
 	}
 
-	resp, err := client.AddAllowedPrincipalToServiceWithResponse(ctx,
-		databaseID,
-		datacenterID,
-		astra.AddAllowedPrincipalToServiceJSONRequestBody{
-			AllowedPrincipals: &allowedPrincipals,
-		},
-	)
-
-	if err != nil {
+	// The private link service may still be provisioning, which is reported as a conflict
+	var resp *astra.AddAllowedPrincipalToServiceResponse
+	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		var err error
+		resp, err = client.AddAllowedPrincipalToServiceWithResponse(ctx,
+			databaseID,
+			datacenterID,
+			astra.AddAllowedPrincipalToServiceJSONRequestBody{
+				AllowedPrincipals: &allowedPrincipals,
+			},
+		)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		} else if resp.StatusCode() == http.StatusConflict || resp.StatusCode() >= http.StatusInternalServerError {
+			return retry.RetryableError(fmt.Errorf("error adding private link to database: %s", string(resp.Body)))
+		} else if resp.StatusCode() >= 400 || resp.JSON200 == nil {
+			return retry.NonRetryableError(fmt.Errorf("error adding private link to database: %s", string(resp.Body)))
+		}
+		return nil
+	}); err != nil {
 		return diag.FromErr(err)
-	} else if resp.StatusCode() >= 400 {
-		return diag.Errorf("error adding private link to database: %s", string(resp.Body))
 	}
 
 	pl := resp.JSON200
@@ -112,13 +131,21 @@ func resourcePrivateLinkDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	if string(*privateLinks.ServiceName) == serviceName {
 		for _, allowedPrincipal := range *privateLinks.AllowedPrincipals {
-			resp, err := client.RemoveAllowedPrincipalFromServiceWithResponse(ctx, databaseID, datacenterID, astra.PrivateLinkDeleteConfigInput{
-				AllowedPrincipal: &allowedPrincipal,
-			})
-			if err != nil {
+			principal := allowedPrincipal
+			if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+				resp, err := client.RemoveAllowedPrincipalFromServiceWithResponse(ctx, databaseID, datacenterID, astra.PrivateLinkDeleteConfigInput{
+					AllowedPrincipal: &principal,
+				})
+				if err != nil {
+					return retry.NonRetryableError(err)
+				} else if resp.StatusCode() == http.StatusConflict || resp.StatusCode() >= http.StatusInternalServerError {
+					return retry.RetryableError(fmt.Errorf("error removing allowed principal \"%s\" from private link: %s", principal, string(resp.Body)))
+				} else if resp.StatusCode() >= 400 {
+					return retry.NonRetryableError(fmt.Errorf("error removing allowed principal \"%s\" from private link: %s", principal, string(resp.Body)))
+				}
+				return nil
+			}); err != nil {
 				return diag.FromErr(err)
-			} else if resp.StatusCode() >= 400 {
-				return diag.Errorf("error removing allowed principal \"%s\" from private link: %s", allowedPrincipal, string(resp.Body))
 			}
 			// update the allowed principal list
 			diagErr := resourcePrivateLinkRead(ctx, d, meta)
@@ -142,10 +169,11 @@ func resourcePrivateLinkRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	privateLinks, err := listPrivateLinks(ctx, client, databaseID, datacenterID)
 	if err != nil {
-		return diag.FromErr(err)
+		return readError(ctx, d, err)
 	}
 
-	if string(*privateLinks.ServiceName) == serviceName {
+	// The private links are nil when the database is terminated
+	if privateLinks != nil && privateLinks.ServiceName != nil && string(*privateLinks.ServiceName) == serviceName {
 		if err := setPrivateLinkData(d, databaseID, datacenterID, serviceName, privateLinks.AllowedPrincipals); err != nil {
 			return diag.FromErr(err)
 		}
@@ -159,7 +187,7 @@ func resourcePrivateLinkRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func setPrivateLinkData(d *schema.ResourceData, databaseID string, datacenterID string, serviceName string, allowedPrincipals *astra.AllowedPrincipals) error {
-	d.SetId(fmt.Sprintf("%s/datacenter/%s/serviceNames/%s", databaseID, datacenterID, serviceName))
+	d.SetId(resourceid.PrivateLink.Format(databaseID, datacenterID, serviceName))
 
 	if err := d.Set("database_id", databaseID); err != nil {
 		return err
@@ -180,13 +208,9 @@ func setPrivateLinkData(d *schema.ResourceData, databaseID string, datacenterID
 }
 
 func parsePrivateLinkID(id string) (string, string, string, error) {
-	re := regexp.MustCompile(`(?P<databaseid>.*)/datacenter/(?P<datacenterid>.*)/serviceNames/(?P<servicename>.*)`)
-	if !re.MatchString(id) {
-		return "", "", "", errors.New("invalid private link id format: expected dataceneter/servicenames")
+	parts, err := resourceid.PrivateLink.Parse(id)
+	if err != nil {
+		return "", "", "", err
 	}
-	matches := re.FindStringSubmatch(id)
-	dbIdIndex := re.SubexpIndex("databaseid")
-	dcIdIndex := re.SubexpIndex("datacenterid")
-	svcNameIndex := re.SubexpIndex("servicename")
-	return matches[dbIdIndex], matches[dcIdIndex], matches[svcNameIndex], nil
+	return parts[0], parts[1], parts[2], nil
 }
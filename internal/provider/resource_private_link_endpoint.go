@@ -2,12 +2,10 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"regexp"
 
 	"github.com/datastax/astra-client-go/v2/astra"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -134,16 +132,14 @@ func resourcePrivateLinkEndpointRead(ctx context.Context, d *schema.ResourceData
 
 	privateLinks, err := listPrivateLinkEndpoints(ctx, client, databaseID, datacenterID, astraEndpointIDStr)
 	if err != nil {
-		return diag.FromErr(err)
+		return readError(ctx, d, err)
 	}
 
 	if privateLinks == nil {
-		tflog.Warn(ctx, fmt.Sprintf("privateLinks was nil. DatabaseID: %s, DatacenterID: %s, endpointID: %s, astraEndpointID: %s", databaseID, datacenterID, endpointID, astraEndpointIDStr))
-		d.SetId("")
-		return nil
+		return readError(ctx, d, notFoundError(fmt.Sprintf("private link endpoint %s of datacenter %s", astraEndpointIDStr, datacenterID)))
 	}
 
-	if string(*privateLinks.EndpointID) == astraEndpointIDStr {
+	if privateLinks.EndpointID != nil && string(*privateLinks.EndpointID) == astraEndpointIDStr {
 		if err := setPrivateLinkEndpointData(d, databaseID, datacenterID, endpointID, astraEndpointIDStr); err != nil {
 			return diag.FromErr(err)
 		}
@@ -157,7 +153,7 @@ func resourcePrivateLinkEndpointRead(ctx context.Context, d *schema.ResourceData
 }
 
 func setPrivateLinkEndpointData(d *schema.ResourceData, databaseID string, datacenterID string, endpointID string, astraEndpointID string) error {
-	d.SetId(fmt.Sprintf("%s/datacenter/%s/endpoint/%s", databaseID, datacenterID, endpointID))
+	d.SetId(resourceid.PrivateLinkEndpoint.Format(databaseID, datacenterID, endpointID))
 
 	if err := d.Set("database_id", databaseID); err != nil {
 		return err
@@ -175,13 +171,9 @@ func setPrivateLinkEndpointData(d *schema.ResourceData, databaseID string, datac
 }
 
 func parsePrivateLinkEndpointID(id string) (string, string, string, error) {
-	re := regexp.MustCompile(`(?P<databaseid>.*)/datacenter/(?P<datacenterid>.*)/endpoint/(?P<endpointid>.*)`)
-	if !re.MatchString(id) {
-		return "", "", "", errors.New("invalid private link id format: expected dataceneter/endpoint")
-	}
-	matches := re.FindStringSubmatch(id)
-	dbIdIndex := re.SubexpIndex("databaseid")
-	dcIdIndex := re.SubexpIndex("datacenterid")
-	epIdIndex := re.SubexpIndex("endpointid")
-	return matches[dbIdIndex], matches[dcIdIndex], matches[epIdIndex], nil
+	parts, err := resourceid.PrivateLinkEndpoint.Parse(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], nil
 }
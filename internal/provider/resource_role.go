@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,9 +20,12 @@ func resourceRole() *schema.Resource {
 		ReadContext:   resourceRoleRead,
 		DeleteContext: resourceRoleDelete,
 		UpdateContext: resourceRoleUpdate,
+		CustomizeDiff: customdiff.ComputedIf("policy_document", func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) bool {
+			return diff.HasChanges("effect", "policy", "resources")
+		}),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRoleImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,6 +64,11 @@ func resourceRole() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"policy_document": {
+				Description: "Canonical JSON of the effective policy of the role: its effect and its sorted actions and resources. It can be compared between roles, audited, or decoded with `jsondecode` to create a similar role.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"role_id": {
 				Description: "Role ID, system generated",
 				Type:        schema.TypeString,
@@ -104,6 +115,8 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	if err != nil {
 		return diag.FromErr(err)
+	} else if err := permissionError(opCreateRole, resp.StatusCode(), resp.Body); err != nil {
+		return diag.FromErr(err)
 	} else if resp.StatusCode() >= 400 {
 		return diag.Errorf("error adding role to org: Status: %s, %s", resp.Status(), resp.Body)
 	}
@@ -145,7 +158,7 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	role, err := listRole(ctx, client, roleID)
 	if err != nil {
-		return diag.FromErr(err)
+		return readError(ctx, d, err)
 	}
 
 	d.SetId(roleID)
@@ -205,20 +218,75 @@ func resourceRoleUpdate(ctx context.Context, resourceData *schema.ResourceData,
 	if err != nil {
 		revertRole(oldRole, resourceData)
 		return diag.FromErr(err)
+	} else if err := permissionError(opUpdateRole, resp.StatusCode(), resp.Body); err != nil {
+		revertRole(oldRole, resourceData)
+		return diag.FromErr(err)
 	} else if resp.StatusCode() >= 400 {
 		revertRole(oldRole, resourceData)
 		return diag.Errorf("error adding role to org: Status: %s, %s", resp.Status(), resp.Body)
 	}
 
+	policyDocument, err := rolePolicyDocument(policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := resourceData.Set("policy_document", policyDocument); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
+// roleImportNamePrefix marks an import ID which is the name of the role instead of its ID
+const roleImportNamePrefix = "name/"
+
+// resourceRoleImport imports a role by its ID, or by its name with an import ID of name/<role-name>
+func resourceRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.HasPrefix(d.Id(), roleImportNamePrefix) {
+		return []*schema.ResourceData{d}, nil
+	}
+	roleName := strings.TrimPrefix(d.Id(), roleImportNamePrefix)
+
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	resp, err := client.GetOrganizationRolesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("error listing roles: %s", string(resp.Body))
+	}
+
+	roleID, err := findRoleIDByName(getRoleSlice(resp.JSON200), roleName)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(roleID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// findRoleIDByName returns the ID of the only role with the name. Role names are not unique, so a name shared by
+// several roles is an error.
+func findRoleIDByName(roles []astra.Role, roleName string) (string, error) {
+	var roleIDs []string
+	for _, role := range roles {
+		if role.Id != nil && astra.StringValue(role.Name) == roleName {
+			roleIDs = append(roleIDs, *role.Id)
+		}
+	}
+	switch len(roleIDs) {
+	case 0:
+		return "", fmt.Errorf("role %q not found", roleName)
+	case 1:
+		return roleIDs[0], nil
+	default:
+		return "", fmt.Errorf("%d roles are named %q, import one of them by its ID: %s", len(roleIDs), roleName, strings.Join(roleIDs, ", "))
+	}
+}
+
 func parseRoleID(id string) (string, error) {
-	idParts := strings.Split(strings.ToLower(id), "/")
-	if len(idParts) != 1 {
-		return "", errors.New("invalid role id format: expected roleID/")
+	parts, err := resourceid.Role.Parse(id)
+	if err != nil {
+		return "", err
 	}
-	return idParts[0], nil
+	return parts[0], nil
 }
 
 func revertRole(oldRole *astra.Role, resourceData *schema.ResourceData) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -21,6 +22,11 @@ func TestRole(t *testing.T) {
 			{
 				Config: testAccBiggerRoleConfiguration(),
 			},
+			{
+				ResourceName:  "astra_role.role",
+				ImportState:   true,
+				ImportStateId: "name/terraform-test-role",
+			},
 		},
 	})
 }
@@ -28,7 +34,7 @@ func TestRole(t *testing.T) {
 func testAccRoleConfiguration() string {
 	return fmt.Sprintf(`
 resource "astra_role" "example" {
-  role_name = "puppies"
+  role_name = "terraform-test-puppies"
   description = "test role"
   effect = "allow"
   resources = ["drn:astra:org:f9f4b1e0-4c05-451e-9bba-d631295a7f73"]
@@ -40,7 +46,7 @@ resource "astra_role" "example" {
 func testAccBiggerRoleConfiguration() string {
 	return fmt.Sprintf(`
 resource "astra_role" "role" {
-  role_name   = "name"
+  role_name   = "terraform-test-role"
   description = "desc"
   effect      = "allow"
   resources = [
@@ -56,3 +62,36 @@ resource "astra_role" "role" {
 }
 `)
 }
+
+func TestFindRoleIDByName(t *testing.T) {
+	roles := []astra.Role{
+		{Id: astra.StringPtr("id1"), Name: astra.StringPtr("admin")},
+		{Id: astra.StringPtr("id2"), Name: astra.StringPtr("reader")},
+		{Id: astra.StringPtr("id3"), Name: astra.StringPtr("reader")},
+	}
+	if roleID, err := findRoleIDByName(roles, "admin"); err != nil || roleID != "id1" {
+		t.Fatalf("expected role id1, got %q, %v", roleID, err)
+	}
+	if _, err := findRoleIDByName(roles, "writer"); err == nil {
+		t.Fatal("expected an error for a missing role")
+	}
+	if _, err := findRoleIDByName(roles, "reader"); err == nil {
+		t.Fatal("expected an error for a role name shared by several roles")
+	}
+}
+
+func TestRolePolicyDocument(t *testing.T) {
+	document, err := rolePolicyDocument(astra.Policy{
+		Actions:     []astra.PolicyAction{"db-table-select", "db-keyspace-describe", "db-cql"},
+		Description: "ignored",
+		Effect:      astra.Allow,
+		Resources:   []string{"drn:astra:org:org-id:db:db-id", "drn:astra:org:org-id"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"effect":"allow","actions":["db-cql","db-keyspace-describe","db-table-select"],"resources":["drn:astra:org:org-id","drn:astra:org:org-id:db:db-id"]}`
+	if document != expected {
+		t.Errorf("expected %s, got %s", expected, document)
+	}
+}
@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// astraDBSinkName is the name of the builtin Astra DB sink, which is also used as the name of the sink
+const astraDBSinkName = "astra-db"
+
+func resourceStreamingAstraDBSink() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_streaming_astra_db_sink` creates an Astra DB sink which writes the messages of a topic, like the data topic of `astra_cdc`, to a table of an Astra database. Use `astra_streaming_sink` for other sink connectors.",
+		CreateContext: resourceStreamingAstraDBSinkCreate,
+		ReadContext:   resourceStreamingAstraDBSinkRead,
+		UpdateContext: resourceStreamingAstraDBSinkUpdate,
+		DeleteContext: resourceStreamingAstraDBSinkDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStreamingAstraDBSinkImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"tenant_name": {
+				Description:      "Streaming tenant name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			"cloud_provider": {
+				Description:  "Cloud provider of the streaming tenant.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(availableCloudProviders, true),
+			},
+			"region": {
+				Description:      "Cloud region of the streaming tenant.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignoreRegionFormat,
+			},
+			"namespace": {
+				Description:      "Pulsar namespace the sink runs in.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingNamespaceName,
+			},
+			"topic": {
+				Description: "Fully qualified name of the topic consumed by the sink, for example `persistent://tenant/namespace/topic`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"database_id": {
+				Description:  "Astra database the messages are written to.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"keyspace": {
+				Description:      "Keyspace of the table.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			"table": {
+				Description:      "Table the messages are written to.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateCQLIdentifier,
+			},
+			"mapping": {
+				Description: "Mapping of table columns to message fields, for example `{ id = \"key.id\", name = \"value.name\" }`.",
+				Type:        schema.TypeMap,
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Optional
+			"astra_token": {
+				Description: "Astra token used by the sink to write to the database. Defaults to the token of the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+			},
+			"parallelism": {
+				Description: "Number of sink instances.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     1,
+			},
+			"processing_guarantees": {
+				Description:  "Processing guarantees of the sink, `ATLEAST_ONCE`, `ATMOST_ONCE` or `EFFECTIVELY_ONCE`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "ATLEAST_ONCE",
+				ValidateFunc: validation.StringInSlice([]string{"ATLEAST_ONCE", "ATMOST_ONCE", "EFFECTIVELY_ONCE"}, false),
+			},
+			"retain_ordering": {
+				Description: "Whether or not to retain the ordering of the messages.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"auto_ack": {
+				Description: "Whether or not messages are acknowledged automatically.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"deletion_protection": {
+				Description: "Whether or not to allow Terraform to destroy this sink. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceStreamingAstraDBSinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName := d.Get("tenant_name").(string)
+	namespace := d.Get("namespace").(string)
+
+	pulsarCluster, pulsarToken, err := getStreamingTenantPulsarToken(ctx, meta, d.Get("cloud_provider").(string), d.Get("region").(string), tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	astraToken := d.Get("astra_token").(string)
+	if astraToken == "" {
+		astraToken = meta.(astraClients).token
+	}
+	spec := streamingSinkSpec{
+		Namespace: namespace,
+		SinkName:  astraDBSinkName,
+		Topic:     d.Get("topic").(string),
+		Configs: map[string]interface{}{
+			"astraToken": astraToken,
+			"databaseId": d.Get("database_id").(string),
+			"keyspace":   d.Get("keyspace").(string),
+			"tableName":  d.Get("table").(string),
+			"mapping":    formatAstraDBSinkMapping(d.Get("mapping").(map[string]interface{})),
+		},
+		Parallelism:          int32(d.Get("parallelism").(int)),
+		ProcessingGuarantees: d.Get("processing_guarantees").(string),
+		RetainOrdering:       d.Get("retain_ordering").(bool),
+		AutoAck:              d.Get("auto_ack").(bool),
+	}
+	if err := createStreamingSink(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenantName, spec); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resourceid.StreamingSink.Format(tenantName, namespace, astraDBSinkName))
+	return resourceStreamingAstraDBSinkRead(ctx, d, meta)
+}
+
+func resourceStreamingAstraDBSinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, namespace, sinkName, err := parseStreamingSinkID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pulsarCluster, pulsarToken, err := getStreamingTenantPulsarToken(ctx, meta, d.Get("cloud_provider").(string), d.Get("region").(string), tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getSinksParams := astrastreaming.GetSinksParams{
+		XDataStaxPulsarCluster: pulsarCluster,
+		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
+	}
+	getSinkResponse, err := streamingClientv3.GetSinksWithResponse(ctx, tenantName, namespace, sinkName, &getSinksParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkReadStatus(getSinkResponse.StatusCode(), getSinkResponse.Body, fmt.Sprintf("sink %s", sinkName)); err != nil {
+		return readError(ctx, d, err)
+	}
+
+	var sinkResponse SinkResponse
+	if err := json.Unmarshal(getSinkResponse.Body, &sinkResponse); err != nil {
+		return diag.Errorf("failed to decode sink %s: %s", sinkName, err)
+	}
+
+	if err := d.Set("tenant_name", tenantName); err != nil {
+		return diag.FromErr(err)
+	}
+	// The topic is only read back when it is not known yet (on import), since the API returns it in a normalized form
+	if d.Get("topic").(string) == "" && len(sinkResponse.Inputs) > 0 {
+		if err := d.Set("topic", sinkResponse.Inputs[0]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := setStreamingSinkConfig(d, &sinkResponse); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setAstraDBSinkConfigs(d, sinkResponse.Configs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStreamingAstraDBSinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// In-place update not supported. This is only here to support deletion_protection
+	return nil
+}
+
+func resourceStreamingAstraDBSinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if protectedFromDelete(d) {
+		return diag.Errorf("\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_streaming_astra_db_sink")
+	}
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, namespace, sinkName, err := parseStreamingSinkID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pulsarCluster, pulsarToken, err := getStreamingTenantPulsarToken(ctx, meta, d.Get("cloud_provider").(string), d.Get("region").(string), tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := deleteStreamingSink(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenantName, namespace, sinkName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceStreamingAstraDBSinkImport imports a sink from tenant/namespace/astra-db. The cloud provider and region are
+// read from the tenant and the rest of the sink configuration is read back from the sink.
+func resourceStreamingAstraDBSinkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName, _, sinkName, err := parseStreamingSinkID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if sinkName != astraDBSinkName {
+		return nil, fmt.Errorf("invalid sink name %s: expected %s", sinkName, astraDBSinkName)
+	}
+
+	if err := setStreamingTenantLocation(ctx, d, client, streamingClient, tenantName); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// formatAstraDBSinkMapping formats the column mapping in the `column=field, column=field` format of the sink
+func formatAstraDBSinkMapping(mapping map[string]interface{}) string {
+	columns := make([]string, 0, len(mapping))
+	for column := range mapping {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	pairs := make([]string, 0, len(columns))
+	for _, column := range columns {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", column, mapping[column]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func parseAstraDBSinkMapping(mapping string) map[string]string {
+	columns := map[string]string{}
+	for _, pair := range strings.Split(mapping, ",") {
+		column, field, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		columns[strings.TrimSpace(column)] = strings.TrimSpace(field)
+	}
+	return columns
+}
+
+// setAstraDBSinkConfigs sets the typed configuration of the sink. The token is not read back.
+func setAstraDBSinkConfigs(d *schema.ResourceData, configs map[string]interface{}) error {
+	if v, ok := configs["databaseId"].(string); ok {
+		if err := d.Set("database_id", v); err != nil {
+			return err
+		}
+	}
+	if v, ok := configs["keyspace"].(string); ok {
+		if err := d.Set("keyspace", v); err != nil {
+			return err
+		}
+	}
+	if v, ok := configs["tableName"].(string); ok {
+		if err := d.Set("table", v); err != nil {
+			return err
+		}
+	}
+	if v, ok := configs["mapping"].(string); ok {
+		if err := d.Set("mapping", parseAstraDBSinkMapping(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingAstraDBSink(t *testing.T) {
+	// Disable this test by default until test works with non-prod clusters
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_SINK_TEST_ENABLED", "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	tenantName := fmt.Sprintf("terraform-test-%s", uuid.New().String())[0:20]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingAstraDBSinkConfiguration(tenantName, databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_astra_db_sink.sink", "table", "target"),
+					resource.TestCheckResourceAttr("astra_streaming_astra_db_sink.sink", "mapping.id", "key.id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAstraDBSinkMapping(t *testing.T) {
+	mapping := formatAstraDBSinkMapping(map[string]interface{}{
+		"name": "value.name",
+		"id":   "key.id",
+	})
+	if mapping != "id=key.id, name=value.name" {
+		t.Fatalf("unexpected mapping: %s", mapping)
+	}
+
+	columns := parseAstraDBSinkMapping(mapping)
+	if len(columns) != 2 || columns["id"] != "key.id" || columns["name"] != "value.name" {
+		t.Fatalf("unexpected parsed mapping: %v", columns)
+	}
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccStreamingAstraDBSinkConfiguration(tenantName, databaseID string) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "tenant" {
+  tenant_name    = "%s"
+  topic          = "terraformtest"
+  region         = "useast-4"
+  cloud_provider = "gcp"
+  user_email     = "terraform-test-user@datastax.com"
+}
+
+resource "astra_streaming_astra_db_sink" "sink" {
+  tenant_name         = astra_streaming_tenant.tenant.tenant_name
+  cloud_provider      = "gcp"
+  region              = "useast-4"
+  namespace           = "default"
+  topic               = "persistent://${astra_streaming_tenant.tenant.tenant_name}/default/terraformtest"
+  database_id         = "%s"
+  keyspace            = "ks1"
+  table               = "target"
+  mapping             = {
+    id   = "key.id"
+    name = "value.name"
+  }
+  deletion_protection = false
+}
+`, tenantName, databaseID)
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	pulsarTopicTypePartitioned    = "partitioned"
+	pulsarTopicTypeNonPartitioned = "non-partitioned"
+)
+
+func resourceStreamingNamespaceAutoTopicCreation() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_streaming_namespace_auto_topic_creation` overrides the auto topic creation policy of the brokers for a namespace of a streaming tenant: whether producers and consumers create the topics they use, and the type and number of partitions of those topics. Destroying the resource removes the override, so the namespace uses the policy of the brokers again.",
+		CreateContext: resourceStreamingNamespaceAutoTopicCreationPut,
+		ReadContext:   resourceStreamingNamespaceAutoTopicCreationRead,
+		UpdateContext: resourceStreamingNamespaceAutoTopicCreationPut,
+		DeleteContext: resourceStreamingNamespaceAutoTopicCreationDelete,
+		CustomizeDiff: resourceStreamingNamespaceAutoTopicCreationDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStreamingNamespaceAutoTopicCreationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"tenant_name": {
+				Description:      "Streaming tenant name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			"cluster_name": {
+				Description: "Name of the Pulsar Cluster. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description: "Pulsar namespace in the tenant.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"allow_auto_topic_creation": {
+				Description: "Whether topics are created when a producer or a consumer uses a topic which does not exist.",
+				Type:        schema.TypeBool,
+				Required:    true,
+			},
+			// Optional
+			"topic_type": {
+				Description:  "The type of the topics created automatically, `non-partitioned` or `partitioned`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      pulsarTopicTypeNonPartitioned,
+				ValidateFunc: validation.StringInSlice([]string{pulsarTopicTypeNonPartitioned, pulsarTopicTypePartitioned}, false),
+			},
+			"default_num_partitions": {
+				Description:  "The number of partitions of the topics created automatically. Required when `topic_type` is `partitioned`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+// autoTopicCreationOverride is the auto topic creation policy of a namespace in the Pulsar admin API
+type autoTopicCreationOverride struct {
+	AllowAutoTopicCreation bool   `json:"allowAutoTopicCreation"`
+	TopicType              string `json:"topicType"`
+	DefaultNumPartitions   *int   `json:"defaultNumPartitions,omitempty"`
+}
+
+func resourceStreamingNamespaceAutoTopicCreationDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("topic_type") || !d.NewValueKnown("default_num_partitions") {
+		return nil
+	}
+	_, err := expandAutoTopicCreationOverride(d.Get("allow_auto_topic_creation").(bool), d.Get("topic_type").(string), d.Get("default_num_partitions").(int))
+	return err
+}
+
+func resourceStreamingNamespaceAutoTopicCreationPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName := d.Get("tenant_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	namespace := d.Get("namespace").(string)
+
+	override, err := expandAutoTopicCreationOverride(d.Get("allow_auto_topic_creation").(bool), d.Get("topic_type").(string), d.Get("default_num_partitions").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, clusterName, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusCode, body, err := streamingAdminRequest(ctx, streamingClientv3, http.MethodPost, autoTopicCreationPath(tenantName, namespace), clusterName, pulsarToken, override)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := permissionError(opConfigureAutoTopicCreation, statusCode, body); err != nil {
+		return diag.FromErr(err)
+	}
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return diag.Errorf("error configuring auto topic creation of namespace %s/%s: %s", tenantName, namespace, string(body))
+	}
+
+	d.SetId(streamingNamespaceID(tenantName, clusterName, namespace))
+
+	return resourceStreamingNamespaceAutoTopicCreationRead(ctx, d, meta)
+}
+
+func resourceStreamingNamespaceAutoTopicCreationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, clusterName, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies, err := getStreamingNamespacePolicies(ctx, streamingClientv3, clusterName, pulsarToken, tenantName, namespace)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if policies == nil {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("namespace %s/%s", tenantName, namespace)))
+	}
+	if policies.AutoTopicCreationOverride == nil {
+		// The override was removed, the namespace uses the policy of the brokers
+		return readError(ctx, d, notFoundError(fmt.Sprintf("auto topic creation override of namespace %s/%s", tenantName, namespace)))
+	}
+
+	override := policies.AutoTopicCreationOverride
+	values := map[string]interface{}{
+		"tenant_name":               tenantName,
+		"cluster_name":              clusterName,
+		"namespace":                 namespace,
+		"allow_auto_topic_creation": override.AllowAutoTopicCreation,
+		"topic_type":                override.TopicType,
+		"default_num_partitions":    0,
+	}
+	if override.TopicType == pulsarTopicTypePartitioned && override.DefaultNumPartitions != nil {
+		values["default_num_partitions"] = *override.DefaultNumPartitions
+	}
+	for attribute, value := range values {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceStreamingNamespaceAutoTopicCreationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, clusterName, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusCode, body, err := streamingAdminRequest(ctx, streamingClientv3, http.MethodDelete, autoTopicCreationPath(tenantName, namespace), clusterName, pulsarToken, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if statusCode >= http.StatusBadRequest && statusCode != http.StatusNotFound {
+		return diag.Errorf("error removing auto topic creation override of namespace %s/%s: %s", tenantName, namespace, string(body))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceStreamingNamespaceAutoTopicCreationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("tenant_name", tenantName)
+	d.Set("cluster_name", clusterName)
+	d.Set("namespace", namespace)
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandAutoTopicCreationOverride returns the auto topic creation policy, the number of partitions is only sent for
+// partitioned topics since Pulsar rejects it otherwise
+func expandAutoTopicCreationOverride(allow bool, topicType string, numPartitions int) (autoTopicCreationOverride, error) {
+	override := autoTopicCreationOverride{
+		AllowAutoTopicCreation: allow,
+		TopicType:              topicType,
+	}
+	switch topicType {
+	case pulsarTopicTypePartitioned:
+		if numPartitions < 1 {
+			return override, errors.New("\"default_num_partitions\" is required when \"topic_type\" is \"partitioned\"")
+		}
+		override.DefaultNumPartitions = &numPartitions
+	default:
+		if numPartitions != 0 {
+			return override, fmt.Errorf("\"default_num_partitions\" can only be set when \"topic_type\" is \"%s\"", pulsarTopicTypePartitioned)
+		}
+	}
+	return override, nil
+}
+
+func autoTopicCreationPath(tenantName, namespace string) string {
+	return fmt.Sprintf("admin/v2/namespaces/%s/%s/autoTopicCreation", tenantName, namespace)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingNamespaceAutoTopicCreation(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TENANT", "ASTRA_TEST_STREAMING_CLUSTER")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+	cluster := os.Getenv("ASTRA_TEST_STREAMING_CLUSTER")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingNamespaceAutoTopicCreationConfiguration(tenant, cluster, `
+  allow_auto_topic_creation = false`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_namespace_auto_topic_creation.default", "allow_auto_topic_creation", "false"),
+					resource.TestCheckResourceAttr("astra_streaming_namespace_auto_topic_creation.default", "topic_type", "non-partitioned"),
+				),
+			},
+			{
+				Config: testAccStreamingNamespaceAutoTopicCreationConfiguration(tenant, cluster, `
+  allow_auto_topic_creation = true
+  topic_type                = "partitioned"
+  default_num_partitions    = 2`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_namespace_auto_topic_creation.default", "allow_auto_topic_creation", "true"),
+					resource.TestCheckResourceAttr("astra_streaming_namespace_auto_topic_creation.default", "default_num_partitions", "2"),
+				),
+			},
+			{
+				ResourceName:      "astra_streaming_namespace_auto_topic_creation.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccStreamingNamespaceAutoTopicCreationConfiguration(tenant, cluster, policy string) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_namespace_auto_topic_creation" "default" {
+  tenant_name  = "%s"
+  cluster_name = "%s"
+  namespace    = "default"%s
+}
+`, tenant, cluster, policy)
+}
+
+func TestExpandAutoTopicCreationOverride(t *testing.T) {
+	override, err := expandAutoTopicCreationOverride(true, "partitioned", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !override.AllowAutoTopicCreation || override.TopicType != "partitioned" || override.DefaultNumPartitions == nil || *override.DefaultNumPartitions != 3 {
+		t.Errorf("unexpected partitioned override: %+v", override)
+	}
+
+	override, err = expandAutoTopicCreationOverride(false, "non-partitioned", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if override.AllowAutoTopicCreation || override.DefaultNumPartitions != nil {
+		t.Errorf("unexpected non-partitioned override: %+v", override)
+	}
+
+	if _, err := expandAutoTopicCreationOverride(true, "partitioned", 0); err == nil {
+		t.Error("expected an error for partitioned topics without default_num_partitions")
+	}
+	if _, err := expandAutoTopicCreationOverride(true, "non-partitioned", 2); err == nil {
+		t.Error("expected an error for non-partitioned topics with default_num_partitions")
+	}
+}
+
+func TestParseStreamingNamespaceID(t *testing.T) {
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID("mytenant/pulsar-gcp-useast4/default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenantName != "mytenant" || clusterName != "pulsar-gcp-useast4" || namespace != "default" {
+		t.Errorf("unexpected id parts: %q, %q, %q", tenantName, clusterName, namespace)
+	}
+	for _, id := range []string{"", "mytenant/pulsar-gcp-useast4", "mytenant//default", "a/b/c/d"} {
+		if _, _, _, err := parseStreamingNamespaceID(id); err == nil {
+			t.Errorf("expected an error for id %q", id)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// schemaCompatibilityNone disables the compatibility check, it is the ALWAYS_COMPATIBLE strategy of Pulsar
+	schemaCompatibilityNone             = "NONE"
+	schemaCompatibilityAlwaysCompatible = "ALWAYS_COMPATIBLE"
+	// schemaCompatibilityUndefined is the strategy of namespaces using the strategy of the brokers
+	schemaCompatibilityUndefined = "UNDEFINED"
+)
+
+var schemaCompatibilityStrategies = []string{
+	"BACKWARD",
+	"BACKWARD_TRANSITIVE",
+	"FORWARD",
+	"FORWARD_TRANSITIVE",
+	"FULL",
+	"FULL_TRANSITIVE",
+	schemaCompatibilityNone,
+	schemaCompatibilityAlwaysCompatible,
+	"ALWAYS_INCOMPATIBLE",
+}
+
+func resourceStreamingNamespaceSchemaPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_streaming_namespace_schema_policy` sets how the schemas of the topics of a namespace of a streaming tenant can evolve: the compatibility strategy new schemas are checked against, and whether producers can register new schemas. Destroying the resource resets the namespace to the strategy of the brokers and allows schema updates again.",
+		CreateContext: resourceStreamingNamespaceSchemaPolicyPut,
+		ReadContext:   resourceStreamingNamespaceSchemaPolicyRead,
+		UpdateContext: resourceStreamingNamespaceSchemaPolicyPut,
+		DeleteContext: resourceStreamingNamespaceSchemaPolicyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStreamingNamespaceSchemaPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"tenant_name": {
+				Description:      "Streaming tenant name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			"cluster_name": {
+				Description: "Name of the Pulsar Cluster. Format: `pulsar-<cloud provider>-<cloud region>`. Example: `pulsar-gcp-useast1`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Description: "Pulsar namespace in the tenant.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_compatibility_strategy": {
+				Description:      "The strategy new schemas are checked against: `BACKWARD`, `FORWARD` or `FULL`, their `_TRANSITIVE` variants which check all the previous schemas instead of the latest one, `NONE` (or `ALWAYS_COMPATIBLE`) to disable the check, or `ALWAYS_INCOMPATIBLE` to reject schema changes.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringInSlice(schemaCompatibilityStrategies, false),
+				DiffSuppressFunc: suppressSchemaCompatibilityAlias,
+			},
+			// Optional
+			"schema_auto_update_enabled": {
+				Description: "Whether producers can register a new schema which passes the compatibility check. When disabled, schemas can only be changed through the admin API.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceStreamingNamespaceSchemaPolicyPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantName := d.Get("tenant_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	namespace := d.Get("namespace").(string)
+
+	strategy := expandSchemaCompatibilityStrategy(d.Get("schema_compatibility_strategy").(string))
+	if err := setStreamingNamespaceSchemaPolicy(ctx, meta, tenantName, clusterName, namespace, strategy, d.Get("schema_auto_update_enabled").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(streamingNamespaceID(tenantName, clusterName, namespace))
+
+	return resourceStreamingNamespaceSchemaPolicyRead(ctx, d, meta)
+}
+
+func resourceStreamingNamespaceSchemaPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, clusterName, tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies, err := getStreamingNamespacePolicies(ctx, streamingClientv3, clusterName, pulsarToken, tenantName, namespace)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if policies == nil {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("namespace %s/%s", tenantName, namespace)))
+	}
+
+	values := map[string]interface{}{
+		"tenant_name":                   tenantName,
+		"cluster_name":                  clusterName,
+		"namespace":                     namespace,
+		"schema_compatibility_strategy": policies.SchemaCompatibilityStrategy,
+		"schema_auto_update_enabled":    schemaAutoUpdateEnabled(policies),
+	}
+	for attribute, value := range values {
+		if err := d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceStreamingNamespaceSchemaPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Reset the Pulsar defaults
+	if err := setStreamingNamespaceSchemaPolicy(ctx, meta, tenantName, clusterName, namespace, schemaCompatibilityUndefined, true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceStreamingNamespaceSchemaPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tenantName, clusterName, namespace, err := parseStreamingNamespaceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("tenant_name", tenantName)
+	d.Set("cluster_name", clusterName)
+	d.Set("namespace", namespace)
+	return []*schema.ResourceData{d}, nil
+}
+
+// setStreamingNamespaceSchemaPolicy sets the compatibility strategy and the schema auto update of the namespace, which
+// are separate policies in the Pulsar admin API
+func setStreamingNamespaceSchemaPolicy(ctx context.Context, meta interface{}, tenantName, clusterName, namespace, strategy string, autoUpdate bool) error {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, clusterName, tenantName)
+	if err != nil {
+		return err
+	}
+
+	policies := []struct {
+		path  string
+		value interface{}
+	}{
+		{path: "schemaCompatibilityStrategy", value: strategy},
+		{path: "isAllowAutoUpdateSchema", value: autoUpdate},
+	}
+	for _, policy := range policies {
+		path := fmt.Sprintf("admin/v2/namespaces/%s/%s/%s", tenantName, namespace, policy.path)
+		statusCode, body, err := streamingAdminRequest(ctx, streamingClientv3, http.MethodPost, path, clusterName, pulsarToken, policy.value)
+		if err != nil {
+			return err
+		}
+		if err := permissionError(opConfigureSchemaPolicy, statusCode, body); err != nil {
+			return err
+		}
+		if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("error setting %s of namespace %s/%s: %s", policy.path, tenantName, namespace, string(body))
+		}
+	}
+	return nil
+}
+
+// expandSchemaCompatibilityStrategy returns the Pulsar name of the strategy
+func expandSchemaCompatibilityStrategy(strategy string) string {
+	if strategy == schemaCompatibilityNone {
+		return schemaCompatibilityAlwaysCompatible
+	}
+	return strategy
+}
+
+// suppressSchemaCompatibilityAlias ignores the difference between NONE in the configuration and ALWAYS_COMPATIBLE
+// returned by Pulsar
+func suppressSchemaCompatibilityAlias(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return expandSchemaCompatibilityStrategy(oldValue) == expandSchemaCompatibilityStrategy(newValue)
+}
+
+// schemaAutoUpdateEnabled returns whether producers can register new schemas, which Pulsar allows unless the policy
+// is set
+func schemaAutoUpdateEnabled(policies *StreamingNamespacePolicies) bool {
+	return policies.IsAllowAutoUpdateSchema == nil || *policies.IsAllowAutoUpdateSchema
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingNamespaceSchemaPolicy(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TENANT", "ASTRA_TEST_STREAMING_CLUSTER")
+	tenant := os.Getenv("ASTRA_TEST_STREAMING_TENANT")
+	cluster := os.Getenv("ASTRA_TEST_STREAMING_CLUSTER")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingNamespaceSchemaPolicyConfiguration(tenant, cluster, "BACKWARD", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_namespace_schema_policy.default", "schema_compatibility_strategy", "BACKWARD"),
+					resource.TestCheckResourceAttr("astra_streaming_namespace_schema_policy.default", "schema_auto_update_enabled", "true"),
+				),
+			},
+			{
+				Config: testAccStreamingNamespaceSchemaPolicyConfiguration(tenant, cluster, "NONE", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_namespace_schema_policy.default", "schema_compatibility_strategy", "ALWAYS_COMPATIBLE"),
+					resource.TestCheckResourceAttr("astra_streaming_namespace_schema_policy.default", "schema_auto_update_enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "astra_streaming_namespace_schema_policy.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccStreamingNamespaceSchemaPolicyConfiguration(tenant, cluster, strategy string, autoUpdate bool) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_namespace_schema_policy" "default" {
+  tenant_name                   = "%s"
+  cluster_name                  = "%s"
+  namespace                     = "default"
+  schema_compatibility_strategy = "%s"
+  schema_auto_update_enabled    = %t
+}
+`, tenant, cluster, strategy, autoUpdate)
+}
+
+func TestSchemaCompatibilityStrategy(t *testing.T) {
+	if expandSchemaCompatibilityStrategy("NONE") != "ALWAYS_COMPATIBLE" {
+		t.Error("expected NONE to be sent as ALWAYS_COMPATIBLE")
+	}
+	if expandSchemaCompatibilityStrategy("FULL") != "FULL" {
+		t.Error("expected FULL to be sent as is")
+	}
+	if !suppressSchemaCompatibilityAlias("", "ALWAYS_COMPATIBLE", "NONE", nil) {
+		t.Error("expected no diff between ALWAYS_COMPATIBLE and NONE")
+	}
+	if suppressSchemaCompatibilityAlias("", "UNDEFINED", "NONE", nil) {
+		t.Error("expected a diff between UNDEFINED and NONE")
+	}
+
+	disabled := false
+	if !schemaAutoUpdateEnabled(&StreamingNamespacePolicies{}) || schemaAutoUpdateEnabled(&StreamingNamespacePolicies{IsAllowAutoUpdateSchema: &disabled}) {
+		t.Error("expected schema auto update to be enabled unless disabled by the policy")
+	}
+}
@@ -3,14 +3,14 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,17 +25,17 @@ func resourceStreamingSink() *schema.Resource {
 		UpdateContext: resourceStreamingSinkUpdate,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceStreamingSinkImport,
 		},
 
 		Schema: map[string]*schema.Schema{
 			// Required
 			"tenant_name": {
-				Description:  "Streaming tenant name.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "Streaming tenant name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
 			},
 			"topic": {
 				Description:  "Streaming tenant topic.",
@@ -45,11 +45,12 @@ func resourceStreamingSink() *schema.Resource {
 				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
 			},
 			"region": {
-				Description:  "cloud region",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "cloud region",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				DiffSuppressFunc: ignoreRegionFormat,
 			},
 			"cloud_provider": {
 				Description:  "Cloud provider",
@@ -84,10 +85,11 @@ func resourceStreamingSink() *schema.Resource {
 				ForceNew:    true,
 			},
 			"namespace": {
-				Description: "Pulsar Namespace",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "Pulsar Namespace",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingNamespaceName,
 			},
 			"sink_configs": {
 				Description: "Sink Configs",
@@ -136,77 +138,55 @@ func resourceStreamingSinkDelete(ctx context.Context, resourceData *schema.Resou
 
 	pulsarCluster := GetPulsarCluster(cloudProvider, region)
 
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
+	org, err := getCurrentOrg(ctx, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = json.Unmarshal(bodyBuffer, &org)
-	if err != nil {
-		fmt.Println("Can't deserialize", orgBody)
-	}
-
 	token := meta.(astraClients).token
 	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenantName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	deleteSinkParams := astrastreaming.DeleteSinkParams{
-		XDataStaxPulsarCluster: pulsarCluster,
-		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
-	}
-
-	deleteSinkResponse, err := streamingClientv3.DeleteSinkWithResponse(ctx, tenantName, namespace, sinkName, &deleteSinkParams)
-	if err != nil {
-		diag.FromErr(err)
-	}
-	if !strings.HasPrefix(deleteSinkResponse.Status(), "2") {
-		return diag.Errorf("Error deleting sink %s", deleteSinkResponse.Body)
+	if err := deleteStreamingSink(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenantName, namespace, sinkName); err != nil {
+		return diag.FromErr(err)
 	}
 
-	// Not found. Remove from state.
+	// Deleted. Remove from state.
 	resourceData.SetId("")
 
 	return nil
 }
 
 type SinkResponse struct {
-	Tenant                     string      `json:"tenant"`
-	Namespace                  string      `json:"namespace"`
-	Name                       string      `json:"name"`
-	ClassName                  string      `json:"className"`
-	SourceSubscriptionName     interface{} `json:"sourceSubscriptionName"`
-	SourceSubscriptionPosition string      `json:"sourceSubscriptionPosition"`
-	Inputs                     interface{} `json:"inputs"`
-	TopicToSerdeClassName      interface{} `json:"topicToSerdeClassName"`
-	TopicsPattern              interface{} `json:"topicsPattern"`
-	TopicToSchemaType          interface{} `json:"topicToSchemaType"`
-	TopicToSchemaProperties    interface{} `json:"topicToSchemaProperties"`
-	MaxMessageRetries          interface{} `json:"maxMessageRetries"`
-	DeadLetterTopic            interface{} `json:"deadLetterTopic"`
-	Configs                    struct {
-		Password  string `json:"password"`
-		JdbcURL   string `json:"jdbcUrl"`
-		UserName  string `json:"userName"`
-		TableName string `json:"tableName"`
-	} `json:"configs"`
-	Secrets                      interface{} `json:"secrets"`
-	Parallelism                  int         `json:"parallelism"`
-	ProcessingGuarantees         string      `json:"processingGuarantees"`
-	RetainOrdering               bool        `json:"retainOrdering"`
-	RetainKeyOrdering            bool        `json:"retainKeyOrdering"`
-	Resources                    interface{} `json:"resources"`
-	AutoAck                      bool        `json:"autoAck"`
-	TimeoutMs                    interface{} `json:"timeoutMs"`
-	NegativeAckRedeliveryDelayMs interface{} `json:"negativeAckRedeliveryDelayMs"`
-	Archive                      string      `json:"archive"`
-	CleanupSubscription          interface{} `json:"cleanupSubscription"`
-	RuntimeFlags                 interface{} `json:"runtimeFlags"`
-	CustomRuntimeOptions         interface{} `json:"customRuntimeOptions"`
+	Tenant                       string                 `json:"tenant"`
+	Namespace                    string                 `json:"namespace"`
+	Name                         string                 `json:"name"`
+	ClassName                    string                 `json:"className"`
+	SourceSubscriptionName       interface{}            `json:"sourceSubscriptionName"`
+	SourceSubscriptionPosition   string                 `json:"sourceSubscriptionPosition"`
+	Inputs                       []string               `json:"inputs"`
+	TopicToSerdeClassName        interface{}            `json:"topicToSerdeClassName"`
+	TopicsPattern                interface{}            `json:"topicsPattern"`
+	TopicToSchemaType            interface{}            `json:"topicToSchemaType"`
+	TopicToSchemaProperties      interface{}            `json:"topicToSchemaProperties"`
+	MaxMessageRetries            interface{}            `json:"maxMessageRetries"`
+	DeadLetterTopic              interface{}            `json:"deadLetterTopic"`
+	Configs                      map[string]interface{} `json:"configs"`
+	Secrets                      interface{}            `json:"secrets"`
+	Parallelism                  int                    `json:"parallelism"`
+	ProcessingGuarantees         string                 `json:"processingGuarantees"`
+	RetainOrdering               bool                   `json:"retainOrdering"`
+	RetainKeyOrdering            bool                   `json:"retainKeyOrdering"`
+	Resources                    interface{}            `json:"resources"`
+	AutoAck                      bool                   `json:"autoAck"`
+	TimeoutMs                    interface{}            `json:"timeoutMs"`
+	NegativeAckRedeliveryDelayMs interface{}            `json:"negativeAckRedeliveryDelayMs"`
+	Archive                      string                 `json:"archive"`
+	CleanupSubscription          interface{}            `json:"cleanupSubscription"`
+	RuntimeFlags                 interface{}            `json:"runtimeFlags"`
+	CustomRuntimeOptions         interface{}            `json:"customRuntimeOptions"`
 }
 
 func resourceStreamingSinkRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -225,20 +205,15 @@ func resourceStreamingSinkRead(ctx context.Context, resourceData *schema.Resourc
 
 	pulsarCluster := GetPulsarCluster(cloudProvider, region)
 
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
-
-	err = json.Unmarshal(bodyBuffer, &org)
+	org, err := getCurrentOrg(ctx, client)
 	if err != nil {
-		fmt.Println("Can't deserislize", orgBody)
+		return diag.FromErr(err)
 	}
 
 	token := meta.(astraClients).token
 	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenantName)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
 	getSinksParams := astrastreaming.GetSinksParams{
@@ -248,20 +223,83 @@ func resourceStreamingSinkRead(ctx context.Context, resourceData *schema.Resourc
 
 	getSinkResponse, err := streamingClientv3.GetSinksWithResponse(ctx, tenantName, namespace, sinkName, &getSinksParams)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
-	if !strings.HasPrefix(getSinkResponse.Status(), "2") {
-		return diag.Errorf("Error getting sinks %s", getSinkResponse.Body)
+	if err := checkReadStatus(getSinkResponse.StatusCode(), getSinkResponse.Body, fmt.Sprintf("sink %s", sinkName)); err != nil {
+		return readError(ctx, resourceData, err)
 	}
 
 	var sinkResponse SinkResponse
-	json.Unmarshal(getSinkResponse.Body, sinkResponse)
+	if err := json.Unmarshal(getSinkResponse.Body, &sinkResponse); err != nil {
+		return diag.Errorf("failed to decode sink %s: %s", sinkName, err)
+	}
 
-	setStreamingSinkData(resourceData, tenantName, topic)
+	// The topic and configs are only read back when they are not known yet (on import), since the API
+	// returns them in a normalized form which does not match the configuration
+	if topic == "" && len(sinkResponse.Inputs) > 0 {
+		topic = sinkResponse.Inputs[0]
+	}
+	if resourceData.Get("sink_configs").(string) == "" {
+		configs, err := json.Marshal(sinkResponse.Configs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := resourceData.Set("sink_configs", string(configs)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := setStreamingSinkConfig(resourceData, &sinkResponse); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setStreamingSinkData(resourceData, tenantName, topic); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
+// resourceStreamingSinkImport imports a sink from tenant/namespace/sink_name. The cloud provider and region are read
+// from the tenant and the rest of the sink configuration is read back from the sink.
+func resourceStreamingSinkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName, namespace, sinkName, err := parseStreamingSinkID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setStreamingTenantLocation(ctx, d, client, streamingClient, tenantName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("tenant_name", tenantName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("namespace", namespace); err != nil {
+		return nil, err
+	}
+	if err := d.Set("sink_name", sinkName); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func setStreamingSinkConfig(d *schema.ResourceData, sink *SinkResponse) error {
+	if err := d.Set("namespace", sink.Namespace); err != nil {
+		return err
+	}
+	if err := d.Set("retain_ordering", sink.RetainOrdering); err != nil {
+		return err
+	}
+	if err := d.Set("processing_guarantees", sink.ProcessingGuarantees); err != nil {
+		return err
+	}
+	if err := d.Set("parallelism", sink.Parallelism); err != nil {
+		return err
+	}
+	return d.Set("auto_ack", sink.AutoAck)
+}
+
 func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
@@ -281,24 +319,19 @@ func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.Resou
 	topic := resourceData.Get("topic").(string)
 	autoAck := resourceData.Get("auto_ack").(bool)
 
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
-
-	err = json.Unmarshal(bodyBuffer, &org)
+	org, err := getCurrentOrg(ctx, client)
 	if err != nil {
-		fmt.Println("Can't deserislize", orgBody)
+		return diag.FromErr(err)
 	}
 
-	streamingClustersResponse, _ := streamingClient.GetPulsarClustersWithResponse(ctx, org.ID)
+	streamingClustersResponse, err := streamingClient.GetPulsarClustersWithResponse(ctx, org.ID)
+	if err != nil {
+		return diag.Errorf("failed to list streaming clusters: %s", err)
+	}
 
 	var streamingClusters StreamingClusters
-	//bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
-
-	err = json.Unmarshal(streamingClustersResponse.Body, &streamingClusters)
-	if err != nil {
-		fmt.Println("Can't deserislize", orgBody)
+	if err := json.Unmarshal(streamingClustersResponse.Body, &streamingClusters); err != nil {
+		return diag.Errorf("failed to decode streaming clusters: %s: %s", err, streamingClustersResponse.Body)
 	}
 
 	for i := 0; i < len(streamingClusters); i++ {
@@ -316,9 +349,55 @@ func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.Resou
 	token := meta.(astraClients).token
 	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenantName)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
+	var configs map[string]interface{}
+	if err := json.Unmarshal([]byte(rawConfigs), &configs); err != nil {
+		return diag.Errorf("sink_configs must be a JSON object: %s", err)
+	}
+
+	spec := streamingSinkSpec{
+		Namespace:            namespace,
+		SinkName:             sinkName,
+		Topic:                topic,
+		Configs:              configs,
+		Parallelism:          parallelism,
+		ProcessingGuarantees: processingGuarantees,
+		RetainOrdering:       retainOrdering,
+		AutoAck:              autoAck,
+	}
+	if err := createStreamingSink(ctx, streamingClientv3, pulsarCluster, pulsarToken, tenantName, spec); err != nil {
+		return diag.FromErr(err)
+	}
+
+	setStreamingSinkData(resourceData, tenantName, topic)
+
+	return nil
+}
+
+// streamingSinkSpec describes a builtin sink which consumes a topic
+type streamingSinkSpec struct {
+	Namespace            string
+	SinkName             string
+	Topic                string
+	Configs              map[string]interface{}
+	Parallelism          int32
+	ProcessingGuarantees string
+	RetainOrdering       bool
+	AutoAck              bool
+}
+
+// createStreamingSink registers a builtin sink in a namespace of the tenant
+func createStreamingSink(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, pulsarCluster, pulsarToken, tenantName string, spec streamingSinkSpec) error {
+	namespace := spec.Namespace
+	sinkName := spec.SinkName
+	configs := spec.Configs
+	parallelism := spec.Parallelism
+	processingGuarantees := spec.ProcessingGuarantees
+	retainOrdering := spec.RetainOrdering
+	autoAck := spec.AutoAck
+
 	createSinkParams := astrastreaming.CreateSinkJSONParams{
 		XDataStaxPulsarCluster: pulsarCluster,
 		//XDataStaxCurrentOrg:    org.ID,
@@ -331,9 +410,12 @@ func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.Resou
 		Authorization:          pulsarToken,
 	}
 
-	builtinSinksResponse, err := streamingClientv3.GetBuiltInSinks(ctx, &getBuiltinSinkParams)
+	builtinSinksResponse, err := streamingClientv3.GetBuiltInSinksWithResponse(ctx, &getBuiltinSinkParams)
 	if err != nil {
-		diag.FromErr(err)
+		return err
+	}
+	if builtinSinksResponse.StatusCode() < http.StatusOK || builtinSinksResponse.StatusCode() >= http.StatusMultipleChoices {
+		return fmt.Errorf("error listing builtin sinks, status code: %d, message: %s", builtinSinksResponse.StatusCode(), builtinSinksResponse.Body)
 	}
 
 	type SinkConfig []struct {
@@ -347,8 +429,9 @@ func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.Resou
 
 	var builtinSinks []map[string]interface{}
 
-	bodyBuffer, err = ioutil.ReadAll(builtinSinksResponse.Body)
-	json.Unmarshal(bodyBuffer, &builtinSinks)
+	if err := json.Unmarshal(builtinSinksResponse.Body, &builtinSinks); err != nil {
+		return fmt.Errorf("failed to decode builtin sinks: %w", err)
+	}
 
 	var sinkConfig map[string]interface{}
 
@@ -363,16 +446,13 @@ func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.Resou
 		}
 	}
 
-	var configs map[string]interface{}
-	json.Unmarshal([]byte(rawConfigs), &configs)
-
 	if sinkConfig == nil {
-		return diag.Errorf("Could not find sink name %s in prebuilt sinks", sinkName)
+		return fmt.Errorf("Could not find sink name %s in prebuilt sinks", sinkName)
 	}
 
 	archive := fmt.Sprintf("builtin://%s", sinkName)
 
-	inputs := []string{topic}
+	inputs := []string{spec.Topic}
 	createSinkBody := astrastreaming.CreateSinkJSONJSONRequestBody{
 		Archive:                      &archive,
 		AutoAck:                      &autoAck,
@@ -405,18 +485,34 @@ func resourceStreamingSinkCreate(ctx context.Context, resourceData *schema.Resou
 		TopicsPattern:                nil,
 	}
 
-	sinkCreationResponse, err := streamingClientv3.CreateSinkJSON(ctx, tenantName, namespace, sinkName, &createSinkParams, createSinkBody)
+	sinkCreationResponse, err := streamingClientv3.CreateSinkJSONWithResponse(ctx, tenantName, namespace, sinkName, &createSinkParams, createSinkBody)
 	if err != nil {
-		diag.FromErr(err)
+		return err
 	}
-	if !strings.HasPrefix(sinkCreationResponse.Status, "2") {
-		bodyBuffer, err = ioutil.ReadAll(sinkCreationResponse.Body)
-		return diag.Errorf("Error creating sink %s", bodyBuffer)
+	if sinkCreationResponse.StatusCode() < http.StatusOK || sinkCreationResponse.StatusCode() >= http.StatusMultipleChoices {
+		return fmt.Errorf("Error creating sink %s", sinkCreationResponse.Body)
 	}
-	bodyBuffer, err = ioutil.ReadAll(sinkCreationResponse.Body)
 
-	setStreamingSinkData(resourceData, tenantName, topic)
+	return nil
+}
 
+// deleteStreamingSink deletes a sink of the tenant. A sink which does not exist is ignored.
+func deleteStreamingSink(ctx context.Context, streamingClientv3 *astrastreaming.ClientWithResponses, pulsarCluster, pulsarToken, tenantName, namespace, sinkName string) error {
+	deleteSinkParams := astrastreaming.DeleteSinkParams{
+		XDataStaxPulsarCluster: pulsarCluster,
+		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
+	}
+
+	deleteSinkResponse, err := streamingClientv3.DeleteSinkWithResponse(ctx, tenantName, namespace, sinkName, &deleteSinkParams)
+	if err != nil {
+		return err
+	}
+	if deleteSinkResponse.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+	if deleteSinkResponse.StatusCode() < http.StatusOK || deleteSinkResponse.StatusCode() >= http.StatusMultipleChoices {
+		return fmt.Errorf("Error deleting sink %s", deleteSinkResponse.Body)
+	}
 	return nil
 }
 
@@ -433,10 +529,10 @@ func setStreamingSinkData(d *schema.ResourceData, tenantName string, topic strin
 	return nil
 }
 
-func parseStreamingSinkID(id string) (string, string, error) {
-	idParts := strings.Split(strings.ToLower(id), "/")
-	if len(idParts) != 1 {
-		return "", "", errors.New("invalid role id format: expected tenant_name/topic")
+func parseStreamingSinkID(id string) (string, string, string, error) {
+	parts, err := resourceid.StreamingSink.Parse(id)
+	if err != nil {
+		return "", "", "", err
 	}
-	return idParts[0], idParts[1], nil
+	return parts[0], parts[1], parts[2], nil
 }
@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -133,3 +138,49 @@ resource "astra_streaming_sink" "streaming_sink-1" {
 }
 `, tenantName, "%s", "%s", "%s")
 }
+
+func TestCreateAndDeleteStreamingSink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`[{"name":"jdbc-postgres"},{"name":"failing"}]`))
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/failing"):
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"reason":"invalid sink"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	client, err := astrastreaming.NewClientWithResponses(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	spec := streamingSinkSpec{Namespace: "default", SinkName: "jdbc-postgres", Topic: "persistent://tenant/default/topic"}
+	if err := createStreamingSink(ctx, client, "pulsar-gcp-useast1", "token", "tenant", spec); err != nil {
+		t.Fatalf("expected the sink to be created, got %v", err)
+	}
+	spec.SinkName = "failing"
+	if err := createStreamingSink(ctx, client, "pulsar-gcp-useast1", "token", "tenant", spec); err == nil || !strings.Contains(err.Error(), "invalid sink") {
+		t.Fatalf("expected a create error, got %v", err)
+	}
+	spec.SinkName = "unknown"
+	if err := createStreamingSink(ctx, client, "pulsar-gcp-useast1", "token", "tenant", spec); err == nil || !strings.Contains(err.Error(), "Could not find sink name unknown") {
+		t.Fatalf("expected an unknown sink error, got %v", err)
+	}
+
+	if err := deleteStreamingSink(ctx, client, "pulsar-gcp-useast1", "token", "tenant", "default", "sink"); err != nil {
+		t.Fatalf("expected the sink to be deleted, got %v", err)
+	}
+	if err := deleteStreamingSink(ctx, client, "pulsar-gcp-useast1", "token", "tenant", "default", "missing"); err != nil {
+		t.Fatalf("expected a missing sink to be ignored, got %v", err)
+	}
+	if err := deleteStreamingSink(ctx, client, "pulsar-gcp-useast1", "token", "tenant", "default", "failing"); err == nil || !strings.Contains(err.Error(), "invalid sink") {
+		t.Fatalf("expected a delete error, got %v", err)
+	}
+}
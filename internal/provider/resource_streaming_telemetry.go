@@ -0,0 +1,425 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// streamingTelemetryExporters are the blocks of the external systems metrics can be exported to, only one can be set
+var streamingTelemetryExporters = []string{"prometheus_remote", "datadog", "kafka"}
+
+func resourceStreamingTelemetry() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_streaming_telemetry` exports the Pulsar metrics of an Astra Streaming tenant, or of all the tenants of a dedicated cluster, to an external system: a Prometheus remote write endpoint, Datadog or Kafka. Database metrics are configured separately.",
+		CreateContext: resourceStreamingTelemetryCreate,
+		ReadContext:   resourceStreamingTelemetryRead,
+		UpdateContext: resourceStreamingTelemetryUpdate,
+		DeleteContext: resourceStreamingTelemetryDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStreamingTelemetryImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"cluster_name": {
+				Description: "Pulsar cluster name. When `tenant_name` is not set, the metrics of all the tenants of the cluster are exported, which is only supported on dedicated clusters.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			// Optional
+			"tenant_name": {
+				Description:      "Streaming tenant name. When set, only the metrics of the tenant are exported.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			"prometheus_remote": {
+				Description:  "Export the metrics to a Prometheus remote write endpoint.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: streamingTelemetryExporters,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint": {
+							Description:  "The URL of the remote write endpoint.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+						},
+						"username": {
+							Description: "The username for basic authentication.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"password": {
+							Description: "The password for basic authentication.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"bearer_token": {
+							Description: "The token for bearer authentication, instead of basic authentication.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"datadog": {
+				Description:  "Export the metrics to Datadog.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: streamingTelemetryExporters,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_key": {
+							Description: "The Datadog API key.",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"site": {
+							Description: "The Datadog site, for example `datadoghq.eu`. Defaults to `datadoghq.com`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"kafka": {
+				Description:  "Export the metrics to a Kafka topic.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: streamingTelemetryExporters,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bootstrap_servers": {
+							Description: "The bootstrap servers of the Kafka cluster.",
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"topic": {
+							Description: "The Kafka topic the metrics are written to.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"security_protocol": {
+							Description:  "The security protocol, one of `SASL_SSL`, `SASL_PLAINTEXT`, `SSL` or `PLAINTEXT`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"SASL_SSL", "SASL_PLAINTEXT", "SSL", "PLAINTEXT"}, false),
+						},
+						"sasl_mechanism": {
+							Description:  "The SASL mechanism, one of `PLAIN`, `SCRAM-SHA-256` or `SCRAM-SHA-512`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"}, false),
+						},
+						"sasl_username": {
+							Description: "The SASL username.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"sasl_password": {
+							Description: "The SASL password.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// streamingTelemetryConfig is the telemetry configuration of a tenant or a cluster in the Astra Streaming API, it has
+// the same format as the telemetry configuration of databases
+type streamingTelemetryConfig struct {
+	PrometheusRemote *prometheusRemoteTelemetry `json:"prometheus_remote,omitempty"`
+	Datadog          *datadogTelemetry          `json:"datadog,omitempty"`
+	Kafka            *kafkaTelemetry            `json:"kafka,omitempty"`
+}
+
+type prometheusRemoteTelemetry struct {
+	Endpoint     string `json:"endpoint"`
+	AuthStrategy string `json:"auth_strategy"`
+	User         string `json:"user,omitempty"`
+	Password     string `json:"password,omitempty"`
+	Token        string `json:"token,omitempty"`
+}
+
+type datadogTelemetry struct {
+	APIKey string `json:"api_key"`
+	Site   string `json:"site,omitempty"`
+}
+
+type kafkaTelemetry struct {
+	BootstrapServers []string `json:"bootstrap_servers"`
+	Topic            string   `json:"topic"`
+	SecurityProtocol string   `json:"security_protocol,omitempty"`
+	SASLMechanism    string   `json:"sasl_mechanism,omitempty"`
+	SASLUsername     string   `json:"sasl_username,omitempty"`
+	SASLPassword     string   `json:"sasl_password,omitempty"`
+}
+
+func resourceStreamingTelemetryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := putStreamingTelemetry(ctx, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(streamingTelemetryID(d.Get("tenant_name").(string), d.Get("cluster_name").(string)))
+
+	return resourceStreamingTelemetryRead(ctx, d, meta)
+}
+
+func resourceStreamingTelemetryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := putStreamingTelemetry(ctx, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStreamingTelemetryRead(ctx, d, meta)
+}
+
+func resourceStreamingTelemetryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	astraClient := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName, clusterName, err := parseStreamingTelemetryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	orgID, err := getCurrentOrgID(ctx, astraClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusCode, body, err := streamingAPIRequest(ctx, streamingClient, http.MethodGet, streamingTelemetryPath(tenantName, clusterName), orgID, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkReadStatus(statusCode, body, fmt.Sprintf("telemetry configuration of %s", streamingTelemetryTarget(tenantName, clusterName))); err != nil {
+		return readError(ctx, d, err)
+	}
+	var config streamingTelemetryConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return diag.Errorf("failed to decode telemetry configuration: %s", err)
+	}
+	if config.PrometheusRemote == nil && config.Datadog == nil && config.Kafka == nil {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("telemetry configuration of %s", streamingTelemetryTarget(tenantName, clusterName))))
+	}
+
+	if err := setStreamingTelemetryData(d, tenantName, clusterName, config); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceStreamingTelemetryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	astraClient := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName, clusterName, err := parseStreamingTelemetryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	orgID, err := getCurrentOrgID(ctx, astraClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusCode, body, err := streamingAPIRequest(ctx, streamingClient, http.MethodDelete, streamingTelemetryPath(tenantName, clusterName), orgID, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if statusCode >= http.StatusBadRequest && statusCode != http.StatusNotFound {
+		return diag.Errorf("error deleting telemetry configuration of %s: %s", streamingTelemetryTarget(tenantName, clusterName), string(body))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceStreamingTelemetryImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tenantName, clusterName, err := parseStreamingTelemetryID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("tenant_name", tenantName)
+	d.Set("cluster_name", clusterName)
+	return []*schema.ResourceData{d}, nil
+}
+
+// putStreamingTelemetry replaces the telemetry configuration of the tenant or the cluster
+func putStreamingTelemetry(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	astraClient := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName := d.Get("tenant_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	orgID, err := getCurrentOrgID(ctx, astraClient)
+	if err != nil {
+		return err
+	}
+
+	config := expandStreamingTelemetry(d)
+	statusCode, body, err := streamingAPIRequest(ctx, streamingClient, http.MethodPut, streamingTelemetryPath(tenantName, clusterName), orgID, config)
+	if err != nil {
+		return err
+	}
+	if err := permissionError(opConfigureStreamingTelemetry, statusCode, body); err != nil {
+		return err
+	}
+	if statusCode >= http.StatusBadRequest {
+		return fmt.Errorf("error configuring telemetry of %s: %s", streamingTelemetryTarget(tenantName, clusterName), string(body))
+	}
+	return nil
+}
+
+// expandStreamingTelemetry returns the telemetry configuration of the exporter block which is set
+func expandStreamingTelemetry(d *schema.ResourceData) streamingTelemetryConfig {
+	var config streamingTelemetryConfig
+	if v, ok := d.GetOk("prometheus_remote.0"); ok {
+		prometheus := v.(map[string]interface{})
+		config.PrometheusRemote = &prometheusRemoteTelemetry{
+			Endpoint: prometheus["endpoint"].(string),
+			User:     prometheus["username"].(string),
+			Password: prometheus["password"].(string),
+			Token:    prometheus["bearer_token"].(string),
+		}
+		config.PrometheusRemote.AuthStrategy = "basic"
+		if config.PrometheusRemote.Token != "" {
+			config.PrometheusRemote.AuthStrategy = "bearer"
+		}
+	}
+	if v, ok := d.GetOk("datadog.0"); ok {
+		datadog := v.(map[string]interface{})
+		config.Datadog = &datadogTelemetry{
+			APIKey: datadog["api_key"].(string),
+			Site:   datadog["site"].(string),
+		}
+	}
+	if v, ok := d.GetOk("kafka.0"); ok {
+		kafka := v.(map[string]interface{})
+		config.Kafka = &kafkaTelemetry{
+			BootstrapServers: expandStrings(kafka["bootstrap_servers"].([]interface{})),
+			Topic:            kafka["topic"].(string),
+			SecurityProtocol: kafka["security_protocol"].(string),
+			SASLMechanism:    kafka["sasl_mechanism"].(string),
+			SASLUsername:     kafka["sasl_username"].(string),
+			SASLPassword:     kafka["sasl_password"].(string),
+		}
+	}
+	return config
+}
+
+// setStreamingTelemetryData sets the exporter block of the telemetry configuration. The API does not return the
+// secrets, so they are kept from the state.
+func setStreamingTelemetryData(d *schema.ResourceData, tenantName, clusterName string, config streamingTelemetryConfig) error {
+	if err := d.Set("tenant_name", tenantName); err != nil {
+		return err
+	}
+	if err := d.Set("cluster_name", clusterName); err != nil {
+		return err
+	}
+
+	prometheus := []map[string]interface{}{}
+	if c := config.PrometheusRemote; c != nil {
+		prometheus = append(prometheus, map[string]interface{}{
+			"endpoint":     c.Endpoint,
+			"username":     c.User,
+			"password":     streamingTelemetrySecret(d, "prometheus_remote.0.password", c.Password),
+			"bearer_token": streamingTelemetrySecret(d, "prometheus_remote.0.bearer_token", c.Token),
+		})
+	}
+	datadog := []map[string]interface{}{}
+	if c := config.Datadog; c != nil {
+		datadog = append(datadog, map[string]interface{}{
+			"api_key": streamingTelemetrySecret(d, "datadog.0.api_key", c.APIKey),
+			"site":    c.Site,
+		})
+	}
+	kafka := []map[string]interface{}{}
+	if c := config.Kafka; c != nil {
+		kafka = append(kafka, map[string]interface{}{
+			"bootstrap_servers": c.BootstrapServers,
+			"topic":             c.Topic,
+			"security_protocol": c.SecurityProtocol,
+			"sasl_mechanism":    c.SASLMechanism,
+			"sasl_username":     c.SASLUsername,
+			"sasl_password":     streamingTelemetrySecret(d, "kafka.0.sasl_password", c.SASLPassword),
+		})
+	}
+
+	if err := d.Set("prometheus_remote", prometheus); err != nil {
+		return err
+	}
+	if err := d.Set("datadog", datadog); err != nil {
+		return err
+	}
+	return d.Set("kafka", kafka)
+}
+
+// streamingTelemetrySecret returns the secret returned by the API, or the secret of the state when it is redacted
+func streamingTelemetrySecret(d *schema.ResourceData, key, secret string) string {
+	if secret == "" || strings.Trim(secret, "*") == "" {
+		return d.Get(key).(string)
+	}
+	return secret
+}
+
+// streamingTelemetryPath returns the path of the telemetry configuration of the tenant, or of the cluster when the
+// tenant name is empty
+func streamingTelemetryPath(tenantName, clusterName string) string {
+	if tenantName == "" {
+		return fmt.Sprintf("v2/streaming/clusters/%s/telemetry/metrics", clusterName)
+	}
+	return fmt.Sprintf("v2/streaming/tenants/%s/clusters/%s/telemetry/metrics", tenantName, clusterName)
+}
+
+// streamingTelemetryTarget describes the tenant or the cluster in error messages
+func streamingTelemetryTarget(tenantName, clusterName string) string {
+	if tenantName == "" {
+		return fmt.Sprintf("cluster %s", clusterName)
+	}
+	return fmt.Sprintf("tenant %s", tenantName)
+}
+
+// streamingTelemetryID returns the ID of the telemetry configuration: tenant_name/cluster_name, or cluster_name for the
+// telemetry of a cluster
+func streamingTelemetryID(tenantName, clusterName string) string {
+	if tenantName == "" {
+		return resourceid.StreamingClusterTelemetry.Format(clusterName)
+	}
+	return resourceid.StreamingTelemetry.Format(tenantName, clusterName)
+}
+
+func parseStreamingTelemetryID(id string) (string, string, error) {
+	if parts, err := resourceid.StreamingClusterTelemetry.Parse(id); err == nil {
+		return "", parts[0], nil
+	}
+	parts, err := resourceid.StreamingTelemetry.Parse(id)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid streaming telemetry id format: expected %s or %s", resourceid.StreamingTelemetry, resourceid.StreamingClusterTelemetry)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestStreamingTelemetry(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_TELEMETRY_TEST_ENABLED")
+	t.Parallel()
+	tenantName := "terraform-test-" + randomString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingTelemetryConfiguration(tenantName),
+			},
+		},
+	})
+}
+
+func testAccStreamingTelemetryConfiguration(tenantName string) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "streaming_tenant_1" {
+  tenant_name         = "%s"
+  cloud_provider      = "gcp"
+  region              = "useast-4"
+  user_email          = "terraform-test-user@datastax.com"
+  deletion_protection = false
+}
+
+resource "astra_streaming_telemetry" "telemetry_1" {
+  tenant_name  = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  cluster_name = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  prometheus_remote {
+    endpoint = "https://prometheus.example.com/api/v1/write"
+    username = "astra"
+    password = "secret"
+  }
+}
+`, tenantName)
+}
+
+func TestParseStreamingTelemetryID(t *testing.T) {
+	cases := []struct {
+		id          string
+		tenantName  string
+		clusterName string
+		valid       bool
+	}{
+		{"tenant1/pulsar-gcp-useast4", "tenant1", "pulsar-gcp-useast4", true},
+		{"pulsar-gcp-useast4", "", "pulsar-gcp-useast4", true},
+		{"", "", "", false},
+		{"tenant1/", "", "", false},
+		{"a/b/c", "", "", false},
+	}
+	for _, c := range cases {
+		tenantName, clusterName, err := parseStreamingTelemetryID(c.id)
+		if (err == nil) != c.valid {
+			t.Errorf("parseStreamingTelemetryID(%q) error = %v, expected valid %t", c.id, err, c.valid)
+			continue
+		}
+		if tenantName != c.tenantName || clusterName != c.clusterName {
+			t.Errorf("parseStreamingTelemetryID(%q) = %q, %q, expected %q, %q", c.id, tenantName, clusterName, c.tenantName, c.clusterName)
+		}
+		if c.valid && streamingTelemetryID(tenantName, clusterName) != c.id {
+			t.Errorf("streamingTelemetryID(%q, %q) = %q, expected %q", tenantName, clusterName, streamingTelemetryID(tenantName, clusterName), c.id)
+		}
+	}
+}
+
+func TestExpandStreamingTelemetry(t *testing.T) {
+	r := resourceStreamingTelemetry()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_name": "pulsar-gcp-useast4",
+		"prometheus_remote": []interface{}{
+			map[string]interface{}{
+				"endpoint":     "https://prometheus.example.com/api/v1/write",
+				"bearer_token": "token",
+			},
+		},
+	})
+	config := expandStreamingTelemetry(d)
+	if config.Datadog != nil || config.Kafka != nil {
+		t.Fatalf("expected only the prometheus exporter, got %+v", config)
+	}
+	if p := config.PrometheusRemote; p == nil || p.AuthStrategy != "bearer" || p.Token != "token" {
+		t.Fatalf("unexpected prometheus exporter %+v", p)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_name": "pulsar-gcp-useast4",
+		"kafka": []interface{}{
+			map[string]interface{}{
+				"bootstrap_servers": []interface{}{"kafka-1:9093", "kafka-2:9093"},
+				"topic":             "pulsar-metrics",
+				"sasl_password":     "secret",
+			},
+		},
+	})
+	config = expandStreamingTelemetry(d)
+	if k := config.Kafka; k == nil || len(k.BootstrapServers) != 2 || k.Topic != "pulsar-metrics" {
+		t.Fatalf("unexpected kafka exporter %+v", k)
+	}
+
+	// Redacted secrets are kept from the state
+	config.Kafka.SASLPassword = "****"
+	if err := setStreamingTelemetryData(d, "", "pulsar-gcp-useast4", config); err != nil {
+		t.Fatal(err)
+	}
+	if password := d.Get("kafka.0.sasl_password").(string); password != "secret" {
+		t.Errorf("expected the SASL password of the state, got %q", password)
+	}
+}
@@ -1,21 +1,36 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var streamingTenantCreateTimeout = time.Minute * 10
+var streamingTenantUpdateTimeout = time.Minute * 10
+var streamingTenantDeleteTimeout = time.Minute * 10
+
+// streamingTenantPlans are the plans of streaming tenants. Dedicated tenants are created on a private cluster set by
+// cluster_name.
+var streamingTenantPlans = []string{
+	"free",
+	"payg",
+	"dedicated",
+}
+
 func resourceStreamingTenant() *schema.Resource {
 	return &schema.Resource{
 		Description:   "`astra_streaming_tenant` creates an Astra Streaming tenant.",
@@ -23,18 +38,28 @@ func resourceStreamingTenant() *schema.Resource {
 		ReadContext:   resourceStreamingTenantRead,
 		DeleteContext: resourceStreamingTenantDelete,
 		UpdateContext: resourceStreamingTenantUpdate,
+		CustomizeDiff: customdiff.All(
+			resourceStreamingTenantProtocolsDiff,
+			resourceStreamingTenantPlanDiff,
+		),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: &streamingTenantCreateTimeout,
+			Update: &streamingTenantUpdateTimeout,
+			Delete: &streamingTenantDeleteTimeout,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"tenant_name": {
-				Description:  "Streaming tenant name.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[a-z]([-a-z0-9]*[a-z0-9])$"), "name must be atleast 2 characters and contain only alphanumeric characters"),
+				Description:      "Streaming tenant name. Must be 2 to 63 characters, start with a lowercase letter, end with a lowercase letter or number, and only contain lowercase letters, numbers and hyphens. The `pulsar` prefix is reserved.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
 			},
 			"topic": {
 				Description:  "Streaming tenant topic. Please use the `astra_streaming_topic` resource instead.",
@@ -66,7 +91,7 @@ func resourceStreamingTenant() *schema.Resource {
 				ForceNew:         true,
 				RequiredWith:     []string{"cloud_provider"},
 				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
-				DiffSuppressFunc: streamingRegionSuppressDiff,
+				DiffSuppressFunc: ignoreRegionFormat,
 			},
 			"user_email": {
 				Description:  "User email for tenant.",
@@ -74,12 +99,32 @@ func resourceStreamingTenant() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
 			},
+			"plan": {
+				Description:  "The plan of the tenant, one of `free`, `payg` (pay as you go) or `dedicated`. Defaults to the plan assigned by Astra Streaming. A `free` tenant can be upgraded to `payg` in place, which raises its limits. Any other plan change destroys and recreates the tenant.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(streamingTenantPlans, false),
+			},
+			"deletion_policy": deletionPolicySchema("streaming tenant"),
 			"deletion_protection": {
 				Description: "Whether or not to allow Terraform to destroy this tenant. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
 			},
+			"kafka_enabled": {
+				Description: "Whether or not to enable Starlight for Kafka on the tenant, so Kafka clients can produce and consume the messages of its topics. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"rabbitmq_enabled": {
+				Description: "Whether or not to enable Starlight for RabbitMQ on the tenant, so AMQP 0.9.1 clients can produce and consume the messages of its topics. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"broker_service_url": {
 				Description: "The Pulsar Binary Protocol URL used for production and consumption of messages.",
 				Type:        schema.TypeString,
@@ -111,12 +156,69 @@ func resourceStreamingTenant() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			// The fields below are only filled in when Starlight for Kafka is enabled
+			"kafka_bootstrap_servers": {
+				Description: "Kafka bootstrap servers of the tenant.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kafka_security_protocol": {
+				Description: "Kafka security protocol, for the `security.protocol` of Kafka clients.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kafka_sasl_mechanism": {
+				Description: "Kafka SASL mechanism, for the `sasl.mechanism` of Kafka clients.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kafka_sasl_username": {
+				Description: "Kafka SASL username, which is the tenant name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kafka_sasl_password": {
+				Description: "Kafka SASL password, which is a Pulsar token of the tenant.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			// The fields below are only filled in when Starlight for RabbitMQ is enabled
+			"rabbitmq_host": {
+				Description: "RabbitMQ host of the tenant.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rabbitmq_port": {
+				Description: "RabbitMQ port of the tenant, which only accepts TLS connections.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"rabbitmq_virtual_host": {
+				Description: "RabbitMQ virtual host of the tenant.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rabbitmq_password": {
+				Description: "RabbitMQ password, which is a Pulsar token of the tenant. The username is not used.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"rabbitmq_url": {
+				Description: "AMQP URL of the tenant, including the password.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
 		},
 	}
 }
 
-type OrgId struct {
-	ID string `json:"id"`
+// createStreamingTenantRequest adds the plan, which is not known by the client, to the tenant request
+type createStreamingTenantRequest struct {
+	astrastreaming.TenantRequest
+	Plan string `json:"plan,omitempty"`
 }
 
 type StreamingClusters []struct {
@@ -141,11 +243,87 @@ type StreamingClusters []struct {
 }
 
 func resourceStreamingTenantUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// In-place update not supported. This is only here to support deletion_protection
-	return nil
+	// Only the plan and the protocol bridges can be updated in place, the other updatable fields are only kept in the state
+	if !resourceData.HasChanges("plan", "kafka_enabled", "rabbitmq_enabled") {
+		return nil
+	}
+
+	astraClient := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	orgID, err := getCurrentOrgID(ctx, astraClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resourceData.HasChange("plan") {
+		if err := upgradeStreamingTenantPlan(ctx, resourceData, streamingClient, orgID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := updateStreamingTenantProtocols(ctx, resourceData, streamingClient, orgID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStreamingTenantRead(ctx, resourceData, meta)
+}
+
+// upgradeStreamingTenantPlan changes the plan of the tenant and waits until the new plan is reported
+func upgradeStreamingTenantPlan(ctx context.Context, resourceData *schema.ResourceData, streamingClient *astrastreaming.ClientWithResponses, orgID string) error {
+	tenantName := resourceData.Get("tenant_name").(string)
+	plan := resourceData.Get("plan").(string)
+	path := fmt.Sprintf("v2/streaming/tenants/%s/clusters/%s", tenantName, resourceData.Get("cluster_name").(string))
+	statusCode, body, err := streamingAPIRequest(ctx, streamingClient, http.MethodPatch, path, orgID, map[string]string{"plan": plan})
+	if err != nil {
+		return err
+	}
+	if err := permissionError(opUpgradeStreamingTenant, statusCode, body); err != nil {
+		return err
+	}
+	if statusCode >= http.StatusBadRequest {
+		return fmt.Errorf("error upgrading tenant %s to plan %s: %s", tenantName, plan, string(body))
+	}
+
+	return retry.RetryContext(ctx, resourceData.Timeout(schema.TimeoutUpdate), func() *retry.RetryError {
+		resp, err := streamingClient.GetStreamingTenantWithResponse(ctx, orgID, tenantName)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if resp.StatusCode() >= http.StatusInternalServerError {
+			return retry.RetryableError(fmt.Errorf("error fetching tenant %s: %s", tenantName, string(resp.Body)))
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return retry.NonRetryableError(fmt.Errorf("unexpected response fetching tenant %s: %s", tenantName, string(resp.Body)))
+		}
+		if current := astra.StringValue(resp.JSON200.Plan); current != plan {
+			return retry.RetryableError(fmt.Errorf("expected tenant %s to be on plan %s but is on %s", tenantName, plan, current))
+		}
+		return nil
+	})
+}
+
+// resourceStreamingTenantPlanDiff replaces the tenant when its plan changes, unless a free tenant is upgraded to pay as
+// you go, which is done in place
+func resourceStreamingTenantPlanDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("plan") || !diff.NewValueKnown("plan") {
+		return nil
+	}
+	o, n := diff.GetChange("plan")
+	if isStreamingTenantPlanUpgrade(o.(string), n.(string)) {
+		return nil
+	}
+	return diff.ForceNew("plan")
+}
+
+func isStreamingTenantPlanUpgrade(oldPlan, newPlan string) bool {
+	return oldPlan == "free" && newPlan == "payg"
 }
 
 func resourceStreamingTenantDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonedOnDelete(resourceData) {
+		// The tenant is kept, only remove the resource from the state
+		resourceData.SetId("")
+		return nil
+	}
 	if protectedFromDelete(resourceData) {
 		return diag.Errorf("\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_streaming_tenant")
 	}
@@ -160,13 +338,25 @@ func resourceStreamingTenantDelete(ctx context.Context, resourceData *schema.Res
 
 	params := astrastreaming.DeleteStreamingTenantParams{}
 	cluster := resourceData.Get("cluster_name").(string)
-	deleteResponse, err := streamingClient.DeleteStreamingTenantWithResponse(ctx, tenantID, cluster, &params)
-	if err != nil {
+	if err := retry.RetryContext(ctx, resourceData.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		deleteResponse, err := streamingClient.DeleteStreamingTenantWithResponse(ctx, tenantID, cluster, &params)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if deleteResponse.StatusCode() >= http.StatusOK && deleteResponse.StatusCode() < http.StatusMultipleChoices {
+			return nil
+		}
+		if err := permissionError(opDeleteStreamingTenant, deleteResponse.StatusCode(), deleteResponse.Body); err != nil {
+			return retry.NonRetryableError(err)
+		}
+		// Status code >=5xx are assumed to be transient
+		if deleteResponse.StatusCode() >= http.StatusInternalServerError {
+			return retry.RetryableError(fmt.Errorf("error deleting tenant %s", deleteResponse.Body))
+		}
+		return retry.NonRetryableError(fmt.Errorf("Error deleting tenant %s", deleteResponse.Body))
+	}); err != nil {
 		return diag.FromErr(err)
 	}
-	if !strings.HasPrefix(deleteResponse.HTTPResponse.Status, "2") {
-		return diag.Errorf("Error creating tenant %s", deleteResponse.Body)
-	}
 
 	// Deleted. Remove from state.
 	resourceData.SetId("")
@@ -192,21 +382,27 @@ func resourceStreamingTenantRead(ctx context.Context, resourceData *schema.Resou
 	if err != nil {
 		return diag.Errorf("failed to get streaming tenant: %v", err)
 	}
-	if getTenantResponse.HTTPResponse.StatusCode != http.StatusOK {
-		return diag.Errorf("invalid status code returned for tenant: %v", getTenantResponse.HTTPResponse.StatusCode)
+	if err := checkReadStatus(getTenantResponse.StatusCode(), getTenantResponse.Body, fmt.Sprintf("streaming tenant %s", tenantID)); err != nil {
+		return readError(ctx, resourceData, err)
+	}
+	if getTenantResponse.JSON200 == nil {
+		return diag.Errorf("invalid response returned for tenant: %s", string(getTenantResponse.Body))
 	}
 
 	tenantDataFromServer := *getTenantResponse.JSON200
 	if err := setStreamingTenantData(ctx, resourceData, tenantDataFromServer); err != nil {
 		return diag.Errorf("failed to set streaming tenant data: %v", err)
 	}
+	if err := setStreamingTenantProtocolsData(ctx, resourceData, streamingClient, meta.(astraClients).token, orgID); err != nil {
+		return diag.Errorf("failed to set streaming tenant protocol data: %v", err)
+	}
 	return nil
 }
 
 func resourceStreamingTenantCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	clusterName := resourceData.Get("cluster_name").(string) // this can be used for dedicated plan that must specify a cluster name
 	region := resourceData.Get("region").(string)
-	normalizedRegion := removeDashes(region)
+	normalizedRegion := normalizeRegion(region)
 	cloudProvider := resourceData.Get("cloud_provider").(string)
 
 	if clusterName == "" && (cloudProvider == "" || region == "") {
@@ -242,41 +438,73 @@ func resourceStreamingTenantCreate(ctx context.Context, resourceData *schema.Res
 		Topic: &topic,
 	}
 
-	tenantCreationResponse, err := astraStreamingClient.IdOfCreateTenantEndpointWithResponse(ctx, &params, tenantRequest)
+	body, err := json.Marshal(createStreamingTenantRequest{
+		TenantRequest: tenantRequest,
+		Plan:          resourceData.Get("plan").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tenantCreationResponse, err := astraStreamingClient.IdOfCreateTenantEndpointWithBodyWithResponse(ctx, &params, "application/json", bytes.NewReader(body))
 	if err != nil {
 		return diag.Errorf("failed to create tenant: %v", err)
 	}
+	if err := permissionError(opCreateStreamingTenant, tenantCreationResponse.StatusCode(), tenantCreationResponse.Body); err != nil {
+		return diag.FromErr(err)
+	}
 	if tenantCreationResponse.StatusCode() != http.StatusOK {
 		return diag.Errorf("failed to create tenant. Status Code: %d, Message: %s", tenantCreationResponse.StatusCode(), string(tenantCreationResponse.Body))
 	}
 
-	// Now let's fetch the tenant again so that it fills in the missing fields (like userMetricsUrl and tenant ID)
-	streamingTenantResponse, err := astraStreamingClient.GetStreamingTenantWithResponse(ctx, orgID, tenantName)
-	if err != nil {
-		diag.FromErr(err)
-	}
-	if streamingTenantResponse.StatusCode() != http.StatusOK {
-		return diag.Errorf("Unexpected response fetching tenant: %s. Response code: %d, message = %s", tenantName, streamingTenantResponse.StatusCode(), string(streamingTenantResponse.Body))
+	// Now let's fetch the tenant again so that it fills in the missing fields (like userMetricsUrl and tenant ID).
+	// The new tenant may not be visible right away, so retry until it is.
+	var streamingTenantResponse *astrastreaming.GetStreamingTenantResponse
+	if err := retry.RetryContext(ctx, resourceData.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		streamingTenantResponse, err = astraStreamingClient.GetStreamingTenantWithResponse(ctx, orgID, tenantName)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if streamingTenantResponse.StatusCode() == http.StatusNotFound || streamingTenantResponse.StatusCode() >= http.StatusInternalServerError {
+			return retry.RetryableError(fmt.Errorf("tenant %s is not available yet: %s", tenantName, string(streamingTenantResponse.Body)))
+		}
+		if streamingTenantResponse.StatusCode() != http.StatusOK || streamingTenantResponse.JSON200 == nil {
+			return retry.NonRetryableError(fmt.Errorf("Unexpected response fetching tenant: %s. Response code: %d, message = %s", tenantName, streamingTenantResponse.StatusCode(), string(streamingTenantResponse.Body)))
+		}
+		return nil
+	}); err != nil {
+		return diag.FromErr(err)
 	}
 
 	resourceData.SetId(tenantName)
 	setStreamingTenantData(ctx, resourceData, *streamingTenantResponse.JSON200)
 
+	if err := updateStreamingTenantProtocols(ctx, resourceData, astraStreamingClient, orgID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setStreamingTenantProtocolsData(ctx, resourceData, astraStreamingClient, meta.(astraClients).token, orgID); err != nil {
+		return diag.Errorf("failed to set streaming tenant protocol data: %v", err)
+	}
+
 	return nil
 }
 
-// getCurrentOrgID returns the organization ID from the Astra server
-func getCurrentOrgID(ctx context.Context, astraClient *astra.ClientWithResponses) (string, error) {
-	orgResponse, err := astraClient.GetCurrentOrganization(ctx)
+// setStreamingTenantLocation sets the cloud_provider and region of a resource which belongs to the given tenant
+func setStreamingTenantLocation(ctx context.Context, d *schema.ResourceData, astraClient *astra.ClientWithResponses, streamingClient *astrastreaming.ClientWithResponses, tenantName string) error {
+	orgID, err := getCurrentOrgID(ctx, astraClient)
 	if err != nil {
-		return "", fmt.Errorf("failed to get current organization data: %w", err)
+		return err
 	}
-	var orgID OrgId
-	err = json.NewDecoder(orgResponse.Body).Decode(&orgID)
+	tenantResponse, err := streamingClient.GetStreamingTenantWithResponse(ctx, orgID, tenantName)
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal current organization ID: %w", err)
+		return err
+	} else if tenantResponse.StatusCode() != http.StatusOK || tenantResponse.JSON200 == nil {
+		return fmt.Errorf("error fetching streaming tenant %s: %s", tenantName, string(tenantResponse.Body))
 	}
-	return orgID.ID, nil
+
+	if err := d.Set("cloud_provider", astra.StringValue(tenantResponse.JSON200.CloudProvider)); err != nil {
+		return err
+	}
+	return d.Set("region", astra.StringValue(tenantResponse.JSON200.CloudProviderRegion))
 }
 
 func setStreamingTenantData(ctx context.Context, d *schema.ResourceData, tenantResponse astrastreaming.TenantClusterPlanResponse) error {
@@ -318,21 +546,18 @@ func setStreamingTenantData(ctx context.Context, d *schema.ResourceData, tenantR
 			return err
 		}
 	}
+	if tenantResponse.Plan != nil && *tenantResponse.Plan != "" {
+		if err := d.Set("plan", *tenantResponse.Plan); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func parseStreamingTenantID(id string) (string, error) {
-	idParts := strings.Split(strings.ToLower(id), "/")
-	if len(idParts) != 1 {
-		return "", errors.New("invalid tenant id format: expected tenantID/")
+	parts, err := resourceid.StreamingTenant.Parse(id)
+	if err != nil {
+		return "", err
 	}
-	return idParts[0], nil
-}
-
-func streamingRegionSuppressDiff(k, oldValue, newValue string, d *schema.ResourceData) bool {
-	return removeDashes(oldValue) == removeDashes(newValue)
-}
-
-func removeDashes(s string) string {
-	return strings.ReplaceAll(s, "-", "")
+	return parts[0], nil
 }
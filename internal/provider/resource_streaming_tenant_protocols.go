@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// streamingTenantProtocol is a protocol bridge, like Starlight for Kafka, which can be enabled on a tenant
+type streamingTenantProtocol struct {
+	// name is the name of the protocol in the Astra Streaming API and in the host of the bridge
+	name string
+	// enabledAttribute is the attribute which enables the bridge
+	enabledAttribute string
+	// attributes are the computed connection attributes of the bridge
+	attributes []string
+	// connection returns the connection attributes for the host of the bridge
+	connection func(host, tenantName, pulsarToken string) map[string]interface{}
+}
+
+var streamingTenantProtocols = []streamingTenantProtocol{
+	{
+		name:             "kafka",
+		enabledAttribute: "kafka_enabled",
+		attributes: []string{
+			"kafka_bootstrap_servers",
+			"kafka_security_protocol",
+			"kafka_sasl_mechanism",
+			"kafka_sasl_username",
+			"kafka_sasl_password",
+		},
+		// Kafka clients authenticate with the tenant name and a Pulsar token of the tenant
+		connection: func(host, tenantName, pulsarToken string) map[string]interface{} {
+			return map[string]interface{}{
+				"kafka_bootstrap_servers": host + ":9093",
+				"kafka_security_protocol": "SASL_SSL",
+				"kafka_sasl_mechanism":    "PLAIN",
+				"kafka_sasl_username":     tenantName,
+				"kafka_sasl_password":     "token:" + pulsarToken,
+			}
+		},
+	},
+	{
+		name:             "rabbitmq",
+		enabledAttribute: "rabbitmq_enabled",
+		attributes: []string{
+			"rabbitmq_host",
+			"rabbitmq_port",
+			"rabbitmq_virtual_host",
+			"rabbitmq_password",
+			"rabbitmq_url",
+		},
+		// RabbitMQ clients authenticate with a Pulsar token of the tenant as password, the username is not used
+		connection: func(host, tenantName, pulsarToken string) map[string]interface{} {
+			virtualHost := tenantName + "/rabbitmq"
+			return map[string]interface{}{
+				"rabbitmq_host":         host,
+				"rabbitmq_port":         5671,
+				"rabbitmq_virtual_host": virtualHost,
+				"rabbitmq_password":     pulsarToken,
+				"rabbitmq_url":          fmt.Sprintf("amqps://:%s@%s:5671/%s", url.PathEscape(pulsarToken), host, url.PathEscape(virtualHost)),
+			}
+		},
+	},
+}
+
+// setStreamingTenantProtocol enables or disables a protocol bridge on the tenant
+func setStreamingTenantProtocol(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, orgID, tenantName, clusterName, protocol string, enabled bool) error {
+	method := http.MethodPost
+	if !enabled {
+		method = http.MethodDelete
+	}
+	path := fmt.Sprintf("v2/streaming/tenants/%s/clusters/%s/%s", tenantName, clusterName, protocol)
+	statusCode, body, err := streamingAPIRequest(ctx, streamingClient, method, path, orgID, nil)
+	if err != nil {
+		return err
+	}
+	// Disabling a protocol which is not enabled is not an error
+	if !enabled && statusCode == http.StatusNotFound {
+		return nil
+	}
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		action := "enabling"
+		if !enabled {
+			action = "disabling"
+		}
+		return fmt.Errorf("error %s %s on tenant %s: %s", action, protocol, tenantName, string(body))
+	}
+	return nil
+}
+
+// updateStreamingTenantProtocols enables or disables the protocol bridges of the tenant. On creation only the enabled
+// bridges are changed.
+func updateStreamingTenantProtocols(ctx context.Context, d *schema.ResourceData, streamingClient *astrastreaming.ClientWithResponses, orgID string) error {
+	tenantName := d.Get("tenant_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	for _, protocol := range streamingTenantProtocols {
+		if !d.HasChange(protocol.enabledAttribute) {
+			continue
+		}
+		enabled := d.Get(protocol.enabledAttribute).(bool)
+		if err := setStreamingTenantProtocol(ctx, streamingClient, orgID, tenantName, clusterName, protocol.name, enabled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamingProtocolHost returns the host of a protocol bridge of the cluster, which is served next to the Pulsar
+// brokers, for example kafka-gcp-useast1.streaming.datastax.com for pulsar-gcp-useast1.streaming.datastax.com
+func streamingProtocolHost(brokerServiceURL, protocol string) (string, error) {
+	u, err := url.Parse(brokerServiceURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid broker service URL %s: %w", brokerServiceURL, err)
+	}
+	host := u.Hostname()
+	if !strings.HasPrefix(host, "pulsar-") {
+		return "", fmt.Errorf("unexpected broker service URL %s", brokerServiceURL)
+	}
+	return protocol + "-" + strings.TrimPrefix(host, "pulsar-"), nil
+}
+
+// setStreamingTenantProtocolsData sets the connection attributes of the protocol bridges of the tenant. The Pulsar
+// token is only fetched when a protocol bridge is enabled.
+func setStreamingTenantProtocolsData(ctx context.Context, d *schema.ResourceData, streamingClient *astrastreaming.ClientWithResponses, token, orgID string) error {
+	tenantName := d.Get("tenant_name").(string)
+	brokerServiceURL := d.Get("broker_service_url").(string)
+
+	pulsarToken := ""
+	for _, protocol := range streamingTenantProtocols {
+		if !d.Get(protocol.enabledAttribute).(bool) {
+			// Clear the connection attributes of disabled bridges
+			for _, attribute := range protocol.attributes {
+				if err := d.Set(attribute, nil); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if pulsarToken == "" {
+			var err error
+			pulsarToken, err = getPulsarToken(ctx, d.Get("cluster_name").(string), token, OrgId{ID: orgID}, nil, streamingClient, tenantName)
+			if err != nil {
+				return err
+			}
+		}
+		host, err := streamingProtocolHost(brokerServiceURL, protocol.name)
+		if err != nil {
+			return err
+		}
+		connection := protocol.connection(host, tenantName, pulsarToken)
+		for _, attribute := range protocol.attributes {
+			if err := d.Set(attribute, connection[attribute]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resourceStreamingTenantProtocolsDiff marks the connection attributes of a protocol bridge as unknown when it is
+// enabled or disabled
+func resourceStreamingTenantProtocolsDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	for _, protocol := range streamingTenantProtocols {
+		if !diff.HasChange(protocol.enabledAttribute) {
+			continue
+		}
+		for _, attribute := range protocol.attributes {
+			if err := diff.SetNewComputed(attribute); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -88,3 +90,66 @@ func checkStreamingTenantImportState(cloudProvider, region, tenantName string) f
 		return nil
 	}
 }
+
+func TestStreamingProtocolHost(t *testing.T) {
+	host, err := streamingProtocolHost("pulsar+ssl://pulsar-gcp-useast1.streaming.datastax.com:6651", "kafka")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "kafka-gcp-useast1.streaming.datastax.com" {
+		t.Fatalf("unexpected kafka host: %s", host)
+	}
+	if _, err := streamingProtocolHost("pulsar+ssl://broker.example.com:6651", "kafka"); err == nil {
+		t.Fatal("expected an error for an unexpected broker service URL")
+	}
+}
+
+func TestStreamingTenantRabbitMQConnection(t *testing.T) {
+	for _, protocol := range streamingTenantProtocols {
+		if protocol.name != "rabbitmq" {
+			continue
+		}
+		connection := protocol.connection("rabbitmq-gcp-useast1.streaming.datastax.com", "tenant", "token")
+		if connection["rabbitmq_virtual_host"] != "tenant/rabbitmq" {
+			t.Fatalf("unexpected virtual host: %v", connection["rabbitmq_virtual_host"])
+		}
+		if connection["rabbitmq_url"] != "amqps://:token@rabbitmq-gcp-useast1.streaming.datastax.com:5671/tenant%2Frabbitmq" {
+			t.Fatalf("unexpected url: %v", connection["rabbitmq_url"])
+		}
+		return
+	}
+	t.Fatal("rabbitmq protocol not found")
+}
+
+func TestStreamingTenantPlanUpgrade(t *testing.T) {
+	if !isStreamingTenantPlanUpgrade("free", "payg") {
+		t.Error("expected free to payg to be an in-place upgrade")
+	}
+	for _, plans := range [][2]string{{"payg", "free"}, {"free", "dedicated"}, {"payg", "dedicated"}, {"dedicated", "payg"}} {
+		if isStreamingTenantPlanUpgrade(plans[0], plans[1]) {
+			t.Errorf("expected %s to %s to replace the tenant", plans[0], plans[1])
+		}
+	}
+}
+
+func TestCreateStreamingTenantRequest(t *testing.T) {
+	tenantName := "tenant"
+	body, err := json.Marshal(createStreamingTenantRequest{
+		TenantRequest: astrastreaming.TenantRequest{TenantName: &tenantName},
+		Plan:          "payg",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"tenantName":"tenant","plan":"payg"}` {
+		t.Errorf("unexpected request %s", body)
+	}
+
+	body, err = json.Marshal(createStreamingTenantRequest{TenantRequest: astrastreaming.TenantRequest{TenantName: &tenantName}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"tenantName":"tenant"}` {
+		t.Errorf("expected the plan to be omitted when not set, got %s", body)
+	}
+}
@@ -5,12 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,31 +26,32 @@ func resourceStreamingTopic() *schema.Resource {
 		UpdateContext: resourceStreamingTopicUpdate,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceStreamingTopicImport,
 		},
 
 		Schema: map[string]*schema.Schema{
 			// Required
 			"tenant_name": {
-				Description:  "Streaming tenant name.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "Streaming tenant name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
 			},
 			"topic": {
-				Description:  "Streaming tenant topic.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "Streaming tenant topic. This is the short topic name, without the `persistent://tenant/namespace/` prefix.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTopicName,
 			},
 			"region": {
-				Description:  "cloud region",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				Description:      "cloud region",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
+				DiffSuppressFunc: ignoreRegionFormat,
 			},
 			"cloud_provider": {
 				Description:  "Cloud provider",
@@ -59,10 +61,11 @@ func resourceStreamingTopic() *schema.Resource {
 				ValidateFunc: validation.StringMatch(regexp.MustCompile("^.{2,}"), "name must be atleast 2 characters"),
 			},
 			"namespace": {
-				Description: "Pulsar Namespace",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "Pulsar Namespace",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingNamespaceName,
 			},
 			// Optional
 			"deletion_protection": {
@@ -84,8 +87,6 @@ func resourceStreamingTopicDelete(ctx context.Context, resourceData *schema.Reso
 	if protectedFromDelete(resourceData) {
 		return diag.Errorf("\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_streaming_topic")
 	}
-	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
 
 	namespace := resourceData.Get("namespace").(string)
@@ -95,37 +96,24 @@ func resourceStreamingTopicDelete(ctx context.Context, resourceData *schema.Reso
 	cloudProvider := resourceData.Get("cloud_provider").(string)
 	rawRegion := resourceData.Get("region").(string)
 
-	token := meta.(astraClients).token
-
-	region := strings.ReplaceAll(rawRegion, "-", "")
-	pulsarCluster := GetPulsarCluster(cloudProvider, region)
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
-
-	err = json.Unmarshal(bodyBuffer, &org)
+	pulsarCluster := GetPulsarCluster(cloudProvider, rawRegion)
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenant)
 	if err != nil {
-		fmt.Println("Can't deserislize", orgBody)
+		return diag.FromErr(err)
 	}
 
-	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenant)
-
 	deleteTopicParams := astrastreaming.DeleteTopicParams{
 		XDataStaxPulsarCluster: pulsarCluster,
 		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
 	}
 
-	deleteTopicResponse, err := streamingClientv3.DeleteTopic(ctx, tenant, namespace, topic, &deleteTopicParams)
+	deleteTopicResponse, err := streamingClientv3.DeleteTopicWithResponse(ctx, tenant, namespace, topic, &deleteTopicParams)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
-
-	if !strings.HasPrefix(deleteTopicResponse.Status, "2") {
-		bodyBuffer, err = ioutil.ReadAll(deleteTopicResponse.Body)
-		return diag.Errorf("Error deleting topic %s", bodyBuffer)
+	if deleteTopicResponse.StatusCode() < http.StatusOK || deleteTopicResponse.StatusCode() >= http.StatusMultipleChoices {
+		return diag.Errorf("Error deleting topic %s", deleteTopicResponse.Body)
 	}
-	bodyBuffer, err = ioutil.ReadAll(deleteTopicResponse.Body)
 
 	resourceData.SetId("")
 
@@ -133,8 +121,6 @@ func resourceStreamingTopicDelete(ctx context.Context, resourceData *schema.Reso
 }
 
 func resourceStreamingTopicRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
 
 	namespace := resourceData.Get("namespace").(string)
@@ -144,51 +130,57 @@ func resourceStreamingTopicRead(ctx context.Context, resourceData *schema.Resour
 	cloudProvider := resourceData.Get("cloud_provider").(string)
 	rawRegion := resourceData.Get("region").(string)
 
-	token := meta.(astraClients).token
-
-	region := strings.ReplaceAll(rawRegion, "-", "")
-	pulsarCluster := GetPulsarCluster(cloudProvider, region)
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
+	pulsarCluster := GetPulsarCluster(cloudProvider, rawRegion)
+	pulsarToken, _, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenant)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = json.Unmarshal(bodyBuffer, &org)
-	if err != nil {
-		fmt.Println("Can't deserislize", orgBody)
-	}
-
-	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenant)
-
 	getTopicsParams := astrastreaming.GetTopicsParams{
 		XDataStaxPulsarCluster: &pulsarCluster,
 		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
 	}
 
-	createTopicResponse, err := streamingClientv3.GetTopics(ctx, tenant, namespace, &getTopicsParams)
+	getTopicsResponse, err := streamingClientv3.GetTopicsWithResponse(ctx, tenant, namespace, &getTopicsParams)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-
-	if !strings.HasPrefix(createTopicResponse.Status, "2") {
-		bodyBuffer, err = ioutil.ReadAll(createTopicResponse.Body)
-		return diag.Errorf("Error reading topic %s", bodyBuffer)
+	if err := checkReadStatus(getTopicsResponse.StatusCode(), getTopicsResponse.Body, fmt.Sprintf("namespace %s/%s", tenant, namespace)); err != nil {
+		return readError(ctx, resourceData, err)
 	}
-	bodyBuffer, err = ioutil.ReadAll(createTopicResponse.Body)
 
-	//TODO: validate that our topic is there
+	var topics []string
+	if err := json.Unmarshal(getTopicsResponse.Body, &topics); err != nil {
+		return diag.Errorf("failed to decode topics of namespace %s/%s: %s", tenant, namespace, err)
+	}
+	if !containsPulsarTopic(topics, tenant, namespace, topic) {
+		return readError(ctx, resourceData, notFoundError(fmt.Sprintf("topic %s", pulsarTopicName(tenant, namespace, topic))))
+	}
 
-	setStreamingTopicData(resourceData, tenant, topic)
+	if err := setStreamingTopicData(resourceData, tenant, topic); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
+// pulsarTopicName returns the fully qualified name of a persistent topic
+func pulsarTopicName(tenant, namespace, topic string) string {
+	return fmt.Sprintf("persistent://%s/%s/%s", tenant, namespace, topic)
+}
+
+// containsPulsarTopic returns whether the topic is in the topics of the namespace listed by the Pulsar admin API
+func containsPulsarTopic(topics []string, tenant, namespace, topic string) bool {
+	name := pulsarTopicName(tenant, namespace, topic)
+	for _, t := range topics {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceStreamingTopicCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
 
 	namespace := resourceData.Get("namespace").(string)
@@ -198,38 +190,25 @@ func resourceStreamingTopicCreate(ctx context.Context, resourceData *schema.Reso
 	cloudProvider := resourceData.Get("cloud_provider").(string)
 	rawRegion := resourceData.Get("region").(string)
 
-	token := meta.(astraClients).token
-
-	region := strings.ReplaceAll(rawRegion, "-", "")
-	pulsarCluster := GetPulsarCluster(cloudProvider, region)
-	orgBody, _ := client.GetCurrentOrganization(ctx)
-
-	var org OrgId
-	bodyBuffer, err := ioutil.ReadAll(orgBody.Body)
-
-	err = json.Unmarshal(bodyBuffer, &org)
+	pulsarCluster := GetPulsarCluster(cloudProvider, rawRegion)
+	pulsarToken, org, err := getClusterPulsarToken(ctx, meta, pulsarCluster, tenant)
 	if err != nil {
-		fmt.Println("Can't deserislize", orgBody)
+		return diag.FromErr(err)
 	}
 
-	pulsarToken, err := getPulsarToken(ctx, pulsarCluster, token, org, err, streamingClient, tenant)
-
 	createTopicParams := astrastreaming.CreateTopicParams{
 		XDataStaxCurrentOrg:    &org.ID,
 		XDataStaxPulsarCluster: pulsarCluster,
 		Authorization:          fmt.Sprintf("Bearer %s", pulsarToken),
 	}
 
-	createTopicResponse, err := streamingClientv3.CreateTopic(ctx, tenant, namespace, topic, &createTopicParams)
+	createTopicResponse, err := streamingClientv3.CreateTopicWithResponse(ctx, tenant, namespace, topic, &createTopicParams)
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
-
-	if !strings.HasPrefix(createTopicResponse.Status, "2") {
-		bodyBuffer, err = ioutil.ReadAll(createTopicResponse.Body)
-		return diag.Errorf("Error creating topic %s", bodyBuffer)
+	if createTopicResponse.StatusCode() < http.StatusOK || createTopicResponse.StatusCode() >= http.StatusMultipleChoices {
+		return diag.Errorf("Error creating topic %s", createTopicResponse.Body)
 	}
-	bodyBuffer, err = ioutil.ReadAll(createTopicResponse.Body)
 
 	setStreamingTopicData(resourceData, tenant, topic)
 
@@ -249,10 +228,40 @@ func setStreamingTopicData(d *schema.ResourceData, tenantName string, topic stri
 	return nil
 }
 
-func parseStreamingTopicID(id string) (string, string, error) {
-	idParts := strings.Split(strings.ToLower(id), "/")
-	if len(idParts) != 1 {
-		return "", "", errors.New("invalid role id format: expected tenant_name/topic")
+// resourceStreamingTopicImport imports a topic from its fully qualified name, persistent://tenant/namespace/topic,
+// or from tenant/namespace/topic. The cloud provider and region are read from the tenant.
+func resourceStreamingTopicImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenant, namespace, topic, err := parsePulsarTopicName(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setStreamingTenantLocation(ctx, d, client, streamingClient, tenant); err != nil {
+		return nil, err
+	}
+	if err := d.Set("namespace", namespace); err != nil {
+		return nil, err
+	}
+	if err := setStreamingTopicData(d, tenant, topic); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parsePulsarTopicName parses a topic name of the form persistent://tenant/namespace/topic or tenant/namespace/topic
+func parsePulsarTopicName(name string) (string, string, string, error) {
+	if strings.Contains(name, "://") {
+		if !strings.HasPrefix(name, "persistent://") {
+			return "", "", "", fmt.Errorf("invalid topic name %s: only persistent topics are supported", name)
+		}
+		name = strings.TrimPrefix(name, "persistent://")
+	}
+	parts, err := resourceid.PulsarTopic.Parse(name)
+	if err != nil {
+		return "", "", "", errors.New("invalid topic name format: expected persistent://tenant/namespace/topic or tenant/namespace/topic")
 	}
-	return idParts[0], idParts[1], nil
+	return parts[0], parts[1], parts[2], nil
 }
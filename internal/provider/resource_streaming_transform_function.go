@@ -0,0 +1,510 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// transformFunctionArchive is the archive of the builtin transform function of Astra Streaming
+const transformFunctionArchive = "builtin://transforms"
+
+// transformStepTypes are the types of the steps of the transform function
+var transformStepTypes = []string{
+	"drop-fields",
+	"drop",
+	"merge-key-value",
+	"unwrap-key-value",
+	"cast",
+	"flatten",
+	"compute",
+}
+
+func resourceStreamingTransformFunction() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_streaming_transform_function` creates a builtin transform function which applies a list of steps, like dropping fields, filtering messages or computing new fields, to the messages of topics and publishes the results to an output topic. This is typically used between the data topic of `astra_cdc` and a clean topic, without packaging a function archive.",
+		CreateContext: resourceStreamingTransformFunctionCreate,
+		ReadContext:   resourceStreamingTransformFunctionRead,
+		UpdateContext: resourceStreamingTransformFunctionUpdate,
+		DeleteContext: resourceStreamingTransformFunctionDelete,
+		CustomizeDiff: resourceStreamingTransformFunctionCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStreamingTransformFunctionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"tenant_name": {
+				Description:      "Streaming tenant name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingTenantName,
+			},
+			"cloud_provider": {
+				Description:  "Cloud provider of the streaming tenant.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(availableCloudProviders, true),
+			},
+			"region": {
+				Description:      "Cloud region of the streaming tenant.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: ignoreRegionFormat,
+			},
+			"namespace": {
+				Description:      "Pulsar namespace the function runs in.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStreamingNamespaceName,
+			},
+			"name": {
+				Description:  "Name of the function.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(2, 64),
+			},
+			"inputs": {
+				Description: "Fully qualified names of the topics consumed by the function, for example the data topic of `astra_cdc`.",
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"output": {
+				Description: "Fully qualified name of the topic the transformed messages are published to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"step": {
+				Description: "The steps applied to each message, in order.",
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description:  "The type of the step: `drop-fields`, `drop`, `merge-key-value`, `unwrap-key-value`, `cast`, `flatten` or `compute`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(transformStepTypes, false),
+						},
+						"when": {
+							Description: "Only apply the step to the messages matching this expression, for example `value.status == 'deleted'`. A `drop` step drops the matching messages, or all the messages when not set.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"fields": {
+							Description: "The fields dropped by a `drop-fields` step.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"part": {
+							Description:  "The part of the message a `drop-fields`, `cast` or `flatten` step applies to, `key` or `value`. Applies to both when not set.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"key", "value"}, false),
+						},
+						"schema_type": {
+							Description: "The schema type a `cast` step converts the message to, for example `STRING`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"delimiter": {
+							Description: "The delimiter of the names of the fields flattened by a `flatten` step. Defaults to `_` in the function.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"unwrap_key": {
+							Description: "Whether an `unwrap-key-value` step keeps the key instead of the value. Defaults to `false`.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"compute": {
+							Description: "The fields computed by a `compute` step, which can also rename fields.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "The name of the computed field, prefixed with its part, for example `value.full_name`.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"expression": {
+										Description: "The expression computing the field, for example `value.first_name + ' ' + value.last_name`.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"type": {
+										Description: "The schema type of the computed field, for example `STRING` or `INT32`.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"optional": {
+										Description: "Whether the computed field is optional. Defaults to `true`.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			// Optional
+			"parallelism": {
+				Description: "Number of function instances.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     1,
+			},
+			"processing_guarantees": {
+				Description:  "Processing guarantees of the function, `ATLEAST_ONCE`, `ATMOST_ONCE` or `EFFECTIVELY_ONCE`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "ATLEAST_ONCE",
+				ValidateFunc: validation.StringInSlice([]string{"ATLEAST_ONCE", "ATMOST_ONCE", "EFFECTIVELY_ONCE"}, false),
+			},
+			"deletion_protection": {
+				Description: "Whether or not to allow Terraform to destroy this function. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+// TransformFunctionConfig is the configuration of a builtin transform function in the Pulsar admin API
+type TransformFunctionConfig struct {
+	Tenant               string                    `json:"tenant"`
+	Namespace            string                    `json:"namespace"`
+	Name                 string                    `json:"name"`
+	Inputs               []string                  `json:"inputs"`
+	Output               string                    `json:"output"`
+	Jar                  string                    `json:"jar,omitempty"`
+	Parallelism          int                       `json:"parallelism"`
+	ProcessingGuarantees string                    `json:"processingGuarantees"`
+	UserConfig           TransformFunctionSettings `json:"userConfig"`
+}
+
+// TransformFunctionSettings is the user config of the transform function
+type TransformFunctionSettings struct {
+	Steps []TransformStep `json:"steps"`
+}
+
+// TransformStep is a step of the transform function. Only the attributes of the type of the step are set.
+type TransformStep struct {
+	Type       string      `json:"type"`
+	When       string      `json:"when,omitempty"`
+	Fields     interface{} `json:"fields,omitempty"`
+	Part       string      `json:"part,omitempty"`
+	SchemaType string      `json:"schema-type,omitempty"`
+	Delimiter  string      `json:"delimiter,omitempty"`
+	UnwrapKey  *bool       `json:"unwrap-key,omitempty"`
+}
+
+// TransformComputeField is a field computed by a compute step
+type TransformComputeField struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Type       string `json:"type"`
+	Optional   bool   `json:"optional"`
+}
+
+func resourceStreamingTransformFunctionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName := d.Get("tenant_name").(string)
+	namespace := d.Get("namespace").(string)
+	name := d.Get("name").(string)
+
+	steps, err := expandTransformSteps(d.Get("step").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pulsarCluster, pulsarToken, err := getStreamingTenantPulsarToken(ctx, meta, d.Get("cloud_provider").(string), d.Get("region").(string), tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config := TransformFunctionConfig{
+		Tenant:               tenantName,
+		Namespace:            namespace,
+		Name:                 name,
+		Inputs:               expandStrings(d.Get("inputs").([]interface{})),
+		Output:               d.Get("output").(string),
+		Jar:                  transformFunctionArchive,
+		Parallelism:          d.Get("parallelism").(int),
+		ProcessingGuarantees: d.Get("processing_guarantees").(string),
+		UserConfig:           TransformFunctionSettings{Steps: steps},
+	}
+	path := fmt.Sprintf("admin/v3/functions/%s/%s/%s", tenantName, namespace, name)
+	statusCode, body, err := streamingAdminFormRequest(ctx, streamingClientv3, http.MethodPost, path, pulsarCluster, pulsarToken, map[string]interface{}{
+		"functionConfig": config,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return diag.Errorf("error creating transform function %s: %s", name, string(body))
+	}
+
+	d.SetId(resourceid.StreamingFunction.Format(tenantName, namespace, name))
+	return resourceStreamingTransformFunctionRead(ctx, d, meta)
+}
+
+func resourceStreamingTransformFunctionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, namespace, name, err := parseStreamingFunctionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pulsarCluster, pulsarToken, err := getStreamingTenantPulsarToken(ctx, meta, d.Get("cloud_provider").(string), d.Get("region").(string), tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := fmt.Sprintf("admin/v3/functions/%s/%s/%s", tenantName, namespace, name)
+	statusCode, body, err := streamingAdminGet(ctx, streamingClientv3, path, pulsarCluster, pulsarToken)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkReadStatus(statusCode, body, fmt.Sprintf("function %s", name)); err != nil {
+		return readError(ctx, d, err)
+	}
+
+	var config TransformFunctionConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return diag.Errorf("failed to decode function %s: %s", name, err)
+	}
+
+	if err := d.Set("tenant_name", tenantName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("namespace", namespace); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	// The topics and steps are only read back when they are not known yet (on import), since the API returns them in
+	// a normalized form which does not match the configuration
+	if len(d.Get("inputs").([]interface{})) == 0 {
+		if err := d.Set("inputs", config.Inputs); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("output", config.Output); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("step", flattenTransformSteps(config.UserConfig.Steps)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("parallelism", config.Parallelism); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("processing_guarantees", config.ProcessingGuarantees); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStreamingTransformFunctionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// In-place update not supported. This is only here to support deletion_protection
+	return nil
+}
+
+func resourceStreamingTransformFunctionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if protectedFromDelete(d) {
+		return diag.Errorf("\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_streaming_transform_function")
+	}
+	streamingClientv3 := meta.(astraClients).astraStreamingClientv3
+
+	tenantName, namespace, name, err := parseStreamingFunctionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pulsarCluster, pulsarToken, err := getStreamingTenantPulsarToken(ctx, meta, d.Get("cloud_provider").(string), d.Get("region").(string), tenantName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := fmt.Sprintf("admin/v3/functions/%s/%s/%s", tenantName, namespace, name)
+	statusCode, body, err := streamingAdminRequest(ctx, streamingClientv3, http.MethodDelete, path, pulsarCluster, pulsarToken, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if statusCode != http.StatusNotFound && (statusCode < 200 || statusCode >= 300) {
+		return diag.Errorf("error deleting transform function %s: %s", name, string(body))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceStreamingTransformFunctionImport imports a function from tenant/namespace/name. The cloud provider and
+// region are read from the tenant and the rest of the function configuration is read back from the function.
+func resourceStreamingTransformFunctionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	streamingClient := meta.(astraClients).astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenantName, _, _, err := parseStreamingFunctionID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setStreamingTenantLocation(ctx, d, client, streamingClient, tenantName); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceStreamingTransformFunctionCustomizeDiff checks that each step has the attributes required by its type
+func resourceStreamingTransformFunctionCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("step") {
+		return nil
+	}
+	_, err := expandTransformSteps(diff.Get("step").([]interface{}))
+	return err
+}
+
+// expandTransformSteps converts the step blocks to the steps of the user config of the function
+func expandTransformSteps(rawSteps []interface{}) ([]TransformStep, error) {
+	steps := make([]TransformStep, 0, len(rawSteps))
+	for i, rawStep := range rawSteps {
+		s, ok := rawStep.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("step %d is empty", i)
+		}
+		step := TransformStep{
+			Type: s["type"].(string),
+			When: s["when"].(string),
+		}
+		switch step.Type {
+		case "drop-fields":
+			fields := expandStrings(s["fields"].([]interface{}))
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("step %d: \"fields\" is required by drop-fields steps", i)
+			}
+			step.Fields = strings.Join(fields, ",")
+			step.Part = s["part"].(string)
+		case "cast":
+			step.SchemaType = s["schema_type"].(string)
+			if step.SchemaType == "" {
+				return nil, fmt.Errorf("step %d: \"schema_type\" is required by cast steps", i)
+			}
+			step.Part = s["part"].(string)
+		case "flatten":
+			step.Delimiter = s["delimiter"].(string)
+			step.Part = s["part"].(string)
+		case "unwrap-key-value":
+			unwrapKey := s["unwrap_key"].(bool)
+			step.UnwrapKey = &unwrapKey
+		case "compute":
+			rawFields := s["compute"].([]interface{})
+			if len(rawFields) == 0 {
+				return nil, fmt.Errorf("step %d: \"compute\" is required by compute steps", i)
+			}
+			fields := make([]TransformComputeField, 0, len(rawFields))
+			for _, rawField := range rawFields {
+				f := rawField.(map[string]interface{})
+				fields = append(fields, TransformComputeField{
+					Name:       f["name"].(string),
+					Expression: f["expression"].(string),
+					Type:       f["type"].(string),
+					Optional:   f["optional"].(bool),
+				})
+			}
+			step.Fields = fields
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// flattenTransformSteps converts the steps of the user config of the function to step blocks
+func flattenTransformSteps(steps []TransformStep) []map[string]interface{} {
+	flatSteps := make([]map[string]interface{}, 0, len(steps))
+	for _, step := range steps {
+		flatStep := map[string]interface{}{
+			"type":        step.Type,
+			"when":        step.When,
+			"part":        step.Part,
+			"schema_type": step.SchemaType,
+			"delimiter":   step.Delimiter,
+			"unwrap_key":  step.UnwrapKey != nil && *step.UnwrapKey,
+		}
+		switch fields := step.Fields.(type) {
+		case string:
+			// the fields of drop-fields steps are comma separated
+			flatStep["fields"] = strings.Split(fields, ",")
+		case []interface{}:
+			if step.Type == "compute" {
+				flatStep["compute"] = flattenTransformComputeFields(fields)
+			}
+		}
+		flatSteps = append(flatSteps, flatStep)
+	}
+	return flatSteps
+}
+
+func flattenTransformComputeFields(fields []interface{}) []map[string]interface{} {
+	computed := make([]map[string]interface{}, 0, len(fields))
+	for _, rawField := range fields {
+		f, _ := rawField.(map[string]interface{})
+		name, _ := f["name"].(string)
+		expression, _ := f["expression"].(string)
+		fieldType, _ := f["type"].(string)
+		// fields are optional unless set otherwise
+		optional, ok := f["optional"].(bool)
+		computed = append(computed, map[string]interface{}{
+			"name":       name,
+			"expression": expression,
+			"type":       fieldType,
+			"optional":   optional || !ok,
+		})
+	}
+	return computed
+}
+
+func parseStreamingFunctionID(id string) (string, string, string, error) {
+	parts, err := resourceid.StreamingFunction.Parse(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStreamingTransformFunction(t *testing.T) {
+	// Disable this test by default until test works with non-prod clusters
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_SINK_TEST_ENABLED")
+	tenantName := fmt.Sprintf("terraform-test-%s", uuid.New().String())[0:20]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingTransformFunctionConfiguration(tenantName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_transform_function.clean", "parallelism", "1"),
+					resource.TestCheckResourceAttr("astra_streaming_transform_function.clean", "step.0.type", "drop-fields"),
+				),
+			},
+		},
+	})
+}
+
+func TestTransformSteps(t *testing.T) {
+	rawSteps := []interface{}{
+		map[string]interface{}{"type": "drop-fields", "when": "", "fields": []interface{}{"password", "ssn"}, "part": "value", "schema_type": "", "delimiter": "", "unwrap_key": false, "compute": []interface{}{}},
+		map[string]interface{}{"type": "drop", "when": "value.deleted == true", "fields": []interface{}{}, "part": "", "schema_type": "", "delimiter": "", "unwrap_key": false, "compute": []interface{}{}},
+		map[string]interface{}{"type": "compute", "when": "", "fields": []interface{}{}, "part": "", "schema_type": "", "delimiter": "", "unwrap_key": false, "compute": []interface{}{
+			map[string]interface{}{"name": "value.full_name", "expression": "value.first + ' ' + value.last", "type": "STRING", "optional": true},
+		}},
+	}
+	steps, err := expandTransformSteps(rawSteps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(TransformFunctionSettings{Steps: steps})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"steps":[{"type":"drop-fields","fields":"password,ssn","part":"value"},{"type":"drop","when":"value.deleted == true"},{"type":"compute","fields":[{"name":"value.full_name","expression":"value.first + ' ' + value.last","type":"STRING","optional":true}]}]}`
+	if string(encoded) != expected {
+		t.Fatalf("unexpected user config:\n%s\nexpected:\n%s", encoded, expected)
+	}
+
+	// The steps read back from the API match the configured ones
+	var decoded TransformFunctionSettings
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	flatSteps := flattenTransformSteps(decoded.Steps)
+	if !reflect.DeepEqual(flatSteps[0]["fields"], []string{"password", "ssn"}) || flatSteps[1]["when"] != "value.deleted == true" {
+		t.Fatalf("unexpected steps: %v", flatSteps)
+	}
+	if compute := flatSteps[2]["compute"].([]map[string]interface{}); len(compute) != 1 || compute[0]["name"] != "value.full_name" {
+		t.Fatalf("unexpected compute fields: %v", flatSteps[2]["compute"])
+	}
+
+	_, err = expandTransformSteps([]interface{}{
+		map[string]interface{}{"type": "cast", "when": "", "fields": []interface{}{}, "part": "", "schema_type": "", "delimiter": "", "unwrap_key": false, "compute": []interface{}{}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "schema_type") {
+		t.Fatalf("expected a missing schema_type error, got %v", err)
+	}
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccStreamingTransformFunctionConfiguration(tenantName string) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "tenant" {
+  tenant_name    = "%s"
+  topic          = "terraformtest"
+  region         = "useast-4"
+  cloud_provider = "gcp"
+  user_email     = "terraform-test-user@datastax.com"
+}
+
+resource "astra_streaming_transform_function" "clean" {
+  tenant_name    = astra_streaming_tenant.tenant.tenant_name
+  cloud_provider = "gcp"
+  region         = "useast-4"
+  namespace      = "default"
+  name           = "clean"
+  inputs         = ["persistent://${astra_streaming_tenant.tenant.tenant_name}/default/terraformtest"]
+  output         = "persistent://${astra_streaming_tenant.tenant.tenant_name}/default/terraformtest-clean"
+
+  step {
+    type   = "drop-fields"
+    fields = ["password"]
+    part   = "value"
+  }
+
+  deletion_protection = false
+}
+`, tenantName)
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -37,11 +37,11 @@ func resourceTable() *schema.Resource {
 				ValidateDiagFunc: validateKeyspace,
 			},
 			"table": {
-				Description:      "Table name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
+				Description:      "Table name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character. Reserved CQL keywords are not allowed.",
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
-				ValidateDiagFunc: validateKeyspace,
+				ValidateDiagFunc: validateCQLIdentifier,
 			},
 			"database_id": {
 				Description:  "Astra database to create the keyspace.",
@@ -80,14 +80,14 @@ func resourceTable() *schema.Resource {
 					},
 				},
 			},
+			// Computed
+			"create_table_cql": tableCreateCQLSchema(),
 		},
 	}
 }
 
 func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
-	providerVersion := meta.(astraClients).providerVersion
-	userAgent := meta.(astraClients).userAgent
 	token := meta.(astraClients).token
 
 	stargateCache := meta.(astraClients).stargateClientCache
@@ -147,7 +147,7 @@ func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		restClient = val
 	} else {
 		var err error
-		restClient, err = newRestClient(databaseID, providerVersion, userAgent, region)
+		restClient, err = newRestClient(meta, databaseID, region)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -205,12 +205,10 @@ func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return resourceTableRead(ctx, d, meta)
 }
 
 func resourceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	providerVersion := meta.(astraClients).providerVersion
-	userAgent := meta.(astraClients).userAgent
 	token := meta.(astraClients).token
 
 	tableName := d.Get("table").(string)
@@ -229,7 +227,7 @@ func resourceTableRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		restClient = val
 	} else {
 		var err error
-		restClient, err = newRestClient(databaseID, providerVersion, userAgent, region)
+		restClient, err = newRestClient(meta, databaseID, region)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -237,34 +235,39 @@ func resourceTableRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	fmt.Printf("%v", restClient)
 
+	raw := true
 	params := astrarestapi.GetTableParams{
-		Raw:             nil,
+		Raw:             &raw,
 		XCassandraToken: token,
 	}
 	resp, err := restClient.GetTable(ctx, keyspaceName, tableName, &params)
 	if err != nil {
-		b, _ := io.ReadAll(resp.Body)
-		return diag.FromErr(fmt.Errorf("Error getting table (not retrying) err: %s,  body: %s", err, b))
-	} else if resp.StatusCode == 409 {
-		// DevOps API returns 409 for concurrent modifications, these need to be retried.
-		b, _ := io.ReadAll(resp.Body)
-		return diag.FromErr(fmt.Errorf("error getting table (retrying): %s", b))
-	} else if resp.StatusCode >= 400 {
-		//table not found
-		d.SetId("")
-		return nil
+		return diag.FromErr(fmt.Errorf("Error getting table (not retrying) err: %s", err))
+	}
+
+	tableResp, err := astrarestapi.ParseGetTableResponse(resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkReadStatus(tableResp.StatusCode(), tableResp.Body, fmt.Sprintf("table %s.%s", keyspaceName, tableName)); err != nil {
+		return readError(ctx, d, err)
 	}
 
 	if err := setTableResourceData(d, databaseID, keyspaceName, tableName); err != nil {
 		return diag.FromErr(fmt.Errorf("Error setting keyspace data (not retrying) %s", err))
 	}
+	createTableCQL := ""
+	if tableResp.JSON200 != nil {
+		createTableCQL = tableCreateCQL(keyspaceName, tableResp.JSON200)
+	}
+	if err := d.Set("create_table_cql", createTableCQL); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourceTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	providerVersion := meta.(astraClients).providerVersion
-	userAgent := meta.(astraClients).userAgent
 	token := meta.(astraClients).token
 
 	tableName := d.Get("table").(string)
@@ -283,7 +286,7 @@ func resourceTableDelete(ctx context.Context, d *schema.ResourceData, meta inter
 		restClient = val
 	} else {
 		var err error
-		restClient, err = newRestClient(databaseID, providerVersion, userAgent, region)
+		restClient, err = newRestClient(meta, databaseID, region)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -312,7 +315,7 @@ func resourceTableDelete(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func setTableResourceData(d *schema.ResourceData, databaseID string, keyspaceName string, table string) error {
-	d.SetId(fmt.Sprintf("%s/%s/%s", databaseID, keyspaceName, table))
+	d.SetId(resourceid.Table.Format(databaseID, keyspaceName, table))
 	if err := d.Set("table", table); err != nil {
 		return err
 	}
@@ -327,9 +330,9 @@ func setTableResourceData(d *schema.ResourceData, databaseID string, keyspaceNam
 }
 
 func parseTableID(id string) (string, string, string, error) {
-	idParts := strings.Split(id, "/")
-	if len(idParts) != 3 {
-		return "", "", "", errors.New("invalid keyspace id format: expected database_id/keyspace/table")
+	parts, err := resourceid.Table.Parse(id)
+	if err != nil {
+		return "", "", "", err
 	}
-	return idParts[0], idParts[1], idParts[2], nil
+	return parts[0], parts[1], parts[2], nil
 }
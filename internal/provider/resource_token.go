@@ -2,13 +2,15 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/datastax/terraform-provider-astra/v2/internal/resourceid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceToken() *schema.Resource {
@@ -25,14 +27,61 @@ func resourceToken() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			// Required
 			"roles": {
-				Description: "List of Role IDs to be assigned to the generated token",
-				Type:        schema.TypeList,
-				Required:    true,
-				ForceNew:    true,
+				Description:  "List of Role IDs to be assigned to the generated token. Required unless `scope` is set.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"roles", "scope"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"scope": {
+				Description: "Restricts the token to databases or keyspaces. A custom role granting `policy` on only these resources is created with the token, assigned to it in addition to `roles`, and deleted with it.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database_ids": {
+							Description: "IDs of the databases the token can access.",
+							Type:        schema.TypeList,
+							Required:    true,
+							ForceNew:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsUUID),
+							},
+						},
+						"keyspaces": {
+							Description: "Names of the keyspaces of the databases the token can access. All keyspaces, including future ones, are accessible when this is not set.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"policy": {
+							Description: "List of policies granted on the databases and keyspaces, like `db-cql` or `db-table-select`. See https://docs.datastax.com/en/astra/docs/user-permissions.html#_operational_roles_detail for supported policies.",
+							Type:        schema.TypeList,
+							Required:    true,
+							ForceNew:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"scope_role_id": {
+				Description: "ID of the custom role created for `scope`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"client_id": {
 				Description: "Client id, use as username in cql to connect",
 				Type:        schema.TypeString,
@@ -71,27 +120,53 @@ func resourceTokenCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		rolesList[k] = roleId
 	}
 
-	tokenJSON := astra.GenerateTokenForClientJSONRequestBody{
-		Roles: rolesList,
+	scopeRoleID := ""
+	if scope, ok := expandTokenScope(d); ok {
+		orgID, err := getCurrentOrgID(ctx, client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		scopeRoleID, err = createTokenScopeRole(ctx, client, scope.policy(orgID))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rolesList = append(rolesList, scopeRoleID)
 	}
-	resp, err := client.GenerateTokenForClientWithResponse(ctx,
-		tokenJSON,
-	)
 
+	token, err := generateToken(ctx, client, rolesList)
 	if err != nil {
+		// The token was not created, so the scope role would be left unused
+		if scopeRoleID != "" {
+			client.DeleteOrganizationRoleWithResponse(ctx, astra.RoleIdParam(scopeRoleID))
+		}
 		return diag.FromErr(err)
-	} else if resp.StatusCode() >= 400 {
-		return diag.Errorf("error adding role to org: %s", resp.Body)
 	}
 
-	token := (*resp.JSON200).(map[string]interface{})
 	if err := setTokenData(d, token); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("scope_role_id", scopeRoleID); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
+// generateToken creates a token with the roles and returns it, with its clientId, secret and token
+func generateToken(ctx context.Context, client *astra.ClientWithResponses, roles []string) (map[string]interface{}, error) {
+	resp, err := client.GenerateTokenForClientWithResponse(ctx, astra.GenerateTokenForClientJSONRequestBody{
+		Roles: roles,
+	})
+	if err != nil {
+		return nil, err
+	} else if err := permissionError(opCreateToken, resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	} else if resp.StatusCode() >= 400 {
+		return nil, fmt.Errorf("error adding role to org: %s", resp.Body)
+	}
+	return (*resp.JSON200).(map[string]interface{}), nil
+}
+
 func resourceTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 
@@ -104,6 +179,16 @@ func resourceTokenDelete(ctx context.Context, d *schema.ResourceData, meta inter
 
 	client.DeleteTokenForClient(ctx, astra.ClientIdParam(clientID))
 
+	// The scope role is only used by this token
+	if scopeRoleID := d.Get("scope_role_id").(string); scopeRoleID != "" {
+		resp, err := client.DeleteOrganizationRoleWithResponse(ctx, astra.RoleIdParam(scopeRoleID))
+		if err != nil {
+			return diag.FromErr(err)
+		} else if resp.StatusCode() >= 400 && resp.StatusCode() != http.StatusNotFound {
+			return diag.Errorf("error deleting scope role %s of token: %s", scopeRoleID, resp.Body)
+		}
+	}
+
 	return nil
 }
 
@@ -122,6 +207,9 @@ func resourceTokenRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if token == nil {
+		return readError(ctx, d, notFoundError(fmt.Sprintf("token %s", clientID)))
+	}
 
 	d.SetId(fmt.Sprintf("%s", clientID))
 	if err := d.Set("client_id", token["client_id"]); err != nil {
@@ -153,9 +241,79 @@ func setTokenData(d *schema.ResourceData, tokenMap map[string]interface{}) error
 }
 
 func parseTokenID(id string) (string, error) {
-	idParts := strings.Split(id, "/")
-	if len(idParts) != 1 {
-		return "", errors.New("invalid token id format: expected clientID/")
+	parts, err := resourceid.Token.Parse(id)
+	if err != nil {
+		return "", err
+	}
+	return parts[0], nil
+}
+
+// tokenScope restricts a token to databases or keyspaces
+type tokenScope struct {
+	databaseIDs []string
+	keyspaces   []string
+	actions     []string
+}
+
+func expandTokenScope(d *schema.ResourceData) (tokenScope, bool) {
+	scopes := d.Get("scope").([]interface{})
+	if len(scopes) == 0 || scopes[0] == nil {
+		return tokenScope{}, false
+	}
+	scope := scopes[0].(map[string]interface{})
+	return tokenScope{
+		databaseIDs: expandStrings(scope["database_ids"].([]interface{})),
+		keyspaces:   expandStrings(scope["keyspaces"].([]interface{})),
+		actions:     expandStrings(scope["policy"].([]interface{})),
+	}, true
+}
+
+func expandStrings(values []interface{}) []string {
+	strs := make([]string, len(values))
+	for k, v := range values {
+		strs[k] = v.(string)
+	}
+	return strs
+}
+
+// policy returns the policy granting the actions on the databases, or only on their keyspaces when set, and their tables
+func (s tokenScope) policy(orgID string) astra.Policy {
+	keyspaces := s.keyspaces
+	if len(keyspaces) == 0 {
+		keyspaces = []string{"*"}
+	}
+	var resources []string
+	for _, databaseID := range s.databaseIDs {
+		database := fmt.Sprintf("%s%s:db:%s", roleResourcePrefix, orgID, databaseID)
+		resources = append(resources, database)
+		for _, keyspace := range keyspaces {
+			resources = append(resources, fmt.Sprintf("%s:keyspace:%s", database, keyspace), fmt.Sprintf("%s:keyspace:%s:table:*", database, keyspace))
+		}
+	}
+	actions := make([]astra.PolicyAction, len(s.actions))
+	for k, a := range s.actions {
+		actions[k] = astra.PolicyAction(a)
+	}
+	return astra.Policy{
+		Actions:     actions,
+		Description: "Scope of a token managed by Terraform",
+		Effect:      astra.Allow,
+		Resources:   resources,
+	}
+}
+
+// createTokenScopeRole creates the custom role of a scoped token and returns its ID
+func createTokenScopeRole(ctx context.Context, client *astra.ClientWithResponses, policy astra.Policy) (string, error) {
+	resp, err := client.AddOrganizationRoleWithResponse(ctx, astra.AddOrganizationRoleJSONRequestBody{
+		Name:   id.PrefixedUniqueId("token-scope-"),
+		Policy: policy,
+	})
+	if err != nil {
+		return "", err
+	} else if err := permissionError(opCreateRole, resp.StatusCode(), resp.Body); err != nil {
+		return "", err
+	} else if resp.StatusCode() >= 400 || resp.JSON201 == nil || resp.JSON201.Id == nil {
+		return "", fmt.Errorf("error creating scope role of token: Status: %s, %s", resp.Status(), resp.Body)
 	}
-	return idParts[0], nil
+	return *resp.JSON201.Id, nil
 }
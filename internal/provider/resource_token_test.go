@@ -2,8 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -22,7 +24,7 @@ func TestToken(t *testing.T) {
 func testAccTokenConfiguration() string {
 	return fmt.Sprintf(`
 resource "astra_role" "example" {
-  role_name = "example-role"
+  role_name = "terraform-test-example-role"
   description = "test role"
   effect = "allow"
   resources = []
@@ -33,3 +35,38 @@ resource "astra_token" "example" {
 }
 `)
 }
+
+func TestTokenScopePolicy(t *testing.T) {
+	scope := tokenScope{
+		databaseIDs: []string{"db1"},
+		keyspaces:   []string{"ks1", "ks2"},
+		actions:     []string{"db-cql"},
+	}
+	policy := scope.policy("org")
+	expected := []string{
+		"drn:astra:org:org:db:db1",
+		"drn:astra:org:org:db:db1:keyspace:ks1",
+		"drn:astra:org:org:db:db1:keyspace:ks1:table:*",
+		"drn:astra:org:org:db:db1:keyspace:ks2",
+		"drn:astra:org:org:db:db1:keyspace:ks2:table:*",
+	}
+	if !reflect.DeepEqual(policy.Resources, expected) {
+		t.Errorf("expected resources %v, got %v", expected, policy.Resources)
+	}
+	if !reflect.DeepEqual(policy.Actions, []astra.PolicyAction{"db-cql"}) || policy.Effect != astra.Allow {
+		t.Errorf("unexpected policy %v", policy)
+	}
+
+	scope = tokenScope{databaseIDs: []string{"db1", "db2"}, actions: []string{"db-cql"}}
+	expected = []string{
+		"drn:astra:org:org:db:db1",
+		"drn:astra:org:org:db:db1:keyspace:*",
+		"drn:astra:org:org:db:db1:keyspace:*:table:*",
+		"drn:astra:org:org:db:db2",
+		"drn:astra:org:org:db:db2:keyspace:*",
+		"drn:astra:org:org:db:db2:keyspace:*:table:*",
+	}
+	if resources := scope.policy("org").Resources; !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected resources %v, got %v", expected, resources)
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTokens() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`astra_tokens` creates one token per role, for example to bootstrap the credentials of several services at once. The tokens are created together: if one of them cannot be created, the others are deleted. When a token is deleted outside of Terraform, the resource is replaced: the remaining tokens are deleted and all the tokens are created again.",
+		CreateContext: resourceTokensCreate,
+		ReadContext:   resourceTokensRead,
+		DeleteContext: resourceTokensDelete,
+		CustomizeDiff: resourceTokensCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"roles": {
+				Description: "Role IDs to create a token for, each token is assigned one role.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Computed
+			"client_ids": {
+				Description: "Client id of the token of each role, by role ID. Use as username in cql to connect.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secrets": {
+				Description: "Secret of the token of each role, by role ID. Use as password in cql to connect.",
+				Type:        schema.TypeMap,
+				Sensitive:   true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tokens": {
+				Description: "Token of each role, by role ID. Use as auth bearer for API calls or as password in combination with the word `token` in cql.",
+				Type:        schema.TypeMap,
+				Sensitive:   true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceTokensCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	roles := expandStrings(d.Get("roles").(*schema.Set).List())
+	// Check all the roles before creating any token
+	for _, roleID := range roles {
+		if _, err := listRole(ctx, client, roleID); err != nil {
+			return diag.Errorf("Failed to create tokens. Role ID not found: %s", roleID)
+		}
+	}
+
+	clientIDs := map[string]string{}
+	secrets := map[string]string{}
+	tokens := map[string]string{}
+	for _, roleID := range roles {
+		token, err := generateToken(ctx, client, []string{roleID})
+		if err != nil {
+			// The tokens are only kept when all of them were created
+			if deleteErr := deleteTokens(ctx, client, clientIDs); deleteErr != nil {
+				return diag.Errorf("error creating token of role %s: %s, and the tokens already created could not be deleted: %s", roleID, err, deleteErr)
+			}
+			return diag.Errorf("error creating token of role %s: %s", roleID, err)
+		}
+		clientIDs[roleID] = token["clientId"].(string)
+		secrets[roleID] = token["secret"].(string)
+		tokens[roleID] = token["token"].(string)
+	}
+
+	d.SetId(id.UniqueId())
+	if err := d.Set("client_ids", clientIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secrets", secrets); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tokens", tokens); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceTokensRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	existing, err := listTokenClientIDs(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The secrets cannot be read again, so the tokens deleted outside of Terraform are only dropped from state and
+	// the resource is replaced by the next plan. The remaining tokens stay tracked, so that the replacement deletes them.
+	clientIDs := d.Get("client_ids").(map[string]interface{})
+	secrets := d.Get("secrets").(map[string]interface{})
+	tokens := d.Get("tokens").(map[string]interface{})
+	for roleID, clientID := range clientIDs {
+		if !existing[strings.ToLower(clientID.(string))] {
+			tflog.Warn(ctx, fmt.Sprintf("token %s of role %s not found, the tokens of %s will be replaced", clientID, roleID, d.Id()))
+			delete(clientIDs, roleID)
+			delete(secrets, roleID)
+			delete(tokens, roleID)
+		}
+	}
+	if err := d.Set("client_ids", clientIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secrets", secrets); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tokens", tokens); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceTokensDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	clientIDs := map[string]string{}
+	for roleID, clientID := range d.Get("client_ids").(map[string]interface{}) {
+		clientIDs[roleID] = clientID.(string)
+	}
+	if err := deleteTokens(ctx, client, clientIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceTokensCustomizeDiff replaces the tokens when some of them were deleted outside of Terraform
+func resourceTokensCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || diff.HasChange("roles") {
+		return nil
+	}
+	if len(diff.Get("client_ids").(map[string]interface{})) >= diff.Get("roles").(*schema.Set).Len() {
+		return nil
+	}
+	if err := diff.SetNewComputed("client_ids"); err != nil {
+		return err
+	}
+	return diff.ForceNew("client_ids")
+}
+
+// deleteTokens deletes the tokens by client ID, ignoring the ones already deleted
+func deleteTokens(ctx context.Context, client *astra.ClientWithResponses, clientIDs map[string]string) error {
+	for _, clientID := range clientIDs {
+		resp, err := client.DeleteTokenForClientWithResponse(ctx, astra.ClientIdParam(clientID))
+		if err != nil {
+			return err
+		} else if resp.StatusCode() >= 400 && resp.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("error deleting token %s: %s", clientID, resp.Body)
+		}
+	}
+	return nil
+}
+
+// listTokenClientIDs returns the client IDs of the tokens of the organization, in lower case
+func listTokenClientIDs(ctx context.Context, client *astra.ClientWithResponses) (map[string]bool, error) {
+	resp, err := client.GetClientsForOrgWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := checkReadStatus(resp.StatusCode(), resp.Body, "client tokens"); err != nil {
+		return nil, err
+	}
+
+	clientIDs := map[string]bool{}
+	for _, v := range (*resp.JSON200).(map[string]interface{})["clients"].([]interface{}) {
+		clientIDs[strings.ToLower(v.(map[string]interface{})["clientId"].(string))] = true
+	}
+	return clientIDs, nil
+}
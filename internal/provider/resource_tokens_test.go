@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestTokens(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTokensConfiguration(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_tokens.services", "client_ids.%", "2"),
+					resource.TestCheckResourceAttr("astra_tokens.services", "tokens.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTokensConfiguration() string {
+	return `
+resource "astra_role" "reader" {
+  role_name   = "terraform-test-tokens-reader"
+  description = "test role"
+  effect      = "allow"
+  resources   = []
+  policy      = ["org-db-view"]
+}
+resource "astra_role" "writer" {
+  role_name   = "terraform-test-tokens-writer"
+  description = "test role"
+  effect      = "allow"
+  resources   = []
+  policy      = ["org-db-view", "org-db-expand"]
+}
+resource "astra_tokens" "services" {
+  roles = [astra_role.reader.role_id, astra_role.writer.role_id]
+}
+`
+}
+
+func TestDeleteTokens(t *testing.T) {
+	deleted := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch clientID {
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "failing":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			deleted = append(deleted, clientID)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	client, err := astra.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := deleteTokens(context.Background(), client, map[string]string{"role1": "client1", "role2": "missing"}); err != nil {
+		t.Fatalf("expected the missing token to be ignored, got %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "client1" {
+		t.Fatalf("unexpected deleted tokens: %v", deleted)
+	}
+	if err := deleteTokens(context.Background(), client, map[string]string{"role1": "failing"}); err == nil || !strings.Contains(err.Error(), "error deleting token failing") {
+		t.Fatalf("expected an error, got %v", err)
+	}
+}
+
+func TestTokensPartiallyDeleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"clients": [{"clientId": "CLIENT1"}]}`))
+	}))
+	defer server.Close()
+	client, err := astra.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := resourceTokens()
+	config := map[string]interface{}{
+		"roles": []interface{}{"role1", "role2"},
+	}
+	d := schema.TestResourceDataRaw(t, tokens.Schema, config)
+	d.SetId("tokens")
+	d.Set("client_ids", map[string]interface{}{"role1": "client1", "role2": "client2"})
+	d.Set("secrets", map[string]interface{}{"role1": "secret1", "role2": "secret2"})
+	d.Set("tokens", map[string]interface{}{"role1": "token1", "role2": "token2"})
+
+	if diff, err := tokens.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil); err != nil || diff != nil {
+		t.Fatalf("expected no changes before a token is deleted, got %v, %v", diff, err)
+	}
+
+	if diags := resourceTokensRead(context.Background(), d, astraClients{astraClient: client}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the tokens to stay in state")
+	}
+	if clientIDs := d.Get("client_ids").(map[string]interface{}); len(clientIDs) != 1 || clientIDs["role1"] != "client1" {
+		t.Fatalf("unexpected client IDs: %v", clientIDs)
+	}
+
+	diff, err := tokens.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected the tokens to be replaced, got %v", diff)
+	}
+}
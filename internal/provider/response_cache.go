@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache is a read-through cache of the successful GET responses of the Astra APIs, so refreshing many resources
+// of the same database or tenant does not fetch it again for each of them. Entries expire after the TTL, and every
+// other request invalidates the entries of the object it changes.
+type responseCache struct {
+	ttl     time.Duration
+	lock    sync.Mutex
+	entries map[string]cachedResponse
+	// now is replaced in tests
+	now func() time.Time
+}
+
+type cachedResponse struct {
+	path       string
+	expires    time.Time
+	statusCode int
+	status     string
+	header     http.Header
+	body       []byte
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedResponse),
+		now:     time.Now,
+	}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return cachedResponse{}, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (c *responseCache) put(key string, entry cachedResponse) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry.expires = c.now().Add(c.ttl)
+	c.entries[key] = entry
+}
+
+// invalidate removes the entries of the object changed by a request to the path, of the objects below it, and of the
+// collections containing it
+func (c *responseCache) invalidate(path string) {
+	root := cacheInvalidationRoot(path)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, entry := range c.entries {
+		if pathWithin(entry.path, root) || pathWithin(root, entry.path) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cacheInvalidationRoot returns the path of the object changed by a request, for example /v2/databases/{id} for
+// /v2/databases/{id}/keyspaces/{keyspace}. Pulsar admin objects depend on each other, like the topics of a namespace,
+// so a change to any of them invalidates all of them.
+func cacheInvalidationRoot(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	depth := 3
+	if segments[0] == "admin" {
+		depth = 2
+	}
+	if len(segments) > depth {
+		segments = segments[:depth]
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// pathWithin returns whether the path is the parent path or below it
+func pathWithin(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, strings.TrimSuffix(parent, "/")+"/")
+}
+
+// responseCacheKey identifies a request by its URL and the headers selecting the credentials, organization and Pulsar
+// cluster, since the same URL returns different responses for them
+func responseCacheKey(req *http.Request) string {
+	return strings.Join([]string{
+		req.URL.String(),
+		req.Header.Get("Authorization"),
+		req.Header.Get("X-DataStax-Current-Org"),
+		req.Header.Get("X-DataStax-Pulsar-Cluster"),
+	}, "\n")
+}
+
+// cachingTransport serves GET requests from the response cache
+type cachingTransport struct {
+	base  http.RoundTripper
+	cache *responseCache
+}
+
+// newCachingClient wraps the transport of the client to cache the GET responses, or returns the client as is when the
+// cache is disabled
+func newCachingClient(client *http.Client, cache *responseCache) *http.Client {
+	if cache == nil {
+		return client
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &cachingTransport{
+			base:  transport,
+			cache: cache,
+		},
+		CheckRedirect: client.CheckRedirect,
+		Jar:           client.Jar,
+		Timeout:       client.Timeout,
+	}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		// Responses fetched while the request is sent may already be stale, so invalidate before and after
+		t.cache.invalidate(req.URL.Path)
+		resp, err := t.base.RoundTrip(req)
+		t.cache.invalidate(req.URL.Path)
+		return resp, err
+	}
+
+	key := responseCacheKey(req)
+	if entry, ok := t.cache.get(key); ok {
+		return &http.Response{
+			Status:        entry.status,
+			StatusCode:    entry.statusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.put(key, cachedResponse{
+		path:       req.URL.Path,
+		statusCode: resp.StatusCode,
+		status:     resp.Status,
+		header:     resp.Header.Clone(),
+		body:       body,
+	})
+	return resp, nil
+}
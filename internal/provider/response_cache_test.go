@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+		if r.URL.Path == "/v2/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, r.URL.Path)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	cache := newResponseCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	client := newCachingClient(server.Client(), cache)
+
+	get := func(path, org string) string {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-DataStax-Current-Org", org)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+	post := func(path string) {
+		resp, err := client.Post(server.URL+path, "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	const database = "/v2/databases/8d8b3c2e-5c5b-4c36-9d9e-6d1b9d2c1a11"
+	const otherDatabase = "/v2/databases/a6b2b0cf-9f32-4e6d-8a57-52b4d9f1b0e2"
+	for i := 0; i < 3; i++ {
+		if body := get(database, "org1"); body != database {
+			t.Fatalf("unexpected cached body %q", body)
+		}
+		get(otherDatabase, "org1")
+		get("/v2/databases", "org1")
+		get("/v2/missing", "org1")
+	}
+	if n := requests["GET "+database]; n != 1 {
+		t.Errorf("expected the database to be fetched once, got %d", n)
+	}
+	if n := requests["GET /v2/missing"]; n != 3 {
+		t.Errorf("expected errors not to be cached, got %d requests", n)
+	}
+
+	// Other organizations do not share the cached responses
+	get(database, "org2")
+	if n := requests["GET "+database]; n != 2 {
+		t.Errorf("expected the database to be fetched for another organization, got %d", n)
+	}
+
+	// Changing a keyspace invalidates its database and the database list, but not the other databases
+	post(database + "/keyspaces/ks1")
+	get(database, "org1")
+	get("/v2/databases", "org1")
+	get(otherDatabase, "org1")
+	if requests["GET "+database] != 3 || requests["GET /v2/databases"] != 2 || requests["GET "+otherDatabase] != 1 {
+		t.Errorf("unexpected requests after invalidation: %v", requests)
+	}
+
+	// Entries expire after the TTL
+	now = now.Add(time.Minute)
+	get(otherDatabase, "org1")
+	if n := requests["GET "+otherDatabase]; n != 2 {
+		t.Errorf("expected the expired entry to be fetched again, got %d", n)
+	}
+}
+
+func TestCacheInvalidationRoot(t *testing.T) {
+	cases := map[string]string{
+		"/v2/databases":                       "/v2/databases",
+		"/v2/databases/1234/keyspaces/ks1":    "/v2/databases/1234",
+		"/v2/streaming/tenants/t1/clusters/c": "/v2/streaming/tenants",
+		"/admin/v2/persistent/t1/ns1/topic1":  "/admin/v2",
+	}
+	for path, root := range cases {
+		if got := cacheInvalidationRoot(path); got != root {
+			t.Errorf("cacheInvalidationRoot(%q) = %q, expected %q", path, got, root)
+		}
+	}
+}
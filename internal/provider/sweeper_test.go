@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// defaultSweepPrefix is the name prefix of the databases, tenants and roles created by the acceptance tests. Only
+// objects with the prefix are deleted by the sweepers, except the existing database and streaming tenant the tests
+// are configured to use.
+const defaultSweepPrefix = "terraform-test"
+
+// TestMain runs the sweepers when the -sweep flag is set, for example
+//
+//	go test ./internal/provider -v -sweep=all
+//
+// Astra objects are not regional, so the value of the flag is ignored. Set ASTRA_TEST_SWEEP_PREFIX to sweep another
+// prefix.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("astra_cdc", &resource.Sweeper{
+		Name: "astra_cdc",
+		F:    sweepCDC,
+	})
+	resource.AddTestSweepers("astra_streaming_tenant", &resource.Sweeper{
+		Name:         "astra_streaming_tenant",
+		F:            sweepStreamingTenants,
+		Dependencies: []string{"astra_cdc"},
+	})
+	resource.AddTestSweepers("astra_database", &resource.Sweeper{
+		Name:         "astra_database",
+		F:            sweepDatabases,
+		Dependencies: []string{"astra_cdc"},
+	})
+	resource.AddTestSweepers("astra_token", &resource.Sweeper{
+		Name: "astra_token",
+		F:    sweepTokens,
+	})
+	resource.AddTestSweepers("astra_role", &resource.Sweeper{
+		Name:         "astra_role",
+		F:            sweepRoles,
+		Dependencies: []string{"astra_token"},
+	})
+}
+
+func sweepPrefix() string {
+	if prefix := os.Getenv("ASTRA_TEST_SWEEP_PREFIX"); prefix != "" {
+		return prefix
+	}
+	return defaultSweepPrefix
+}
+
+// sweepClients configures the provider from the environment, like the acceptance tests
+func sweepClients() (astraClients, error) {
+	p := New("sweep")()
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return astraClients{}, fmt.Errorf("failed to configure provider: %v", diags)
+	}
+	return p.Meta().(astraClients), nil
+}
+
+// sweepStreamingTenantList returns the streaming tenants of the organization with the sweep prefix
+func sweepStreamingTenantList(ctx context.Context, clients astraClients) (StreamingClusters, error) {
+	streamingClient := clients.astraStreamingClient.(*astrastreaming.ClientWithResponses)
+	resp, err := streamingClient.GetTenantsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("error listing streaming tenants: %s", string(resp.Body))
+	}
+	var tenants StreamingClusters
+	if err := json.Unmarshal(resp.Body, &tenants); err != nil {
+		return nil, fmt.Errorf("failed to decode streaming tenants: %w", err)
+	}
+	swept := tenants[:0]
+	for _, tenant := range tenants {
+		if strings.HasPrefix(tenant.TenantName, sweepPrefix()) && tenant.TenantName != os.Getenv("ASTRA_TEST_STREAMING_TENANT") {
+			swept = append(swept, tenant)
+		}
+	}
+	return swept, nil
+}
+
+func sweepCDC(_ string) error {
+	ctx := context.Background()
+	clients, err := sweepClients()
+	if err != nil {
+		return err
+	}
+	astraClient := clients.astraClient.(*astra.ClientWithResponses)
+	streamingClient := clients.astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	orgID, err := getCurrentOrgID(ctx, astraClient)
+	if err != nil {
+		return err
+	}
+	tenants, err := sweepStreamingTenantList(ctx, clients)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, tenant := range tenants {
+		pulsarToken, err := getPulsarToken(ctx, tenant.ClusterName, clients.token, OrgId{ID: orgID}, nil, streamingClient, tenant.TenantName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		cdcResult, resp, err := getCDC(ctx, clients.astraStreamingClientv3, tenant.TenantName, &astrastreaming.GetCDCParams{
+			XDataStaxPulsarCluster: tenant.ClusterName,
+			Authorization:          pulsarToken,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		} else if !resp.success() {
+			errs = append(errs, fmt.Errorf("error listing CDC of tenant %s: %s", tenant.TenantName, resp.Body))
+			continue
+		}
+
+		for _, cdc := range cdcResult {
+			log.Printf("[INFO] Deleting CDC of table %s.%s in tenant %s", cdc.Keyspace, cdc.DatabaseTable, tenant.TenantName)
+			deleteResp, err := deleteCDC(ctx, clients.astraStreamingClientv3, tenant.TenantName, &astrastreaming.DeleteCDCParams{
+				XDataStaxPulsarCluster: tenant.ClusterName,
+				Authorization:          pulsarToken,
+			}, astrastreaming.DeleteCDCJSONRequestBody{
+				DatabaseId:   cdc.DatabaseID,
+				DatabaseName: cdc.DatabaseName,
+				Keyspace:     cdc.Keyspace,
+				OrgId:        orgID,
+				TableName:    cdc.DatabaseTable,
+			})
+			if err != nil {
+				errs = append(errs, err)
+			} else if !deleteResp.success() {
+				errs = append(errs, fmt.Errorf("error deleting CDC of table %s.%s in tenant %s: %s", cdc.Keyspace, cdc.DatabaseTable, tenant.TenantName, deleteResp.Body))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepStreamingTenants(_ string) error {
+	ctx := context.Background()
+	clients, err := sweepClients()
+	if err != nil {
+		return err
+	}
+	streamingClient := clients.astraStreamingClient.(*astrastreaming.ClientWithResponses)
+
+	tenants, err := sweepStreamingTenantList(ctx, clients)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, tenant := range tenants {
+		log.Printf("[INFO] Deleting streaming tenant %s", tenant.TenantName)
+		resp, err := streamingClient.DeleteStreamingTenantWithResponse(ctx, tenant.TenantName, tenant.ClusterName, &astrastreaming.DeleteStreamingTenantParams{})
+		if err != nil {
+			errs = append(errs, err)
+		} else if resp.StatusCode() >= http.StatusBadRequest && resp.StatusCode() != http.StatusNotFound {
+			errs = append(errs, fmt.Errorf("error deleting streaming tenant %s: %s", tenant.TenantName, string(resp.Body)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepDatabases(_ string) error {
+	ctx := context.Background()
+	clients, err := sweepClients()
+	if err != nil {
+		return err
+	}
+	client := clients.astraClient.(*astra.ClientWithResponses)
+
+	dbs, err := listDatabases(ctx, client, &astra.ListDatabasesParams{})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, db := range dbs {
+		name := astra.StringValue(db.Info.Name)
+		if !strings.HasPrefix(name, sweepPrefix()) || db.Id == os.Getenv("ASTRA_TEST_DATABASE_ID") ||
+			db.Status == astra.TERMINATED || db.Status == astra.TERMINATING {
+			continue
+		}
+		log.Printf("[INFO] Terminating database %s (%s)", name, db.Id)
+		resp, err := client.TerminateDatabaseWithResponse(ctx, astra.DatabaseIdParam(db.Id), &astra.TerminateDatabaseParams{})
+		if err != nil {
+			errs = append(errs, err)
+		} else if resp.StatusCode() >= http.StatusBadRequest && resp.StatusCode() != http.StatusNotFound {
+			errs = append(errs, fmt.Errorf("error terminating database %s: %s", name, string(resp.Body)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepRoleIDs returns the IDs of the custom roles with the sweep prefix
+func sweepRoleIDs(ctx context.Context, client *astra.ClientWithResponses) (map[string]bool, error) {
+	resp, err := client.GetOrganizationRolesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("error listing roles: %s", string(resp.Body))
+	}
+	roleIDs := map[string]bool{}
+	for _, role := range getRoleSlice(resp.JSON200) {
+		if role.Id != nil && strings.HasPrefix(astra.StringValue(role.Name), sweepPrefix()) {
+			roleIDs[*role.Id] = true
+		}
+	}
+	return roleIDs, nil
+}
+
+// sweepTokens deletes the tokens which only have roles with the sweep prefix, since tokens have no name
+func sweepTokens(_ string) error {
+	ctx := context.Background()
+	clients, err := sweepClients()
+	if err != nil {
+		return err
+	}
+	client := clients.astraClient.(*astra.ClientWithResponses)
+
+	roleIDs, err := sweepRoleIDs(ctx, client)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetClientsForOrgWithResponse(ctx)
+	if err != nil {
+		return err
+	} else if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return fmt.Errorf("error listing tokens: %s", string(resp.Body))
+	}
+	tokens, _ := (*resp.JSON200).(map[string]interface{})["clients"].([]interface{})
+
+	var errs []error
+	for _, t := range tokens {
+		token, _ := t.(map[string]interface{})
+		clientID, _ := token["clientId"].(string)
+		roles, _ := token["roles"].([]interface{})
+		if clientID == "" || len(roles) == 0 {
+			continue
+		}
+		swept := true
+		for _, role := range roles {
+			if !roleIDs[fmt.Sprint(role)] {
+				swept = false
+				break
+			}
+		}
+		if !swept {
+			continue
+		}
+		log.Printf("[INFO] Deleting token %s", clientID)
+		deleteResp, err := client.DeleteTokenForClientWithResponse(ctx, astra.ClientIdParam(clientID))
+		if err != nil {
+			errs = append(errs, err)
+		} else if deleteResp.StatusCode() >= http.StatusBadRequest && deleteResp.StatusCode() != http.StatusNotFound {
+			errs = append(errs, fmt.Errorf("error deleting token %s: %s", clientID, string(deleteResp.Body)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepRoles(_ string) error {
+	ctx := context.Background()
+	clients, err := sweepClients()
+	if err != nil {
+		return err
+	}
+	client := clients.astraClient.(*astra.ClientWithResponses)
+
+	roleIDs, err := sweepRoleIDs(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for roleID := range roleIDs {
+		log.Printf("[INFO] Deleting role %s", roleID)
+		resp, err := client.DeleteOrganizationRoleWithResponse(ctx, astra.RoleIdParam(roleID))
+		if err != nil {
+			errs = append(errs, err)
+		} else if resp.StatusCode() >= http.StatusBadRequest && resp.StatusCode() != http.StatusNotFound {
+			errs = append(errs, fmt.Errorf("error deleting role %s: %s", roleID, string(resp.Body)))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands require a POSIX shell")
+	}
+	ctx := context.Background()
+
+	if token, err := runTokenCommand(ctx, "echo '  AstraCS:token  '"); err != nil || token != "AstraCS:token" {
+		t.Fatalf("expected the printed token, got %q, %v", token, err)
+	}
+	if _, err := runTokenCommand(ctx, "echo 'permission denied' >&2; exit 3"); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("expected the standard error of the failed command, got %v", err)
+	}
+	if _, err := runTokenCommand(ctx, "true"); err == nil {
+		t.Fatal("expected an error when the command does not print a token")
+	}
+}
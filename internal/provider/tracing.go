@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/datastax/terraform-provider-astra"
+
+// newTracerProvider returns a tracer provider exporting spans to the OTLP/HTTP endpoint, or a no-op tracer provider
+// when no endpoint is set. Spans are exported synchronously, because the provider process is stopped by Terraform
+// without a chance to flush batched spans.
+func newTracerProvider(ctx context.Context, endpoint, providerVersion string) (trace.TracerProvider, error) {
+	if endpoint == "" {
+		return noop.NewTracerProvider(), nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenTelemetry exporter endpoint: %w", err)
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName("terraform-provider-astra"),
+			semconv.ServiceVersion(providerVersion),
+		)),
+	), nil
+}
+
+// tracingTransport records a client span for every request sent to an Astra API, including its retries
+type tracingTransport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+	// api is the name of the API in the span names, for example "devops" or "streaming"
+	api string
+}
+
+// newTracingClient wraps the transport of the client to record a span for every request
+func newTracingClient(client *http.Client, tracerProvider trace.TracerProvider, api string) *http.Client {
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &tracingTransport{
+			base:   transport,
+			tracer: tracerProvider.Tracer(tracerName),
+			api:    api,
+		},
+		CheckRedirect: client.CheckRedirect,
+		Jar:           client.Jar,
+		Timeout:       client.Timeout,
+	}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), fmt.Sprintf("%s %s", req.Method, t.api),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+			attribute.String("astra.api", t.api),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingClient(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client := newTracingClient(server.Client(), tracerProvider, "devops")
+
+	for _, path := range []string{"/v2/databases", "/missing"} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name != "GET devops" || spans[0].Status.Code == codes.Error {
+		t.Fatalf("unexpected span: %s %v", spans[0].Name, spans[0].Status)
+	}
+	if spans[1].Status.Code != codes.Error {
+		t.Fatalf("expected the failed request to be an error, got %v", spans[1].Status)
+	}
+	if traceparent == "" {
+		t.Fatal("expected the trace context to be propagated")
+	}
+}
+
+func TestNewTracerProviderDisabled(t *testing.T) {
+	tracerProvider, err := newTracerProvider(context.Background(), "", "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tracerProvider.(*sdktrace.TracerProvider); ok {
+		t.Fatal("expected a no-op tracer provider without an exporter endpoint")
+	}
+}
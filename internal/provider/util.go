@@ -7,16 +7,67 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// mutexKV is a set of mutexes keyed by string, used to serialize API calls per resource (for example per database)
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock locks the mutex for the given key, creating it if needed
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock unlocks the mutex for the given key
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
 func ignoreCase(_, old, new string, _ *schema.ResourceData) bool {
 	return strings.EqualFold(old, new)
 }
 
+// normalizeRegion returns the region in lower case without dashes. The DevOps API names regions with dashes, like
+// us-east1, while the streaming APIs and Pulsar cluster names drop them, like useast1.
+func normalizeRegion(region string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(region), "-", ""))
+}
+
+// regionsEqual returns whether the regions are the same region written in different formats
+func regionsEqual(a, b string) bool {
+	return normalizeRegion(a) == normalizeRegion(b)
+}
+
+// ignoreRegionFormat ignores differences in case and dashes of region attributes
+func ignoreRegionFormat(_, old, new string, _ *schema.ResourceData) bool {
+	return regionsEqual(old, new)
+}
+
 func keyFromStrings(s []string) string {
 	ss := make([]string, len(s))
 	copy(ss, s)
@@ -28,6 +79,28 @@ func protectedFromDelete(resourceData *schema.ResourceData) bool {
 	return resourceData.Get("deletion_protection").(bool)
 }
 
+const (
+	deletionPolicyDelete  = "delete"
+	deletionPolicyAbandon = "abandon"
+)
+
+// deletionPolicySchema returns the deletion_policy attribute of resources whose underlying object can be kept when the
+// resource is destroyed, for example to move it to another Terraform configuration
+func deletionPolicySchema(object string) *schema.Schema {
+	return &schema.Schema{
+		Description:  fmt.Sprintf("What happens to the %s when the resource is destroyed. `delete` deletes it, `abandon` only removes it from the Terraform state and leaves it untouched. Defaults to `delete`.", object),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      deletionPolicyDelete,
+		ValidateFunc: validation.StringInSlice([]string{deletionPolicyDelete, deletionPolicyAbandon}, false),
+	}
+}
+
+// abandonedOnDelete returns whether the underlying object of the resource must be kept when the resource is destroyed
+func abandonedOnDelete(resourceData *schema.ResourceData) bool {
+	return resourceData.Get("deletion_policy").(string) == deletionPolicyAbandon
+}
+
 // checkRequiredTestVars returns true if the given environment variables are not empty
 func checkRequiredTestVars(t *testing.T, vars ...string) {
 	for _, v := range vars {
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
@@ -12,6 +13,36 @@ import (
 var keyspaceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_]{0,48}$`)
 var roleResourcePrefix = "drn:astra:org:"
 
+// databaseNameRegex matches database names of 2 to 50 characters which start and end with a letter or number
+var databaseNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9&+\-_()<>.,@]{0,48}[a-zA-Z0-9]$`)
+
+// streamingTenantNameRegex matches tenant names of 2 to 63 lowercase alphanumeric characters and hyphens
+var streamingTenantNameRegex = regexp.MustCompile(`^[a-z][-a-z0-9]{0,61}[a-z0-9]$`)
+
+// reservedStreamingTenantPrefixes are used by the Pulsar system tenants and cannot start a tenant name
+var reservedStreamingTenantPrefixes = []string{"pulsar"}
+
+// cqlIdentifierRegex matches unquoted CQL identifiers of up to 48 characters
+var cqlIdentifierRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,47}$`)
+
+// cqlReservedWords are the CQL keywords which cannot be used as unquoted identifiers
+var cqlReservedWords = map[string]bool{
+	"add": true, "allow": true, "alter": true, "and": true, "apply": true, "asc": true, "authorize": true,
+	"batch": true, "begin": true, "by": true, "columnfamily": true, "create": true, "delete": true, "desc": true,
+	"describe": true, "drop": true, "entries": true, "execute": true, "from": true, "full": true, "grant": true,
+	"if": true, "in": true, "index": true, "infinity": true, "insert": true, "into": true, "keyspace": true,
+	"limit": true, "modify": true, "nan": true, "norecursive": true, "not": true, "null": true, "of": true,
+	"on": true, "or": true, "order": true, "primary": true, "rename": true, "replace": true, "revoke": true,
+	"schema": true, "select": true, "set": true, "table": true, "to": true, "token": true, "truncate": true,
+	"unlogged": true, "update": true, "use": true, "using": true, "view": true, "where": true, "with": true,
+}
+
+// pulsarNameRegex matches the characters allowed by Pulsar in namespace and topic names
+var pulsarNameRegex = regexp.MustCompile(`^[-=:.\w]+$`)
+
+// pulsarPartitionSuffix is appended by Pulsar to the names of the partitions of a partitioned topic
+var pulsarPartitionSuffix = "-partition-"
+
 func validateKeyspace(v interface{}, path cty.Path) diag.Diagnostics {
 	keyspaceName := v.(string)
 
@@ -29,6 +60,159 @@ func validateKeyspace(v interface{}, path cty.Path) diag.Diagnostics {
 	return nil
 }
 
+// validateCQLIdentifier validates table, collection, index and column names
+func validateCQLIdentifier(v interface{}, path cty.Path) diag.Diagnostics {
+	identifier := v.(string)
+
+	if !cqlIdentifierRegex.MatchString(identifier) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid CQL identifier",
+				Detail:        fmt.Sprintf("\"%s\": invalid CQL identifier - must match %s", identifier, cqlIdentifierRegex),
+				AttributePath: path,
+			},
+		}
+	}
+	if cqlReservedWords[strings.ToLower(identifier)] {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid CQL identifier",
+				Detail:        fmt.Sprintf("\"%s\": invalid CQL identifier - \"%s\" is a reserved CQL keyword", identifier, strings.ToLower(identifier)),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+func validateDatabaseName(v interface{}, path cty.Path) diag.Diagnostics {
+	databaseName := v.(string)
+
+	if !databaseNameRegex.MatchString(databaseName) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid database name",
+				Detail:        fmt.Sprintf("\"%s\": invalid database name - must be 2 to 50 characters, start and end with a letter or number, and only contain letters, numbers and the characters & + - _ ( ) < > . , @", databaseName),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+func validateStreamingTenantName(v interface{}, path cty.Path) diag.Diagnostics {
+	tenantName := v.(string)
+
+	if !streamingTenantNameRegex.MatchString(tenantName) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid streaming tenant name",
+				Detail:        fmt.Sprintf("\"%s\": invalid streaming tenant name - must be 2 to 63 characters, start with a lowercase letter, end with a lowercase letter or number, and only contain lowercase letters, numbers and hyphens", tenantName),
+				AttributePath: path,
+			},
+		}
+	}
+	for _, prefix := range reservedStreamingTenantPrefixes {
+		if strings.HasPrefix(tenantName, prefix) {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid streaming tenant name",
+					Detail:        fmt.Sprintf("\"%s\": invalid streaming tenant name - the prefix \"%s\" is reserved", tenantName, prefix),
+					AttributePath: path,
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateStreamingNamespaceName(v interface{}, path cty.Path) diag.Diagnostics {
+	namespace := v.(string)
+
+	if !pulsarNameRegex.MatchString(namespace) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid namespace name",
+				Detail:        fmt.Sprintf("\"%s\": invalid namespace name - must match %s", namespace, pulsarNameRegex),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+func validateStreamingTopicName(v interface{}, path cty.Path) diag.Diagnostics {
+	topic := v.(string)
+
+	if !pulsarNameRegex.MatchString(topic) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid topic name",
+				Detail:        fmt.Sprintf("\"%s\": invalid topic name - must match %s. Use the topic name without the persistent://tenant/namespace/ prefix", topic, pulsarNameRegex),
+				AttributePath: path,
+			},
+		}
+	}
+	if strings.Contains(topic, pulsarPartitionSuffix) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid topic name",
+				Detail:        fmt.Sprintf("\"%s\": invalid topic name - \"%s\" is reserved for the partitions of partitioned topics", topic, pulsarPartitionSuffix),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+func validateAccessListAddress(v interface{}, path cty.Path) diag.Diagnostics {
+	address := v.(string)
+
+	if _, err := parseAccessListAddress(address); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid access list address",
+				Detail:        fmt.Sprintf("\"%s\": invalid access list address - must be an IP address or a CIDR block", address),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+// parseAccessListAddress parses an IP address or CIDR block. A single IP address is returned as a /32 (or /128) network.
+func parseAccessListAddress(address string) (*net.IPNet, error) {
+	if strings.Contains(address, "/") {
+		_, network, err := net.ParseCIDR(address)
+		return network, err
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %s", address)
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		bits = 8 * net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
 func validateRoleResources(v interface{}, path cty.Path) diag.Diagnostics {
 	roleResource := v.(string)
 
@@ -0,0 +1,49 @@
+package resourceid
+
+// The formats of the IDs of the resources, by resource. The formats of single fields only check that the ID is not
+// empty and does not contain a slash.
+var (
+	// AccessList is the ID of astra_access_list: the ID of the database
+	AccessList = newFormat("access list", "{database_id}").lowerCased()
+	// CDC is the ID of astra_cdc
+	CDC = newFormat("cdc", "{database_id}/{keyspace}/{table}/{tenant_name}").lowerCased()
+	// CDCKeyspace is the ID of astra_cdc_keyspace
+	CDCKeyspace = newFormat("keyspace cdc", "{database_id}/{keyspace}/{tenant_name}")
+	// CDCKeyspaceImport is the import ID of astra_cdc_keyspace with the imported tables separated by commas
+	CDCKeyspaceImport = newFormat("keyspace cdc import", "{database_id}/{keyspace}/{tenant_name}/{tables}")
+	// Collection is the ID of astra_collection
+	Collection = newFormat("collection", "{database_id}/{namespace}/{name}")
+	// DataAPINamespace is the ID of astra_data_api_namespace
+	DataAPINamespace = newFormat("namespace", "{database_id}/namespace/{name}")
+	// Keyspace is the ID of astra_keyspace and of the keyspace datasource
+	Keyspace = newFormat("keyspace", "{database_id}/keyspace/{keyspace}")
+	// Keyspaces is the ID of astra_keyspaces
+	Keyspaces = newFormat("keyspaces", "{database_id}/keyspaces")
+	// KeyspacesImport is the import ID of astra_keyspaces: the names of the imported keyspaces are separated by commas
+	KeyspacesImport = newFormat("keyspaces import", "{database_id}/keyspaces/{names}")
+	// PrivateLink is the ID of astra_private_link. Service names can contain slashes, like the service attachments
+	// of GCP.
+	PrivateLink = newFormat("private link", "{database_id}/datacenter/{datacenter_id}/serviceNames/{service_name...}")
+	// PrivateLinkEndpoint is the ID of astra_private_link_endpoint
+	PrivateLinkEndpoint = newFormat("private link endpoint", "{database_id}/datacenter/{datacenter_id}/endpoint/{endpoint_id...}")
+	// PulsarTopic is the name of a Pulsar topic without the persistent:// prefix
+	PulsarTopic = newFormat("topic name", "{tenant}/{namespace}/{topic}")
+	// Role is the ID of astra_role
+	Role = newFormat("role", "{role_id}").lowerCased()
+	// StreamingFunction is the ID of astra_streaming_transform_function
+	StreamingFunction = newFormat("streaming function", "{tenant_name}/{namespace}/{name}")
+	// StreamingNamespace is the ID of the resources configuring the policies of a namespace of a streaming tenant
+	StreamingNamespace = newFormat("streaming namespace", "{tenant_name}/{cluster_name}/{namespace}")
+	// StreamingSink is the ID of astra_streaming_astra_db_sink, and the ID of astra_streaming_sink on import
+	StreamingSink = newFormat("streaming sink", "{tenant_name}/{namespace}/{sink_name}")
+	// StreamingTelemetry is the ID of astra_streaming_telemetry for the telemetry of a tenant
+	StreamingTelemetry = newFormat("streaming telemetry", "{tenant_name}/{cluster_name}")
+	// StreamingClusterTelemetry is the ID of astra_streaming_telemetry for the telemetry of a cluster
+	StreamingClusterTelemetry = newFormat("streaming cluster telemetry", "{cluster_name}")
+	// StreamingTenant is the ID of astra_streaming_tenant
+	StreamingTenant = newFormat("tenant", "{tenant_name}").lowerCased()
+	// Table is the ID of astra_table and of the table datasource
+	Table = newFormat("table", "{database_id}/{keyspace}/{table}")
+	// Token is the ID of astra_token: the client ID of the token
+	Token = newFormat("token", "{client_id}")
+)
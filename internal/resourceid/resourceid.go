@@ -0,0 +1,208 @@
+// Package resourceid formats and parses the composite IDs of the resources of the provider, like
+// database_id/keyspace/keyspace_name, so that the resources do not each split their IDs by hand.
+package resourceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is the format of a composite ID. It is described by templates holding the fields of the ID in braces,
+// separated by literals, for example {database_id}/keyspace/{keyspace}. A field cannot be empty nor contain a slash,
+// except the last field of a template when it is written {field...}, which matches the rest of the ID.
+//
+// The templates are the versions of the format, the latest one last. IDs are always formatted with the latest
+// version, while all the versions are parsed so that the IDs in existing states and import commands keep working
+// after a format changes. All the versions have the same fields, possibly in another order.
+type Format struct {
+	name      string
+	versions  []template
+	lowerCase bool
+}
+
+// template is a version of a format: literals[i] precedes fields[i], and the last literal is the suffix of the ID
+type template struct {
+	raw      string
+	literals []string
+	fields   []string
+	// rest is whether the last field matches the rest of the ID, including slashes
+	rest bool
+}
+
+// newFormat returns the format with the versions of its template, from the oldest to the latest. It panics when a
+// template is invalid, since the formats are defined by the package.
+func newFormat(name string, templates ...string) *Format {
+	if len(templates) == 0 {
+		panic(fmt.Sprintf("resourceid: format %s has no template", name))
+	}
+	f := &Format{name: name}
+	for _, raw := range templates {
+		t, err := parseTemplate(raw)
+		if err != nil {
+			panic(fmt.Sprintf("resourceid: invalid template %q of format %s: %v", raw, name, err))
+		}
+		f.versions = append(f.versions, t)
+	}
+	latest := f.latest()
+	for _, t := range f.versions {
+		if !sameFields(t.fields, latest.fields) {
+			panic(fmt.Sprintf("resourceid: template %q of format %s does not have the fields of %q", t.raw, name, latest.raw))
+		}
+	}
+	return f
+}
+
+// lowerCased returns the format with the IDs converted to lower case before they are parsed
+func (f *Format) lowerCased() *Format {
+	lower := *f
+	lower.lowerCase = true
+	return &lower
+}
+
+func (f *Format) latest() template {
+	return f.versions[len(f.versions)-1]
+}
+
+// Fields returns the names of the fields of the format, in the order of the values of Format and Parse
+func (f *Format) Fields() []string {
+	return append([]string(nil), f.latest().fields...)
+}
+
+// String returns the latest template of the format without the braces, for example database_id/keyspace/keyspace
+func (f *Format) String() string {
+	return strings.NewReplacer("{", "", "}", "", "...", "").Replace(f.latest().raw)
+}
+
+// Format returns the ID with the values of the fields. It panics when the number of values does not match the number
+// of fields, like a call with the wrong arguments.
+func (f *Format) Format(values ...string) string {
+	t := f.latest()
+	if len(values) != len(t.fields) {
+		panic(fmt.Sprintf("resourceid: format %s expects %d values, got %d", f.name, len(t.fields), len(values)))
+	}
+	var b strings.Builder
+	for i, value := range values {
+		b.WriteString(t.literals[i])
+		b.WriteString(value)
+	}
+	b.WriteString(t.literals[len(values)])
+	return b.String()
+}
+
+// Parse returns the values of the fields of the ID, in the order of Fields. The latest version of the format is tried
+// first.
+func (f *Format) Parse(id string) ([]string, error) {
+	if f.lowerCase {
+		id = strings.ToLower(id)
+	}
+	for i := len(f.versions) - 1; i >= 0; i-- {
+		values, ok := f.versions[i].parse(id)
+		if !ok {
+			continue
+		}
+		return f.reorder(f.versions[i], values), nil
+	}
+	return nil, fmt.Errorf("invalid %s id format: expected %s, got %q", f.name, f.String(), id)
+}
+
+// reorder returns the values parsed with a version of the format in the order of the fields of the latest version
+func (f *Format) reorder(t template, values []string) []string {
+	latest := f.latest()
+	if t.raw == latest.raw {
+		return values
+	}
+	byField := make(map[string]string, len(values))
+	for i, field := range t.fields {
+		byField[field] = values[i]
+	}
+	ordered := make([]string, len(latest.fields))
+	for i, field := range latest.fields {
+		ordered[i] = byField[field]
+	}
+	return ordered
+}
+
+func (t template) parse(id string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(id, t.literals[0])
+	if !ok {
+		return nil, false
+	}
+	if rest, ok = strings.CutSuffix(rest, t.literals[len(t.fields)]); !ok {
+		return nil, false
+	}
+	values := make([]string, len(t.fields))
+	for i := range t.fields {
+		last := i == len(t.fields)-1
+		value := rest
+		if !last {
+			var found bool
+			value, rest, found = strings.Cut(rest, t.literals[i+1])
+			if !found {
+				return nil, false
+			}
+		}
+		if value == "" || (strings.Contains(value, "/") && !(last && t.rest)) {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
+
+func parseTemplate(raw string) (template, error) {
+	t := template{raw: raw}
+	rest := raw
+	for {
+		literal, after, found := strings.Cut(rest, "{")
+		if strings.Contains(literal, "}") {
+			return t, fmt.Errorf("unexpected } in %q", literal)
+		}
+		t.literals = append(t.literals, literal)
+		if !found {
+			break
+		}
+		if t.rest {
+			return t, fmt.Errorf("field %s... must be the last field", t.fields[len(t.fields)-1])
+		}
+		if len(t.fields) > 0 && literal == "" {
+			return t, fmt.Errorf("fields %s and the next field must be separated", t.fields[len(t.fields)-1])
+		}
+		field, after, found := strings.Cut(after, "}")
+		if !found {
+			return t, fmt.Errorf("unclosed field %q", field)
+		}
+		if field, t.rest = strings.CutSuffix(field, "..."); field == "" {
+			return t, fmt.Errorf("empty field name")
+		}
+		for _, f := range t.fields {
+			if f == field {
+				return t, fmt.Errorf("duplicate field %s", field)
+			}
+		}
+		t.fields = append(t.fields, field)
+		rest = after
+	}
+	if len(t.fields) == 0 {
+		return t, fmt.Errorf("no field")
+	}
+	if t.rest && t.literals[len(t.fields)] != "" {
+		return t, fmt.Errorf("field %s... must end the template", t.fields[len(t.fields)-1])
+	}
+	return t, nil
+}
+
+func sameFields(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, field := range a {
+		set[field] = true
+	}
+	for _, field := range b {
+		if !set[field] {
+			return false
+		}
+	}
+	return true
+}
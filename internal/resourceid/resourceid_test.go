@@ -0,0 +1,236 @@
+package resourceid
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		format  *Format
+		values  []string
+		id      string
+		invalid []string
+	}{
+		{
+			format:  AccessList,
+			values:  []string{"5b70892f-e01a-4595-98e6-19ecc9985d50"},
+			id:      "5b70892f-e01a-4595-98e6-19ecc9985d50",
+			invalid: []string{"", "5b70892f-e01a-4595-98e6-19ecc9985d50/"},
+		},
+		{
+			format:  CDC,
+			values:  []string{"5b70892f-e01a-4595-98e6-19ecc9985d50", "ks1", "table1", "tenant1"},
+			id:      "5b70892f-e01a-4595-98e6-19ecc9985d50/ks1/table1/tenant1",
+			invalid: []string{"db/ks1/table1", "db/ks1/table1/tenant1/extra", "db//table1/tenant1"},
+		},
+		{
+			format:  CDCKeyspace,
+			values:  []string{"5b70892f-e01a-4595-98e6-19ecc9985d50", "ks1", "tenant1"},
+			id:      "5b70892f-e01a-4595-98e6-19ecc9985d50/ks1/tenant1",
+			invalid: []string{"db/ks1", "db/ks1/tenant1/extra", "db//tenant1"},
+		},
+		{
+			format:  CDCKeyspaceImport,
+			values:  []string{"5b70892f-e01a-4595-98e6-19ecc9985d50", "ks1", "tenant1", "table1,table2"},
+			id:      "5b70892f-e01a-4595-98e6-19ecc9985d50/ks1/tenant1/table1,table2",
+			invalid: []string{"db/ks1/tenant1", "db/ks1/tenant1/", "db/ks1/tenant1/table1/extra"},
+		},
+		{
+			format:  Collection,
+			values:  []string{"db", "default_keyspace", "products"},
+			id:      "db/default_keyspace/products",
+			invalid: []string{"db/default_keyspace", "db/default_keyspace/", "/default_keyspace/products"},
+		},
+		{
+			format:  DataAPINamespace,
+			values:  []string{"db", "ns1"},
+			id:      "db/namespace/ns1",
+			invalid: []string{"db/ns1", "db/namespace/", "db/namespace/ns1/extra"},
+		},
+		{
+			format:  Keyspace,
+			values:  []string{"db", "ks1"},
+			id:      "db/keyspace/ks1",
+			invalid: []string{"db/ks1", "db/keyspace/", "/keyspace/ks1", "db/keyspace/ks1/extra"},
+		},
+		{
+			format:  Keyspaces,
+			values:  []string{"db"},
+			id:      "db/keyspaces",
+			invalid: []string{"db", "/keyspaces", "db/keyspaces/ks1"},
+		},
+		{
+			format:  KeyspacesImport,
+			values:  []string{"db", "ks1,ks2"},
+			id:      "db/keyspaces/ks1,ks2",
+			invalid: []string{"db/keyspaces", "db/keyspaces/", "db/keyspaces/ks1/extra"},
+		},
+		{
+			format:  PrivateLink,
+			values:  []string{"db", "db-1", "projects/p1/regions/us-east1/serviceAttachments/sa1"},
+			id:      "db/datacenter/db-1/serviceNames/projects/p1/regions/us-east1/serviceAttachments/sa1",
+			invalid: []string{"db/datacenter/db-1", "db/datacenter/db-1/serviceNames/", "db/dc/db-1/serviceNames/svc"},
+		},
+		{
+			format:  PrivateLinkEndpoint,
+			values:  []string{"db", "db-1", "vpce-0123"},
+			id:      "db/datacenter/db-1/endpoint/vpce-0123",
+			invalid: []string{"db/datacenter/db-1", "db/datacenter/db-1/endpoint/", "db/datacenter//endpoint/vpce-0123"},
+		},
+		{
+			format:  PulsarTopic,
+			values:  []string{"tenant1", "default", "topic1"},
+			id:      "tenant1/default/topic1",
+			invalid: []string{"tenant1/default", "tenant1/default/topic1/extra", "tenant1//topic1"},
+		},
+		{
+			format:  Role,
+			values:  []string{"role1"},
+			id:      "role1",
+			invalid: []string{"", "role1/extra"},
+		},
+		{
+			format:  StreamingFunction,
+			values:  []string{"tenant1", "default", "transform1"},
+			id:      "tenant1/default/transform1",
+			invalid: []string{"tenant1/transform1", "tenant1/default/", "tenant1/default/transform1/extra"},
+		},
+		{
+			format:  StreamingNamespace,
+			values:  []string{"tenant1", "pulsar-gcp-useast1", "default"},
+			id:      "tenant1/pulsar-gcp-useast1/default",
+			invalid: []string{"tenant1/pulsar-gcp-useast1", "tenant1//default", "tenant1/pulsar-gcp-useast1/default/extra"},
+		},
+		{
+			format:  StreamingSink,
+			values:  []string{"tenant1", "default", "sink1"},
+			id:      "tenant1/default/sink1",
+			invalid: []string{"tenant1/sink1", "tenant1/default/"},
+		},
+		{
+			format:  StreamingTelemetry,
+			values:  []string{"tenant1", "pulsar-gcp-useast1"},
+			id:      "tenant1/pulsar-gcp-useast1",
+			invalid: []string{"pulsar-gcp-useast1", "tenant1/", "tenant1/pulsar-gcp-useast1/extra"},
+		},
+		{
+			format:  StreamingClusterTelemetry,
+			values:  []string{"pulsar-gcp-useast1"},
+			id:      "pulsar-gcp-useast1",
+			invalid: []string{"", "tenant1/pulsar-gcp-useast1"},
+		},
+		{
+			format:  StreamingTenant,
+			values:  []string{"tenant1"},
+			id:      "tenant1",
+			invalid: []string{"", "tenant1/"},
+		},
+		{
+			format:  Table,
+			values:  []string{"db", "ks1", "table1"},
+			id:      "db/ks1/table1",
+			invalid: []string{"db/ks1", "db/ks1/", "db/ks1/table1/extra"},
+		},
+		{
+			format:  Token,
+			values:  []string{"AbCdEf"},
+			id:      "AbCdEf",
+			invalid: []string{"", "AbCdEf/"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.format.name, func(t *testing.T) {
+			if id := test.format.Format(test.values...); id != test.id {
+				t.Fatalf("expected ID %q, got %q", test.id, id)
+			}
+			values, err := test.format.Parse(test.id)
+			if err != nil {
+				t.Fatalf("failed to parse ID %q: %v", test.id, err)
+			}
+			if !reflect.DeepEqual(values, test.values) {
+				t.Fatalf("expected values %v, got %v", test.values, values)
+			}
+			if len(test.format.Fields()) != len(test.values) {
+				t.Fatalf("expected %d fields, got %v", len(test.values), test.format.Fields())
+			}
+			for _, id := range test.invalid {
+				if values, err := test.format.Parse(id); err == nil {
+					t.Fatalf("expected an error parsing ID %q, got %v", id, values)
+				} else if !strings.Contains(err.Error(), "expected "+test.format.String()) {
+					t.Fatalf("expected the error to contain the format, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatLowerCase(t *testing.T) {
+	values, err := CDC.Parse("DB/Ks1/Table1/Tenant1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"db", "ks1", "table1", "tenant1"}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected values %v, got %v", expected, values)
+	}
+	values, err = Token.Parse("AbCdEf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0] != "AbCdEf" {
+		t.Fatalf("expected the case of the token ID to be kept, got %s", values[0])
+	}
+}
+
+func TestFormatVersions(t *testing.T) {
+	f := newFormat("topic", "{topic}@{tenant_name}/{namespace}", "{tenant_name}/{namespace}/{topic}")
+	if id := f.Format("tenant1", "default", "topic1"); id != "tenant1/default/topic1" {
+		t.Fatalf("expected the latest version to be formatted, got %s", id)
+	}
+	for _, id := range []string{"tenant1/default/topic1", "topic1@tenant1/default"} {
+		values, err := f.Parse(id)
+		if err != nil {
+			t.Fatalf("failed to parse ID %q: %v", id, err)
+		}
+		if expected := []string{"tenant1", "default", "topic1"}; !reflect.DeepEqual(values, expected) {
+			t.Fatalf("expected values %v for ID %q, got %v", expected, id, values)
+		}
+	}
+	if _, err := f.Parse("topic1@tenant1"); err == nil {
+		t.Fatal("expected an error for an ID matching no version")
+	}
+}
+
+func TestFormatInvalidTemplates(t *testing.T) {
+	for _, templates := range [][]string{
+		{},
+		{"database_id"},
+		{"{database_id}{keyspace}"},
+		{"{database_id"},
+		{"{}"},
+		{"{database_id}/{database_id}"},
+		{"{service_name...}/{database_id}"},
+		{"{service_name...}/suffix"},
+		{"{database_id}/}"},
+		{"{database_id}/{keyspace}", "{database_id}"},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected templates %q to be invalid", templates)
+				}
+			}()
+			newFormat("invalid", templates...)
+		}()
+	}
+}
+
+func TestFormatValueCount(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected formatting with the wrong number of values to panic")
+		}
+	}()
+	Keyspace.Format("db")
+}
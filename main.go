@@ -6,7 +6,10 @@ import (
 	"log"
 
 	"github.com/datastax/terraform-provider-astra/v2/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -34,15 +37,24 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: provider.New(version)}
+	ctx := context.Background()
 
+	// The SDK provider implements the resources and data sources, the framework provider the provider functions
+	providers := []func() tfprotov5.ProviderServer{
+		provider.New(version)().GRPCProvider,
+		providerserver.NewProtocol5(provider.NewFrameworkProvider(version)()),
+	}
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/datastax/astra", opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	if err := tf5server.Serve("registry.terraform.io/datastax/astra", muxServer.ProviderServer, serveOpts...); err != nil {
+		log.Fatal(err.Error())
+	}
 }